@@ -1,48 +1,52 @@
 package database
 
 import (
-    "database/sql"
-    "log"
-	"os"
-	"sync"
-	"time"
+	"api-order/src/config"
+	"database/sql"
+	"fmt"
+	"log/slog"
 
+	"github.com/XSAM/otelsql"
 	_ "github.com/go-sql-driver/mysql"
-	"github.com/joho/godotenv"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	_ "modernc.org/sqlite"
 )
 
-var (
-	instance *sql.DB
-	once     sync.Once
-)
-
-func Connect() (*sql.DB, error){
-	once.Do(func() {
-		if err := godotenv.Load(); err != nil {
-			log.Fatalf("Error loading .env file: %v", err)
-		}
-
-		dsn := os.Getenv("DB_USER") + ":" + os.Getenv("DB_PASSWORD") + "@tcp(" + os.Getenv("DB_HOST") + ":" + os.Getenv("DB_PORT") + ")/" + os.Getenv("DB_NAME") + "?charset=utf8mb4&parseTime=True&loc=Local"
-
-		db, err := sql.Open("mysql", dsn)
-		if err != nil {
-			log.Fatalf("Error connecting to database: %v", err)
+// Open connects to the configured database and verifies the connection. With
+// cfg.AutoMigrate the pending schema migrations are applied first. The caller
+// owns the returned pool and passes it to the repositories that need it,
+// along with the query deadlines of NewTimeouts.
+func Open(cfg config.DatabaseConfig) (*sql.DB, error) {
+	driver := cfg.Driver
+	if driver == "" {
+		driver = config.DriverMySQL
+	}
+	driverName := driver
+	if driver == config.DriverPostgres {
+		driverName = "pgx"
+	}
+	db, err := otelsql.Open(driverName, cfg.DSN(), tracingOptions(driver)...)
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to database: %w", err)
+	}
+
+	// Configuration max connection
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error pinging database: %w", err)
+	}
+
+	if cfg.AutoMigrate {
+		if err := Migrate(db, cfg); err != nil {
+			db.Close()
+			return nil, err
 		}
+	}
 
-		// Configuration max connection 
-		db.SetMaxOpenConns(25)  
-		db.SetMaxIdleConns(25)  
-		db.SetConnMaxLifetime(1 * time.Minute)  
-
-		err = db.Ping()
-		if err != nil {
-            log.Fatalf("Error pinging database: %v", err)
-        }
-
-		instance = db
-		log.Println("Connected to database successfully")
-
-	})
-
-	return instance, nil
-}
\ No newline at end of file
+	slog.Info("Connected to database", "driver", driver)
+	return db, nil
+}
@@ -0,0 +1,68 @@
+package database
+
+import (
+	"api-order/src/shared/domainerrors"
+	"errors"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// MySQL server error numbers, see
+// https://dev.mysql.com/doc/mysql-errors/8.0/en/server-error-reference.html
+const (
+	mysqlDuplicateEntry  = 1062
+	mysqlNoReferencedRow = 1452
+)
+
+// PostgreSQL SQLSTATE codes of the integrity constraint violation class.
+const (
+	postgresUniqueViolation     = "23505"
+	postgresForeignKeyViolation = "23503"
+)
+
+// TranslateError maps the constraint violations of every supported driver to
+// domain errors: a duplicate key becomes domainerrors.ErrDuplicate and a
+// missing foreign key row domainerrors.ErrMissingReference, both wrapping err.
+// Any other error is returned unchanged. Repositories call it on the result of
+// their writes and may check the translated error to return a more specific one.
+func TranslateError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		switch mysqlErr.Number {
+		case mysqlDuplicateEntry:
+			return domainerrors.ErrDuplicate.Wrap(err)
+		case mysqlNoReferencedRow:
+			return domainerrors.ErrMissingReference.Wrap(err)
+		}
+		return err
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		switch pgErr.Code {
+		case postgresUniqueViolation:
+			return domainerrors.ErrDuplicate.Wrap(err)
+		case postgresForeignKeyViolation:
+			return domainerrors.ErrMissingReference.Wrap(err)
+		}
+		return err
+	}
+
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) {
+		switch sqliteErr.Code() {
+		case sqlite3.SQLITE_CONSTRAINT_UNIQUE, sqlite3.SQLITE_CONSTRAINT_PRIMARYKEY:
+			return domainerrors.ErrDuplicate.Wrap(err)
+		case sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY:
+			return domainerrors.ErrMissingReference.Wrap(err)
+		}
+	}
+	return err
+}
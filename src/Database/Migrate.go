@@ -0,0 +1,266 @@
+package database
+
+import (
+	"api-order/src/config"
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+)
+
+//go:embed migrations
+var migrationFiles embed.FS
+
+// ErrIrreversible is returned by Migrator.Down for a migration without a
+// down script.
+var ErrIrreversible = errors.New("migration cannot be reverted")
+
+// Migration is one schema version: <version>.up.sql applies it and the
+// optional <version>.down.sql reverts it.
+type Migration struct {
+	Version string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus tells whether a migration is applied and since when.
+type MigrationStatus struct {
+	Version   string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Migrator applies and reverts the migrations embedded for one driver and
+// records the applied versions in schema_migrations.
+type Migrator struct {
+	db          *sql.DB
+	migrations  []Migration
+	createTable string
+	bind        func(string) string
+}
+
+// NewMigrator loads the migrations of cfg.Driver. PostgreSQL in TimescaleDB
+// mode also gets the hypertable migrations, after the plain ones.
+func NewMigrator(db *sql.DB, cfg config.DatabaseConfig) (*Migrator, error) {
+	m := &Migrator{db: db, bind: func(query string) string { return query }}
+	var dirs []string
+	switch cfg.Driver {
+	case "", config.DriverMySQL:
+		dirs = []string{"migrations/mysql"}
+		m.createTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+            version VARCHAR(255) PRIMARY KEY,
+            applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+        )`
+	case config.DriverSQLite:
+		dirs = []string{"migrations/sqlite"}
+		m.createTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+            version TEXT PRIMARY KEY,
+            applied_at DATETIME NOT NULL DEFAULT (` + SQLiteNow + `)
+        )`
+	case config.DriverPostgres:
+		dirs = []string{"migrations/postgres"}
+		if cfg.Timescale {
+			dirs = append(dirs, "migrations/timescale")
+		}
+		m.createTable = `CREATE TABLE IF NOT EXISTS schema_migrations (
+            version TEXT PRIMARY KEY,
+            applied_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+        )`
+		m.bind = Rebind
+	default:
+		return nil, fmt.Errorf("no migrations for driver %q", cfg.Driver)
+	}
+
+	for _, dir := range dirs {
+		migrations, err := loadMigrations(dir)
+		if err != nil {
+			return nil, err
+		}
+		m.migrations = append(m.migrations, migrations...)
+	}
+	return m, nil
+}
+
+// loadMigrations reads the up/down pairs of dir in version order.
+func loadMigrations(dir string) ([]Migration, error) {
+	names, err := fs.Glob(migrationFiles, dir+"/*.up.sql")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	migrations := make([]Migration, 0, len(names))
+	for _, name := range names {
+		up, err := migrationFiles.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		migration := Migration{
+			Version: strings.TrimSuffix(name[strings.LastIndex(name, "/")+1:], ".up.sql"),
+			Up:      string(up),
+		}
+		down, err := migrationFiles.ReadFile(strings.TrimSuffix(name, ".up.sql") + ".down.sql")
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+		migration.Down = string(down)
+		migrations = append(migrations, migration)
+	}
+	return migrations, nil
+}
+
+// Migrate applies the pending migrations of cfg.Driver to db.
+func Migrate(db *sql.DB, cfg config.DatabaseConfig) error {
+	migrator, err := NewMigrator(db, cfg)
+	if err != nil {
+		return err
+	}
+	_, err = migrator.Up(context.Background())
+	return err
+}
+
+// Up applies every pending migration in order and returns their versions.
+func (m *Migrator) Up(ctx context.Context) ([]string, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	for _, migration := range m.migrations {
+		if _, ok := applied[migration.Version]; ok {
+			continue
+		}
+		record := m.bind("INSERT INTO schema_migrations (version) VALUES (?)")
+		if err := m.run(ctx, migration.Version, migration.Up, record); err != nil {
+			return versions, err
+		}
+		slog.InfoContext(ctx, "Applied migration", "version", migration.Version)
+		versions = append(versions, migration.Version)
+	}
+	return versions, nil
+}
+
+// Down reverts the last steps applied migrations, newest first, and returns
+// their versions.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]string, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []string
+	for i := len(m.migrations) - 1; i >= 0 && len(versions) < steps; i-- {
+		migration := m.migrations[i]
+		if _, ok := applied[migration.Version]; !ok {
+			continue
+		}
+		if strings.TrimSpace(migration.Down) == "" {
+			return versions, fmt.Errorf("%s: %w", migration.Version, ErrIrreversible)
+		}
+		forget := m.bind("DELETE FROM schema_migrations WHERE version = ?")
+		if err := m.run(ctx, migration.Version, migration.Down, forget); err != nil {
+			return versions, err
+		}
+		slog.InfoContext(ctx, "Reverted migration", "version", migration.Version)
+		versions = append(versions, migration.Version)
+	}
+	return versions, nil
+}
+
+// Status lists every known migration in order.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, len(m.migrations))
+	for i, migration := range m.migrations {
+		appliedAt, ok := applied[migration.Version]
+		statuses[i] = MigrationStatus{Version: migration.Version, Applied: ok, AppliedAt: appliedAt}
+	}
+	return statuses, nil
+}
+
+// applied reads schema_migrations, creating it on first use.
+func (m *Migrator) applied(ctx context.Context) (map[string]time.Time, error) {
+	if _, err := m.db.ExecContext(ctx, m.createTable); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	rows, err := m.db.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[string]time.Time)
+	for rows.Next() {
+		var version string
+		var appliedAt time.Time
+		// SQLiteTime also accepts the time.Time the other drivers return
+		if err := rows.Scan(&version, SQLiteTime(&appliedAt)); err != nil {
+			return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+		}
+		applied[version] = appliedAt
+	}
+	return applied, rows.Err()
+}
+
+// run executes the statements of script and the bookkeeping statement in one
+// transaction. MySQL commits DDL implicitly, so there a failing script can
+// leave its earlier statements applied.
+func (m *Migrator) run(ctx context.Context, version, script, bookkeeping string) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin migration %s: %w", version, err)
+	}
+	defer tx.Rollback()
+
+	for _, statement := range splitStatements(script) {
+		if _, err := tx.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("migration %s failed: %w", version, err)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, bookkeeping, version); err != nil {
+		return fmt.Errorf("failed to record migration %s: %w", version, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration %s: %w", version, err)
+	}
+	return nil
+}
+
+// splitStatements cuts a script into statements at lines ending with ";",
+// since the MySQL driver runs a single statement per call. Comment-only
+// chunks are dropped.
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+	flush := func() {
+		statement := strings.TrimSpace(current.String())
+		current.Reset()
+		for _, line := range strings.Split(statement, "\n") {
+			if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "--") {
+				statements = append(statements, statement)
+				return
+			}
+		}
+	}
+	for _, line := range strings.Split(script, "\n") {
+		current.WriteString(line)
+		current.WriteString("\n")
+		if strings.HasSuffix(strings.TrimSpace(line), ";") {
+			flush()
+		}
+	}
+	flush()
+	return statements
+}
@@ -0,0 +1,67 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+)
+
+// RunMigrateCommand implements `api migrate`, which manages the schema of the
+// configured database:
+//
+//	api migrate up               apply every pending migration
+//	api migrate down [-steps 1]  revert the latest applied migrations
+//	api migrate status           list the migrations and whether they are applied
+func RunMigrateCommand(migrator *Migrator, args []string, stdout io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("usage: migrate up|down|status")
+	}
+	ctx := context.Background()
+
+	switch args[0] {
+	case "up":
+		versions, err := migrator.Up(ctx)
+		for _, version := range versions {
+			fmt.Fprintf(stdout, "applied %s\n", version)
+		}
+		if err == nil && len(versions) == 0 {
+			fmt.Fprintln(stdout, "schema is up to date")
+		}
+		return err
+
+	case "down":
+		flags := flag.NewFlagSet("migrate down", flag.ContinueOnError)
+		steps := flags.Int("steps", 1, "number of migrations to revert")
+		if err := flags.Parse(args[1:]); err != nil {
+			return err
+		}
+		if *steps <= 0 {
+			return errors.New("-steps must be positive")
+		}
+		versions, err := migrator.Down(ctx, *steps)
+		for _, version := range versions {
+			fmt.Fprintf(stdout, "reverted %s\n", version)
+		}
+		return err
+
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			state := "pending"
+			if status.Applied {
+				state = "applied " + status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(stdout, "%-40s %s\n", status.Version, state)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown migrate command %q, want up, down or status", args[0])
+	}
+}
@@ -0,0 +1,28 @@
+package database
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Rebind rewrites the ? placeholders of query into PostgreSQL's $1, $2, ...,
+// so the PostgreSQL repositories can share query fragments such as
+// pagination.KeysetClause with the other dialects. Question marks inside
+// string literals are left alone.
+func Rebind(query string) string {
+	var b strings.Builder
+	b.Grow(len(query) + 8)
+	n, quoted := 0, false
+	for _, c := range query {
+		switch {
+		case c == '\'':
+			quoted = !quoted
+		case c == '?' && !quoted:
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
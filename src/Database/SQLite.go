@@ -0,0 +1,69 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// SQLiteTimeLayout is how the SQLite repositories store times: UTC, fixed
+// width and microsecond precision. Stored times therefore compare and sort
+// correctly as text, which keeps the time indexes usable.
+//
+// strftime only resolves milliseconds, so times taken from the database clock
+// are padded with zeros (see SQLiteNow). A stored time never exceeds the real
+// one, which keeps exclusive upper bounds such as "timestamp < now" exact.
+const SQLiteTimeLayout = "2006-01-02 15:04:05.000000"
+
+// SQLiteNow is the SQL expression of the current time in SQLiteTimeLayout.
+const SQLiteNow = "(strftime('%Y-%m-%d %H:%M:%f', 'now') || '000')"
+
+// SQLiteArgs converts the time.Time arguments of a query to SQLiteTimeLayout
+// and leaves the others untouched.
+func SQLiteArgs(args ...interface{}) []interface{} {
+	converted := make([]interface{}, len(args))
+	for i, arg := range args {
+		if t, ok := arg.(time.Time); ok {
+			arg = t.UTC().Format(SQLiteTimeLayout)
+		}
+		converted[i] = arg
+	}
+	return converted
+}
+
+// SQLiteTime returns a rows.Scan destination that reads a stored time into t.
+// The driver only parses columns declared DATETIME; expressions such as
+// MAX(timestamp) arrive as text. NULL leaves t at the zero time.
+func SQLiteTime(t *time.Time) sql.Scanner {
+	return sqliteTime{t}
+}
+
+type sqliteTime struct {
+	dest *time.Time
+}
+
+func (s sqliteTime) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*s.dest = time.Time{}
+	case time.Time:
+		*s.dest = v
+	case string:
+		return s.parse(v)
+	case []byte:
+		return s.parse(string(v))
+	default:
+		return fmt.Errorf("cannot scan %T into a time", value)
+	}
+	return nil
+}
+
+func (s sqliteTime) parse(value string) error {
+	// The layout without fraction accepts any number of fractional digits
+	t, err := time.ParseInLocation("2006-01-02 15:04:05", value, time.UTC)
+	if err != nil {
+		return fmt.Errorf("invalid stored time %q: %w", value, err)
+	}
+	*s.dest = t
+	return nil
+}
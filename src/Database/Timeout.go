@@ -0,0 +1,67 @@
+package database
+
+import (
+	"api-order/src/config"
+	"context"
+	"time"
+)
+
+// Operation classifies a repository call so it gets the matching deadline.
+type Operation int
+
+const (
+	// OpRead covers single queries and short lists.
+	OpRead Operation = iota
+	// OpWrite covers inserts and updates.
+	OpWrite
+	// OpBatch covers rollups, pruning, exports and other long scans.
+	OpBatch
+)
+
+// Timeouts holds the deadline of each operation class. It is built once from
+// the configuration and handed to every repository, so it is never written
+// while queries run.
+type Timeouts struct {
+	Read  time.Duration
+	Write time.Duration
+	Batch time.Duration
+}
+
+// DefaultTimeouts returns the deadlines config.LoadDatabase defaults to.
+func DefaultTimeouts() Timeouts {
+	return Timeouts{Read: 5 * time.Second, Write: 5 * time.Second, Batch: 2 * time.Minute}
+}
+
+// NewTimeouts reads the deadlines of cfg; zero values keep the default.
+func NewTimeouts(cfg config.DatabaseConfig) Timeouts {
+	timeouts := DefaultTimeouts()
+	if cfg.ReadTimeout > 0 {
+		timeouts.Read = cfg.ReadTimeout
+	}
+	if cfg.WriteTimeout > 0 {
+		timeouts.Write = cfg.WriteTimeout
+	}
+	if cfg.BatchTimeout > 0 {
+		timeouts.Batch = cfg.BatchTimeout
+	}
+	return timeouts
+}
+
+// Timeout returns the deadline of an operation class.
+func (t Timeouts) Timeout(op Operation) time.Duration {
+	switch op {
+	case OpWrite:
+		return t.Write
+	case OpBatch:
+		return t.Batch
+	default:
+		return t.Read
+	}
+}
+
+// WithTimeout derives a context bounded by the deadline of the operation class.
+// A tighter deadline already set on ctx (or its cancellation, e.g. when the
+// HTTP client disconnects) still applies.
+func (t Timeouts) WithTimeout(ctx context.Context, op Operation) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, t.Timeout(op))
+}
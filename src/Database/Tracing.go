@@ -0,0 +1,73 @@
+package database
+
+import (
+	"api-order/src/config"
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"strings"
+
+	"github.com/XSAM/otelsql"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// queryTable finds the main table of a statement: the one read from, written
+// into or updated.
+var queryTable = regexp.MustCompile(`(?is)\b(?:from|into|update|table(?: if not exists)?)\s+["` + "`" + `]?(\w+)`)
+
+// QueryName summarises a statement for span names and dashboards, e.g.
+// "SELECT garden_data" or "INSERT metric_samples".
+func QueryName(query string) string {
+	fields := strings.Fields(query)
+	// Skip leading comments of the migration files.
+	for len(fields) > 0 && strings.HasPrefix(fields[0], "--") {
+		line := strings.Index(query, "\n")
+		if line < 0 {
+			return ""
+		}
+		query = query[line+1:]
+		fields = strings.Fields(query)
+	}
+	if len(fields) == 0 {
+		return ""
+	}
+	verb := strings.ToUpper(fields[0])
+	if match := queryTable.FindStringSubmatch(query); match != nil {
+		return verb + " " + match[1]
+	}
+	return verb
+}
+
+// tracingOptions instruments database/sql: every query, exec and transaction
+// becomes a span named after the method and the query, with the statement as
+// an attribute.
+func tracingOptions(driverName string) []otelsql.Option {
+	system := semconv.DBSystemMySQL
+	switch driverName {
+	case config.DriverSQLite:
+		system = semconv.DBSystemSqlite
+	case config.DriverPostgres:
+		system = semconv.DBSystemPostgreSQL
+	}
+	return []otelsql.Option{
+		otelsql.WithAttributes(system),
+		otelsql.WithSpanNameFormatter(func(ctx context.Context, method otelsql.Method, query string) string {
+			if name := QueryName(query); name != "" {
+				return string(method) + " " + name
+			}
+			return string(method)
+		}),
+		otelsql.WithAttributesGetter(func(ctx context.Context, method otelsql.Method, query string, args []driver.NamedValue) []attribute.KeyValue {
+			if name := QueryName(query); name != "" {
+				return []attribute.KeyValue{attribute.String("db.query.name", name)}
+			}
+			return nil
+		}),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{
+			// Rows iteration and pings would only add noise to each trace.
+			OmitRows:             true,
+			OmitConnResetSession: true,
+		}),
+	}
+}
@@ -0,0 +1,53 @@
+package database
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestMySQLMigrationsSplitIntoStatements checks what can be checked without
+// a server: the driver runs one statement per call, so no statement may hold
+// a second one, and every migration can be reverted.
+func TestMySQLMigrationsSplitIntoStatements(t *testing.T) {
+	migrations, err := loadMigrations("migrations/mysql")
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	if len(migrations) == 0 || migrations[0].Version != "0001_initial_schema" {
+		t.Fatalf("got %d migrations, want 0001_initial_schema first", len(migrations))
+	}
+	for _, migration := range migrations {
+		if migration.Down == "" {
+			t.Errorf("%s has no down script", migration.Version)
+		}
+		for _, script := range []string{migration.Up, migration.Down} {
+			for _, statement := range splitStatements(script) {
+				var code []string
+				for _, line := range strings.Split(statement, "\n") {
+					if !strings.HasPrefix(strings.TrimSpace(line), "--") {
+						code = append(code, line)
+					}
+				}
+				if sql := strings.Join(code, "\n"); strings.Count(sql, ";") != 1 || !strings.HasSuffix(sql, ";") {
+					t.Errorf("%s: not a single statement: %q", migration.Version, statement)
+				}
+			}
+		}
+	}
+}
+
+// TestMySQLInitialSchemaIsTheBaseline keeps the first migration at the schema
+// databases set up by hand already have, so adopting it does not skip the
+// columns added later.
+func TestMySQLInitialSchemaIsTheBaseline(t *testing.T) {
+	migrations, err := loadMigrations("migrations/mysql")
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	initial := migrations[0].Up
+	for _, later := range []string{"metric", "kit_sensors", "anomaly_settings", "event_time", "clock_skew_seconds", "sampling_interval_seconds"} {
+		if strings.Contains(initial, later) {
+			t.Errorf("initial schema already has %s", later)
+		}
+	}
+}
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS garden_data;
+DROP TABLE IF EXISTS alerts;
+DROP TABLE IF EXISTS kits;
+DROP TABLE IF EXISTS users;
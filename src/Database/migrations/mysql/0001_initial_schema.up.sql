@@ -0,0 +1,48 @@
+-- Initial MySQL schema, as the repositories used it before migrations
+-- existed. Tables are created only when missing, so databases set up by hand
+-- adopt this version as is and get the later columns from the migrations
+-- that follow.
+
+CREATE TABLE IF NOT EXISTS users (
+    id         BIGINT AUTO_INCREMENT PRIMARY KEY,
+    first_name VARCHAR(100) NOT NULL,
+    last_name  VARCHAR(100) NOT NULL,
+    email      VARCHAR(255) NOT NULL,
+    password   VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_users_email (email)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS kits (
+    kit_id      BIGINT AUTO_INCREMENT PRIMARY KEY,
+    user_id     BIGINT NOT NULL,
+    name        VARCHAR(255) NOT NULL,
+    description VARCHAR(1000) NOT NULL DEFAULT '',
+    created_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    KEY idx_kits_user (user_id),
+    KEY idx_kits_name (name),
+    CONSTRAINT fk_kits_user FOREIGN KEY (user_id) REFERENCES users (id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS alerts (
+    alert_id   BIGINT AUTO_INCREMENT PRIMARY KEY,
+    kit_id     BIGINT NOT NULL,
+    alert_type VARCHAR(50) NOT NULL,
+    message    VARCHAR(1000) NOT NULL,
+    timestamp  TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    KEY idx_alerts_kit_time (kit_id, timestamp, alert_id),
+    CONSTRAINT fk_alerts_kit FOREIGN KEY (kit_id) REFERENCES kits (kit_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS garden_data (
+    data_id             BIGINT AUTO_INCREMENT PRIMARY KEY,
+    kit_id              BIGINT NOT NULL,
+    temperature         DOUBLE NOT NULL DEFAULT 0,
+    ground_humidity     DOUBLE NOT NULL DEFAULT 0,
+    enviroment_humidity DOUBLE NOT NULL DEFAULT 0,
+    ph_level            DOUBLE NOT NULL DEFAULT 0,
+    time                BIGINT NOT NULL,
+    timestamp           TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    KEY idx_garden_data_kit_time (kit_id, timestamp, data_id),
+    CONSTRAINT fk_garden_data_kit FOREIGN KEY (kit_id) REFERENCES kits (kit_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
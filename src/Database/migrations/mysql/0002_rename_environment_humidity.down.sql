@@ -0,0 +1 @@
+ALTER TABLE garden_data RENAME COLUMN environment_humidity TO enviroment_humidity;
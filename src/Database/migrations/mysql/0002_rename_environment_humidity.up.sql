@@ -0,0 +1,2 @@
+-- Fixes the spelling of garden_data.enviroment_humidity.
+ALTER TABLE garden_data RENAME COLUMN enviroment_humidity TO environment_humidity;
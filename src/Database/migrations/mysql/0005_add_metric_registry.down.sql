@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS metric_samples;
+DROP TABLE IF EXISTS kit_sensors;
+DROP TABLE IF EXISTS metrics;
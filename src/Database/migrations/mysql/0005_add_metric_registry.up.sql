@@ -0,0 +1,54 @@
+-- Metric registry, the sensors each kit declares and one row per reading and
+-- metric. Readings stored before carry their values in the fixed columns of
+-- garden_data only, so they get their samples here.
+
+CREATE TABLE IF NOT EXISTS metrics (
+    metric_id   BIGINT AUTO_INCREMENT PRIMARY KEY,
+    name        VARCHAR(64) NOT NULL,
+    unit        VARCHAR(32) NOT NULL,
+    min_value   DOUBLE NOT NULL,
+    max_value   DOUBLE NOT NULL,
+    description VARCHAR(1000) NOT NULL DEFAULT '',
+    created_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_metrics_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS kit_sensors (
+    kit_id      BIGINT NOT NULL,
+    metric_name VARCHAR(64) NOT NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (kit_id, metric_name),
+    CONSTRAINT fk_kit_sensors_kit FOREIGN KEY (kit_id) REFERENCES kits (kit_id),
+    CONSTRAINT fk_kit_sensors_metric FOREIGN KEY (metric_name) REFERENCES metrics (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+CREATE TABLE IF NOT EXISTS metric_samples (
+    sample_id   BIGINT AUTO_INCREMENT PRIMARY KEY,
+    data_id     BIGINT NOT NULL,
+    kit_id      BIGINT NOT NULL,
+    metric_name VARCHAR(64) NOT NULL,
+    value       DOUBLE NOT NULL,
+    time        BIGINT NOT NULL,
+    timestamp   TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+    KEY idx_metric_samples_kit_time (kit_id, timestamp, sample_id),
+    KEY idx_metric_samples_data (data_id),
+    CONSTRAINT fk_metric_samples_data FOREIGN KEY (data_id) REFERENCES garden_data (data_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+INSERT INTO metric_samples (data_id, kit_id, metric_name, value, time, timestamp)
+SELECT d.data_id, d.kit_id, m.metric_name,
+       CASE m.metric_name
+           WHEN 'temperature' THEN d.temperature
+           WHEN 'ground_humidity' THEN d.ground_humidity
+           WHEN 'environment_humidity' THEN d.environment_humidity
+           ELSE d.ph_level
+       END,
+       d.time, d.timestamp
+FROM garden_data d
+CROSS JOIN (
+    SELECT 'temperature' AS metric_name
+    UNION ALL SELECT 'ground_humidity'
+    UNION ALL SELECT 'environment_humidity'
+    UNION ALL SELECT 'ph_level'
+) m
+WHERE NOT EXISTS (SELECT 1 FROM metric_samples s WHERE s.data_id = d.data_id);
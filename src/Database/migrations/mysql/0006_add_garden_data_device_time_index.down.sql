@@ -0,0 +1 @@
+DROP INDEX idx_garden_data_kit_device_time ON garden_data;
@@ -0,0 +1,2 @@
+-- Lets imports find the readings a kit already stored for a device time.
+CREATE INDEX idx_garden_data_kit_device_time ON garden_data (kit_id, time);
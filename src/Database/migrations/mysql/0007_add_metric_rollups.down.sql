@@ -0,0 +1,5 @@
+DROP INDEX idx_metric_samples_time ON metric_samples;
+DROP INDEX idx_garden_data_rollup_state ON garden_data;
+DROP INDEX idx_garden_data_time ON garden_data;
+ALTER TABLE garden_data DROP COLUMN rollup_state;
+DROP TABLE IF EXISTS metric_rollups;
@@ -0,0 +1,22 @@
+-- Hourly and daily aggregates that outlive the raw readings, and the indexes
+-- the compaction scans the raw readings by. rollup_state is 0 for readings
+-- waiting for the compaction, 1 while it rolls them up and 2 once they are in
+-- the rollups.
+
+CREATE TABLE IF NOT EXISTS metric_rollups (
+    kit_id       BIGINT NOT NULL,
+    metric_name  VARCHAR(64) NOT NULL,
+    granularity  VARCHAR(8) NOT NULL,
+    bucket_start DATETIME NOT NULL,
+    sample_count BIGINT NOT NULL,
+    min_value    DOUBLE NOT NULL,
+    max_value    DOUBLE NOT NULL,
+    avg_value    DOUBLE NOT NULL,
+    PRIMARY KEY (kit_id, metric_name, granularity, bucket_start),
+    KEY idx_metric_rollups_granularity (granularity, bucket_start)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
+
+ALTER TABLE garden_data ADD COLUMN rollup_state TINYINT NOT NULL DEFAULT 0;
+CREATE INDEX idx_garden_data_time ON garden_data (timestamp);
+CREATE INDEX idx_garden_data_rollup_state ON garden_data (rollup_state, timestamp);
+CREATE INDEX idx_metric_samples_time ON metric_samples (timestamp);
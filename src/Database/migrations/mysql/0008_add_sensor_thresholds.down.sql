@@ -0,0 +1,2 @@
+ALTER TABLE kit_sensors DROP COLUMN max_threshold;
+ALTER TABLE kit_sensors DROP COLUMN min_threshold;
@@ -0,0 +1,3 @@
+-- Optional bounds of each kit sensor, for the statistics of the kit.
+ALTER TABLE kit_sensors ADD COLUMN min_threshold DOUBLE NULL;
+ALTER TABLE kit_sensors ADD COLUMN max_threshold DOUBLE NULL;
@@ -0,0 +1,9 @@
+-- Per-kit tuning of the anomaly detector; kits without a row use the defaults.
+CREATE TABLE IF NOT EXISTS anomaly_settings (
+    kit_id            BIGINT PRIMARY KEY,
+    enabled           BOOLEAN NOT NULL,
+    z_score_threshold DOUBLE NOT NULL,
+    spike_fraction    DOUBLE NOT NULL,
+    flatline_minutes  INT NOT NULL,
+    CONSTRAINT fk_anomaly_settings_kit FOREIGN KEY (kit_id) REFERENCES kits (kit_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4;
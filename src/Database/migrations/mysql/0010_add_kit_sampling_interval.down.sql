@@ -0,0 +1 @@
+ALTER TABLE kits DROP COLUMN sampling_interval_seconds;
@@ -0,0 +1,2 @@
+-- How often a kit is expected to report, for the completeness report.
+ALTER TABLE kits ADD COLUMN sampling_interval_seconds INT NOT NULL DEFAULT 300;
@@ -0,0 +1,7 @@
+DROP INDEX idx_metric_samples_kit_event_time ON metric_samples;
+DROP INDEX idx_garden_data_kit_event_time ON garden_data;
+ALTER TABLE metric_samples DROP COLUMN event_time;
+ALTER TABLE garden_data DROP COLUMN clock_skew_seconds;
+ALTER TABLE garden_data DROP COLUMN event_time;
+ALTER TABLE kits DROP COLUMN clock_skew_seconds;
+ALTER TABLE kits DROP COLUMN clock_drift_flagged;
@@ -0,0 +1,14 @@
+-- Device clock skew of each kit and reading, and the event time of readings
+-- corrected by it. Readings stored before are backfilled with their server
+-- timestamp, so event_time can be filtered and sorted on through its index.
+ALTER TABLE kits ADD COLUMN clock_drift_flagged BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE kits ADD COLUMN clock_skew_seconds BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE garden_data ADD COLUMN event_time TIMESTAMP(6) NULL DEFAULT NULL;
+ALTER TABLE garden_data ADD COLUMN clock_skew_seconds BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE metric_samples ADD COLUMN event_time TIMESTAMP(6) NULL DEFAULT NULL;
+UPDATE garden_data SET event_time = timestamp;
+UPDATE metric_samples SET event_time = timestamp;
+ALTER TABLE garden_data MODIFY COLUMN event_time TIMESTAMP(6) NOT NULL;
+ALTER TABLE metric_samples MODIFY COLUMN event_time TIMESTAMP(6) NOT NULL;
+CREATE INDEX idx_garden_data_kit_event_time ON garden_data (kit_id, event_time, data_id);
+CREATE INDEX idx_metric_samples_kit_event_time ON metric_samples (kit_id, event_time, sample_id);
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS anomaly_settings;
+DROP TABLE IF EXISTS metric_rollups;
+DROP TABLE IF EXISTS metric_samples;
+DROP TABLE IF EXISTS garden_data;
+DROP TABLE IF EXISTS kit_sensors;
+DROP TABLE IF EXISTS metrics;
+DROP TABLE IF EXISTS alerts;
+DROP TABLE IF EXISTS kits;
+DROP TABLE IF EXISTS users;
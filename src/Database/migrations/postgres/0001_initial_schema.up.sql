@@ -0,0 +1,107 @@
+-- Initial PostgreSQL schema.
+
+CREATE TABLE users (
+    id         BIGSERIAL PRIMARY KEY,
+    first_name TEXT NOT NULL,
+    last_name  TEXT NOT NULL,
+    email      TEXT NOT NULL UNIQUE,
+    password   TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE kits (
+    kit_id                    BIGSERIAL PRIMARY KEY,
+    user_id                   BIGINT NOT NULL REFERENCES users (id),
+    name                      TEXT NOT NULL,
+    description               TEXT NOT NULL DEFAULT '',
+    sampling_interval_seconds INTEGER NOT NULL DEFAULT 300,
+    clock_drift_flagged       BOOLEAN NOT NULL DEFAULT FALSE,
+    clock_skew_seconds        BIGINT NOT NULL DEFAULT 0,
+    created_at                TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_kits_user ON kits (user_id);
+CREATE INDEX idx_kits_name ON kits (name);
+
+CREATE TABLE alerts (
+    alert_id   BIGSERIAL PRIMARY KEY,
+    kit_id     BIGINT NOT NULL REFERENCES kits (kit_id),
+    alert_type TEXT NOT NULL,
+    message    TEXT NOT NULL,
+    timestamp  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX idx_alerts_kit_time ON alerts (kit_id, timestamp, alert_id);
+
+CREATE TABLE metrics (
+    metric_id   BIGSERIAL PRIMARY KEY,
+    name        TEXT NOT NULL UNIQUE,
+    unit        TEXT NOT NULL,
+    min_value   DOUBLE PRECISION NOT NULL,
+    max_value   DOUBLE PRECISION NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE kit_sensors (
+    kit_id        BIGINT NOT NULL REFERENCES kits (kit_id),
+    metric_name   TEXT NOT NULL REFERENCES metrics (name),
+    min_threshold DOUBLE PRECISION,
+    max_threshold DOUBLE PRECISION,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (kit_id, metric_name)
+);
+
+CREATE TABLE garden_data (
+    data_id              BIGSERIAL PRIMARY KEY,
+    kit_id               BIGINT NOT NULL REFERENCES kits (kit_id),
+    temperature          DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ground_humidity      DOUBLE PRECISION NOT NULL DEFAULT 0,
+    environment_humidity DOUBLE PRECISION NOT NULL DEFAULT 0,
+    ph_level             DOUBLE PRECISION NOT NULL DEFAULT 0,
+    time                 BIGINT NOT NULL,
+    timestamp            TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    event_time           TIMESTAMPTZ NOT NULL,
+    clock_skew_seconds   BIGINT NOT NULL DEFAULT 0,
+    -- 0 waits for the compaction, 1 is being rolled up, 2 is in the rollups
+    rollup_state         SMALLINT NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_garden_data_kit_time ON garden_data (kit_id, timestamp, data_id);
+CREATE INDEX idx_garden_data_kit_event_time ON garden_data (kit_id, event_time, data_id);
+CREATE INDEX idx_garden_data_kit_device_time ON garden_data (kit_id, time);
+CREATE INDEX idx_garden_data_time ON garden_data (timestamp);
+CREATE INDEX idx_garden_data_rollup_state ON garden_data (rollup_state, timestamp);
+
+CREATE TABLE metric_samples (
+    sample_id   BIGSERIAL PRIMARY KEY,
+    data_id     BIGINT NOT NULL REFERENCES garden_data (data_id),
+    kit_id      BIGINT NOT NULL,
+    metric_name TEXT NOT NULL,
+    value       DOUBLE PRECISION NOT NULL,
+    time        BIGINT NOT NULL,
+    timestamp   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    event_time  TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_metric_samples_kit_time ON metric_samples (kit_id, timestamp, sample_id);
+CREATE INDEX idx_metric_samples_kit_event_time ON metric_samples (kit_id, event_time, sample_id);
+CREATE INDEX idx_metric_samples_data ON metric_samples (data_id);
+CREATE INDEX idx_metric_samples_time ON metric_samples (timestamp);
+
+CREATE TABLE metric_rollups (
+    kit_id       BIGINT NOT NULL,
+    metric_name  TEXT NOT NULL,
+    granularity  TEXT NOT NULL,
+    bucket_start TIMESTAMPTZ NOT NULL,
+    sample_count BIGINT NOT NULL,
+    min_value    DOUBLE PRECISION NOT NULL,
+    max_value    DOUBLE PRECISION NOT NULL,
+    avg_value    DOUBLE PRECISION NOT NULL,
+    PRIMARY KEY (kit_id, metric_name, granularity, bucket_start)
+);
+CREATE INDEX idx_metric_rollups_granularity ON metric_rollups (granularity, bucket_start);
+
+CREATE TABLE anomaly_settings (
+    kit_id            BIGINT PRIMARY KEY REFERENCES kits (kit_id),
+    enabled           BOOLEAN NOT NULL,
+    z_score_threshold DOUBLE PRECISION NOT NULL,
+    spike_fraction    DOUBLE PRECISION NOT NULL,
+    flatline_minutes  INTEGER NOT NULL
+);
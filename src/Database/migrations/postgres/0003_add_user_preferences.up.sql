@@ -0,0 +1,5 @@
+-- Display preferences of each user: temperature unit, timezone for local
+-- times and the default range of the dashboard in minutes.
+ALTER TABLE users ADD COLUMN temperature_unit TEXT NOT NULL DEFAULT 'C';
+ALTER TABLE users ADD COLUMN timezone TEXT NOT NULL DEFAULT 'UTC';
+ALTER TABLE users ADD COLUMN dashboard_range_minutes INTEGER NOT NULL DEFAULT 60;
@@ -0,0 +1,108 @@
+-- Initial SQLite schema. Times are stored as UTC text in
+-- database.SQLiteTimeLayout ('YYYY-MM-DD HH:MM:SS.SSSSSS').
+
+CREATE TABLE users (
+    id         INTEGER PRIMARY KEY AUTOINCREMENT,
+    first_name TEXT NOT NULL,
+    last_name  TEXT NOT NULL,
+    email      TEXT NOT NULL UNIQUE,
+    password   TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now') || '000')
+);
+
+CREATE TABLE kits (
+    kit_id                    INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_id                   INTEGER NOT NULL REFERENCES users (id),
+    name                      TEXT NOT NULL,
+    description               TEXT NOT NULL DEFAULT '',
+    sampling_interval_seconds INTEGER NOT NULL DEFAULT 300,
+    clock_drift_flagged       INTEGER NOT NULL DEFAULT 0,
+    clock_skew_seconds        INTEGER NOT NULL DEFAULT 0,
+    created_at                DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now') || '000')
+);
+CREATE INDEX idx_kits_user ON kits (user_id);
+CREATE INDEX idx_kits_name ON kits (name);
+
+CREATE TABLE alerts (
+    alert_id   INTEGER PRIMARY KEY AUTOINCREMENT,
+    kit_id     INTEGER NOT NULL REFERENCES kits (kit_id),
+    alert_type TEXT NOT NULL,
+    message    TEXT NOT NULL,
+    timestamp  DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now') || '000')
+);
+CREATE INDEX idx_alerts_kit_time ON alerts (kit_id, timestamp, alert_id);
+
+CREATE TABLE metrics (
+    metric_id   INTEGER PRIMARY KEY AUTOINCREMENT,
+    name        TEXT NOT NULL UNIQUE,
+    unit        TEXT NOT NULL,
+    min_value   REAL NOT NULL,
+    max_value   REAL NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at  DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now') || '000')
+);
+
+CREATE TABLE kit_sensors (
+    kit_id        INTEGER NOT NULL REFERENCES kits (kit_id),
+    metric_name   TEXT NOT NULL REFERENCES metrics (name),
+    min_threshold REAL,
+    max_threshold REAL,
+    created_at    DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now') || '000'),
+    PRIMARY KEY (kit_id, metric_name)
+);
+
+CREATE TABLE garden_data (
+    data_id              INTEGER PRIMARY KEY AUTOINCREMENT,
+    kit_id               INTEGER NOT NULL REFERENCES kits (kit_id),
+    temperature          REAL NOT NULL DEFAULT 0,
+    ground_humidity      REAL NOT NULL DEFAULT 0,
+    environment_humidity REAL NOT NULL DEFAULT 0,
+    ph_level             REAL NOT NULL DEFAULT 0,
+    time                 INTEGER NOT NULL,
+    timestamp            DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now') || '000'),
+    event_time           DATETIME NOT NULL,
+    clock_skew_seconds   INTEGER NOT NULL DEFAULT 0,
+    -- 0 waits for the compaction, 1 is being rolled up, 2 is in the rollups
+    rollup_state         INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_garden_data_kit_time ON garden_data (kit_id, timestamp, data_id);
+CREATE INDEX idx_garden_data_kit_event_time ON garden_data (kit_id, event_time, data_id);
+CREATE INDEX idx_garden_data_kit_device_time ON garden_data (kit_id, time);
+CREATE INDEX idx_garden_data_time ON garden_data (timestamp);
+CREATE INDEX idx_garden_data_rollup_state ON garden_data (rollup_state, timestamp);
+
+CREATE TABLE metric_samples (
+    sample_id   INTEGER PRIMARY KEY AUTOINCREMENT,
+    data_id     INTEGER NOT NULL REFERENCES garden_data (data_id),
+    kit_id      INTEGER NOT NULL,
+    metric_name TEXT NOT NULL,
+    value       REAL NOT NULL,
+    time        INTEGER NOT NULL,
+    timestamp   DATETIME NOT NULL DEFAULT (strftime('%Y-%m-%d %H:%M:%f', 'now') || '000'),
+    event_time  DATETIME NOT NULL
+);
+CREATE INDEX idx_metric_samples_kit_time ON metric_samples (kit_id, timestamp, sample_id);
+CREATE INDEX idx_metric_samples_kit_event_time ON metric_samples (kit_id, event_time, sample_id);
+CREATE INDEX idx_metric_samples_data ON metric_samples (data_id);
+CREATE INDEX idx_metric_samples_time ON metric_samples (timestamp);
+
+CREATE TABLE metric_rollups (
+    kit_id       INTEGER NOT NULL,
+    metric_name  TEXT NOT NULL,
+    granularity  TEXT NOT NULL,
+    bucket_start DATETIME NOT NULL,
+    sample_count INTEGER NOT NULL,
+    min_value    REAL NOT NULL,
+    max_value    REAL NOT NULL,
+    avg_value    REAL NOT NULL,
+    PRIMARY KEY (kit_id, metric_name, granularity, bucket_start)
+);
+CREATE INDEX idx_metric_rollups_granularity ON metric_rollups (granularity, bucket_start);
+
+CREATE TABLE anomaly_settings (
+    kit_id            INTEGER PRIMARY KEY REFERENCES kits (kit_id),
+    enabled           INTEGER NOT NULL,
+    z_score_threshold REAL NOT NULL,
+    spike_fraction    REAL NOT NULL,
+    flatline_minutes  INTEGER NOT NULL
+);
@@ -0,0 +1,2 @@
+-- Stores the language preference of each user; empty follows Accept-Language.
+ALTER TABLE users ADD COLUMN locale TEXT NOT NULL DEFAULT '';
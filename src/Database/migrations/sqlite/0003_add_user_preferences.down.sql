@@ -0,0 +1,3 @@
+ALTER TABLE users DROP COLUMN dashboard_range_minutes;
+ALTER TABLE users DROP COLUMN timezone;
+ALTER TABLE users DROP COLUMN temperature_unit;
@@ -0,0 +1,20 @@
+-- Turns the raw time series into TimescaleDB hypertables partitioned on
+-- their insertion time. Unique constraints of a hypertable must include the
+-- partitioning column, and metric_samples can no longer reference garden_data
+-- by data_id alone, so that foreign key is dropped; samples are always written
+-- in the same transaction as their record and pruned before it.
+--
+-- There is no down migration: hypertables cannot be turned back into plain
+-- tables in place.
+
+CREATE EXTENSION IF NOT EXISTS timescaledb;
+
+ALTER TABLE metric_samples DROP CONSTRAINT metric_samples_data_id_fkey;
+
+ALTER TABLE garden_data DROP CONSTRAINT garden_data_pkey;
+ALTER TABLE garden_data ADD PRIMARY KEY (data_id, timestamp);
+SELECT create_hypertable('garden_data', 'timestamp', migrate_data => TRUE);
+
+ALTER TABLE metric_samples DROP CONSTRAINT metric_samples_pkey;
+ALTER TABLE metric_samples ADD PRIMARY KEY (sample_id, timestamp);
+SELECT create_hypertable('metric_samples', 'timestamp', migrate_data => TRUE);
@@ -0,0 +1,17 @@
+package database
+
+import "testing"
+
+func TestQueryName(t *testing.T) {
+	for query, want := range map[string]string{
+		"SELECT id, time FROM garden_data WHERE kit_id = ?":    "SELECT garden_data",
+		"insert into metric_samples (kit_id) values ($1)":      "INSERT metric_samples",
+		"UPDATE \"kits\" SET sampling_interval = ?":            "UPDATE kits",
+		"-- create kits\nCREATE TABLE IF NOT EXISTS kits (id)": "CREATE kits",
+		"BEGIN": "BEGIN",
+	} {
+		if got := QueryName(query); got != want {
+			t.Errorf("QueryName(%q) = %q, want %q", query, got, want)
+		}
+	}
+}
@@ -3,8 +3,20 @@ package application
 import (
 	"api-order/src/alert/domain/entities" // Adjusted import path
 	"api-order/src/alert/domain/ports"    // Adjusted import path
+	"api-order/src/shared/pagination"
+	"api-order/src/shared/tracing"
+	"context"
+	"fmt"
 )
 
+// AlertsPageSpec describes the paging, sorting and filtering accepted by the alerts list.
+var AlertsPageSpec = pagination.Spec{
+	DefaultLimit: 50,
+	MaxLimit:     500,
+	Sorts:        []string{"-timestamp", "timestamp"},
+	Filters:      []string{"alert_type"},
+}
+
 type GetAlertsByKitIDUseCase struct {
 	AlertRepository ports.IAlert
 }
@@ -13,18 +25,29 @@ func NewGetAlertsByKitIDUseCase(alertRepo ports.IAlert) *GetAlertsByKitIDUseCase
 	return &GetAlertsByKitIDUseCase{AlertRepository: alertRepo}
 }
 
-// Run executes the logic to retrieve alerts for a specific kit ID
-func (uc *GetAlertsByKitIDUseCase) Run(kitID int) ([]entities.Alert, error) {
-	alerts, err := uc.AlertRepository.GetByKitID(kitID)
+// Run executes the logic to retrieve one page of alerts for a specific kit ID
+func (uc *GetAlertsByKitIDUseCase) Run(ctx context.Context, kitID int, params pagination.CursorParams) ([]entities.Alert, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "GetAlertsByKitIDUseCase.Run")
+	defer span.End()
+
+	if alertType, ok := params.Filters["alert_type"]; ok && !entities.IsValidAlertType(alertType) {
+		return nil, nil, fmt.Errorf("%w: invalid alert_type filter", pagination.ErrInvalidParams)
+	}
+
+	alerts, hasMore, err := uc.AlertRepository.GetByKitID(ctx, kitID, params)
 	if err != nil {
 		// Handle potential errors (e.g., DB connection issues)
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Return an empty slice if no alerts found, which is valid
 	if alerts == nil {
-		return []entities.Alert{}, nil
+		alerts = []entities.Alert{}
 	}
 
-	return alerts, nil
+	var last pagination.Cursor
+	if len(alerts) > 0 {
+		last = pagination.Cursor{Time: alerts[len(alerts)-1].Timestamp, ID: int64(alerts[len(alerts)-1].AlertID)}
+	}
+	return alerts, pagination.CursorPage(params, hasMore, last), nil
 }
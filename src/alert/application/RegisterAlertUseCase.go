@@ -3,23 +3,31 @@ package application
 import (
 	"api-order/src/alert/domain/entities" // Adjusted import path
 	"api-order/src/alert/domain/ports"    // Adjusted import path
-	"errors"                              // For custom validation errors
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/tracing"
+	"context"
 )
 
 type RegisterAlertUseCase struct {
 	AlertRepository ports.IAlert
 }
 
+// ErrInvalidAlertType is returned for an alert type that is not one of entities.AlertType*.
+var ErrInvalidAlertType = domainerrors.Validation("invalid_alert_type", "invalid alert_type provided")
+
 func NewRegisterAlertUseCase(alertRepo ports.IAlert) *RegisterAlertUseCase {
 	return &RegisterAlertUseCase{AlertRepository: alertRepo}
 }
 
 // Run executes the logic to register a new alert
 // Takes kitID, alertType, and message as input
-func (uc *RegisterAlertUseCase) Run(kitID int, alertType string, message string) (entities.Alert, error) {
+func (uc *RegisterAlertUseCase) Run(ctx context.Context, kitID int, alertType string, message string) (entities.Alert, error) {
+	ctx, span := tracing.Start(ctx, "RegisterAlertUseCase.Run")
+	defer span.End()
+
 	// Validate alert type against known constants
 	if !entities.IsValidAlertType(alertType) {
-		return entities.Alert{}, errors.New("invalid alert_type provided")
+		return entities.Alert{}, ErrInvalidAlertType
 	}
 
 	alert := entities.Alert{
@@ -29,9 +37,9 @@ func (uc *RegisterAlertUseCase) Run(kitID int, alertType string, message string)
 		// Timestamp will be set by the database default
 	}
 
-	createdAlert, err := uc.AlertRepository.Create(alert)
+	createdAlert, err := uc.AlertRepository.Create(ctx, alert)
 	if err != nil {
-		// Handle potential errors like invalid kit_id
+		// An unknown kit_id comes back as kit ports.ErrKitNotFound
 		return entities.Alert{}, err
 	}
 
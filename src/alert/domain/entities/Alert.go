@@ -6,13 +6,19 @@ import "time"
 const (
 	AlertTypeUnderMin  = "under_min"
 	AlertTypeHigherMax = "higher_max"
+	// Raised by the anomaly detector on incoming readings
+	AlertTypeAnomaly  = "anomaly"  // Reading far from the recent rolling average (z-score)
+	AlertTypeSpike    = "spike"    // Sudden jump between consecutive readings
+	AlertTypeFlatline = "flatline" // Sensor stuck at the same value for too long
+	// Raised by the gap watcher when a kit stops reporting
+	AlertTypeDataGap = "data_gap"
 	// Add other alert types here if needed
 )
 
 // IsValidAlertType checks if a given string is a valid alert type
 func IsValidAlertType(alertType string) bool {
 	switch alertType {
-	case AlertTypeUnderMin, AlertTypeHigherMax:
+	case AlertTypeUnderMin, AlertTypeHigherMax, AlertTypeAnomaly, AlertTypeSpike, AlertTypeFlatline, AlertTypeDataGap:
 		return true
 	default:
 		return false
@@ -1,11 +1,19 @@
 package ports
 
-import "api-order/src/alert/domain/entities" // Adjusted import path
+import (
+	"api-order/src/alert/domain/entities" // Adjusted import path
+	"api-order/src/shared/pagination"
+	"context"
+	"time"
+)
 
 // Interface for alert repository operations
 type IAlert interface {
 	// Creates a new alert record
-	Create(alert entities.Alert) (entities.Alert, error)
-	// Retrieves all alerts associated with a specific kit ID
-	GetByKitID(kitID int) ([]entities.Alert, error)
+	Create(ctx context.Context, alert entities.Alert) (entities.Alert, error)
+	// Retrieves one page of the alerts of a kit, keyset paginated on (timestamp, alert_id).
+	// The bool reports whether more alerts follow.
+	GetByKitID(ctx context.Context, kitID int, page pagination.CursorParams) ([]entities.Alert, bool, error)
+	// Counts the alerts of a kit raised in [from, to)
+	CountByKitIDBetween(ctx context.Context, kitID int, from, to time.Time) (int, error)
 }
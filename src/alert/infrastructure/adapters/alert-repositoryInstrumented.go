@@ -0,0 +1,27 @@
+package adapters
+
+import (
+	"api-order/src/alert/domain/entities"
+	"api-order/src/alert/domain/ports"
+	"api-order/src/shared/monitoring"
+	"context"
+)
+
+// AlertRepositoryInstrumented counts the alerts stored through another
+// repository by type, whichever module raised them.
+type AlertRepositoryInstrumented struct {
+	ports.IAlert
+}
+
+func NewAlertRepositoryInstrumented(inner ports.IAlert) *AlertRepositoryInstrumented {
+	return &AlertRepositoryInstrumented{IAlert: inner}
+}
+
+// Create implements ports.IAlert
+func (r *AlertRepositoryInstrumented) Create(ctx context.Context, alert entities.Alert) (entities.Alert, error) {
+	created, err := r.IAlert.Create(ctx, alert)
+	if err == nil {
+		monitoring.AlertsRaised.WithLabelValues(created.AlertType).Inc()
+	}
+	return created, err
+}
@@ -0,0 +1,72 @@
+package adapters
+
+import (
+	"api-order/src/alert/domain/entities"
+	"api-order/src/shared/pagination"
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// AlertRepositoryMemory keeps alerts in process memory. It backs the HTTP test
+// suite and pages alerts with the same keyset as the MySQL repository.
+type AlertRepositoryMemory struct {
+	mu     sync.RWMutex
+	alerts []entities.Alert
+}
+
+func NewAlertRepositoryMemory() *AlertRepositoryMemory {
+	return &AlertRepositoryMemory{}
+}
+
+// Create implements ports.IAlert
+func (r *AlertRepositoryMemory) Create(ctx context.Context, alert entities.Alert) (entities.Alert, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	alert.AlertID = len(r.alerts) + 1
+	alert.Timestamp = time.Now()
+	r.alerts = append(r.alerts, alert)
+	return alert, nil
+}
+
+// GetByKitID implements ports.IAlert
+func (r *AlertRepositoryMemory) GetByKitID(ctx context.Context, kitID int, page pagination.CursorParams) ([]entities.Alert, bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	alertType, filterByType := page.Filters["alert_type"]
+	alerts := []entities.Alert{}
+	for _, alert := range r.alerts {
+		if alert.KitID != kitID || (filterByType && alert.AlertType != alertType) {
+			continue
+		}
+		if pagination.KeysetAfter(page, alert.Timestamp, int64(alert.AlertID)) {
+			alerts = append(alerts, alert)
+		}
+	}
+	sort.Slice(alerts, func(i, j int) bool {
+		return pagination.KeysetLess(page, alerts[i].Timestamp, int64(alerts[i].AlertID), alerts[j].Timestamp, int64(alerts[j].AlertID))
+	})
+
+	if len(alerts) > page.Limit+1 {
+		alerts = alerts[:page.Limit+1]
+	}
+	alerts, hasMore := pagination.TrimPage(alerts, page.Limit)
+	return alerts, hasMore, nil
+}
+
+// CountByKitIDBetween implements ports.IAlert
+func (r *AlertRepositoryMemory) CountByKitIDBetween(ctx context.Context, kitID int, from, to time.Time) (int, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	count := 0
+	for _, alert := range r.alerts {
+		if alert.KitID == kitID && !alert.Timestamp.Before(from) && alert.Timestamp.Before(to) {
+			count++
+		}
+	}
+	return count, nil
+}
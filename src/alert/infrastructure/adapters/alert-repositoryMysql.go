@@ -3,42 +3,52 @@ package adapters
 import (
 	database "api-order/src/Database"     // Assuming shared DB connection setup
 	"api-order/src/alert/domain/entities" // Adjusted import path
+	kit "api-order/src/kit/domain/ports"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/logging"
+	"api-order/src/shared/pagination"
+	"context"
 	"database/sql"
-	"log"
+	"errors"
+	"time"
 )
 
 type AlertRepositoryMysql struct {
-	DB *sql.DB
+	DB       *sql.DB
+	Timeouts database.Timeouts
 }
 
-func NewAlertRepositoryMysql() (*AlertRepositoryMysql, error) {
-	db, err := database.Connect()
-	if err != nil {
-		return nil, err
-	}
-	return &AlertRepositoryMysql{DB: db}, nil
+// NewAlertRepositoryMysql wraps the shared connection pool.
+func NewAlertRepositoryMysql(db *sql.DB, timeouts database.Timeouts) *AlertRepositoryMysql {
+	return &AlertRepositoryMysql{DB: db, Timeouts: timeouts}
 }
 
 // Create implements ports.IAlert
-func (r *AlertRepositoryMysql) Create(alert entities.Alert) (entities.Alert, error) {
+func (r *AlertRepositoryMysql) Create(ctx context.Context, alert entities.Alert) (entities.Alert, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpWrite)
+	defer cancel()
+
 	query := "INSERT INTO alerts (kit_id, alert_type, message) VALUES (?, ?, ?)"
-	stmt, err := r.DB.Prepare(query)
+	stmt, err := r.DB.PrepareContext(ctx, query)
 	if err != nil {
-		log.Printf("Error preparing alert insert statement: %v", err)
+		logging.FromContext(ctx).Error("Error preparing alert insert statement", "error", err)
 		return entities.Alert{}, err
 	}
 	defer stmt.Close()
 
-	result, err := stmt.Exec(alert.KitID, alert.AlertType, alert.Message)
+	result, err := stmt.ExecContext(ctx, alert.KitID, alert.AlertType, alert.Message)
 	if err != nil {
-		log.Printf("Error executing alert insert statement: %v", err)
-		// Check for specific errors like foreign key violation
+		logging.FromContext(ctx).Error("Error executing alert insert statement", "error", err)
+		// kit_id is the only foreign key of alerts
+		if err = database.TranslateError(err); errors.Is(err, domainerrors.ErrMissingReference) {
+			return entities.Alert{}, kit.ErrKitNotFound.Wrap(err)
+		}
 		return entities.Alert{}, err
 	}
 
 	id, err := result.LastInsertId()
 	if err != nil {
-		log.Printf("Error getting last insert ID for alert: %v", err)
+		logging.FromContext(ctx).Error("Error getting last insert ID for alert", "error", err)
 		return entities.Alert{}, err
 	}
 
@@ -50,13 +60,25 @@ func (r *AlertRepositoryMysql) Create(alert entities.Alert) (entities.Alert, err
 }
 
 // GetByKitID implements ports.IAlert
-func (r *AlertRepositoryMysql) GetByKitID(kitID int) ([]entities.Alert, error) {
-	query := "SELECT alert_id, kit_id, alert_type, message, timestamp FROM alerts WHERE kit_id = ? ORDER BY timestamp DESC" // Order by most recent
-	rows, err := r.DB.Query(query, kitID)
+func (r *AlertRepositoryMysql) GetByKitID(ctx context.Context, kitID int, page pagination.CursorParams) ([]entities.Alert, bool, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	query := "SELECT alert_id, kit_id, alert_type, message, timestamp FROM alerts WHERE kit_id = ?"
+	args := []interface{}{kitID}
+	if alertType, ok := page.Filters["alert_type"]; ok {
+		query += " AND alert_type = ?"
+		args = append(args, alertType)
+	}
+	condition, keysetArgs, orderBy := pagination.KeysetClause("timestamp", "alert_id", page)
+	query += condition + orderBy + " LIMIT ?"
+	args = append(append(args, keysetArgs...), page.Limit+1)
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
 	if err != nil {
 		// Log specific error for not found if needed, but Query handles it okay
-		log.Printf("Error querying alerts by kit ID %d: %v", kitID, err)
-		return nil, err // Return error for DB issues
+		logging.FromContext(ctx).Error("Error querying alerts by kit ID", "kit_id", kitID, "error", err)
+		return nil, false, err // Return error for DB issues
 	}
 	defer rows.Close()
 
@@ -65,21 +87,36 @@ func (r *AlertRepositoryMysql) GetByKitID(kitID int) ([]entities.Alert, error) {
 		var alert entities.Alert
 		// Ensure Scan order matches SELECT statement
 		if err := rows.Scan(&alert.AlertID, &alert.KitID, &alert.AlertType, &alert.Message, &alert.Timestamp); err != nil {
-			log.Printf("Error scanning alert row: %v", err)
-			return nil, err // Fail fast on scan error
+			logging.FromContext(ctx).Error("Error scanning alert row", "error", err)
+			return nil, false, err // Fail fast on scan error
 		}
 		alerts = append(alerts, alert)
 	}
 
 	if err = rows.Err(); err != nil {
-		log.Printf("Error after iterating alert rows: %v", err)
-		return nil, err
+		logging.FromContext(ctx).Error("Error after iterating alert rows", "error", err)
+		return nil, false, err
 	}
 
 	// Return empty slice if no alerts found
 	if len(alerts) == 0 {
-		return []entities.Alert{}, nil
+		return []entities.Alert{}, false, nil
 	}
 
-	return alerts, nil
+	alerts, hasMore := pagination.TrimPage(alerts, page.Limit)
+	return alerts, hasMore, nil
+}
+
+// CountByKitIDBetween implements ports.IAlert
+func (r *AlertRepositoryMysql) CountByKitIDBetween(ctx context.Context, kitID int, from, to time.Time) (int, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	query := "SELECT COUNT(*) FROM alerts WHERE kit_id = ? AND timestamp >= ? AND timestamp < ?"
+	var count int
+	if err := r.DB.QueryRowContext(ctx, query, kitID, from, to).Scan(&count); err != nil {
+		logging.FromContext(ctx).Error("Error counting alerts for kit ID", "kit_id", kitID, "error", err)
+		return 0, err
+	}
+	return count, nil
 }
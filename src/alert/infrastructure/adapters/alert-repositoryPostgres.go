@@ -0,0 +1,97 @@
+package adapters
+
+import (
+	database "api-order/src/Database"
+	"api-order/src/alert/domain/entities"
+	kit "api-order/src/kit/domain/ports"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/logging"
+	"api-order/src/shared/pagination"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+type AlertRepositoryPostgres struct {
+	DB       *sql.DB
+	Timeouts database.Timeouts
+}
+
+// NewAlertRepositoryPostgres wraps the shared connection pool.
+func NewAlertRepositoryPostgres(db *sql.DB, timeouts database.Timeouts) *AlertRepositoryPostgres {
+	return &AlertRepositoryPostgres{DB: db, Timeouts: timeouts}
+}
+
+// Create implements ports.IAlert
+func (r *AlertRepositoryPostgres) Create(ctx context.Context, alert entities.Alert) (entities.Alert, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpWrite)
+	defer cancel()
+
+	query := database.Rebind("INSERT INTO alerts (kit_id, alert_type, message) VALUES (?, ?, ?) RETURNING alert_id")
+	if err := r.DB.QueryRowContext(ctx, query, alert.KitID, alert.AlertType, alert.Message).Scan(&alert.AlertID); err != nil {
+		logging.FromContext(ctx).Error("Error executing alert insert statement", "error", err)
+		// kit_id is the only foreign key of alerts
+		if err = database.TranslateError(err); errors.Is(err, domainerrors.ErrMissingReference) {
+			return entities.Alert{}, kit.ErrKitNotFound.Wrap(err)
+		}
+		return entities.Alert{}, err
+	}
+
+	// Like the MySQL repository, the timestamp is left to the column default and not fetched.
+	return alert, nil
+}
+
+// GetByKitID implements ports.IAlert
+func (r *AlertRepositoryPostgres) GetByKitID(ctx context.Context, kitID int, page pagination.CursorParams) ([]entities.Alert, bool, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	query := "SELECT alert_id, kit_id, alert_type, message, timestamp FROM alerts WHERE kit_id = ?"
+	args := []interface{}{kitID}
+	if alertType, ok := page.Filters["alert_type"]; ok {
+		query += " AND alert_type = ?"
+		args = append(args, alertType)
+	}
+	condition, keysetArgs, orderBy := pagination.KeysetClause("timestamp", "alert_id", page)
+	query += condition + orderBy + " LIMIT ?"
+	args = append(append(args, keysetArgs...), page.Limit+1)
+
+	rows, err := r.DB.QueryContext(ctx, database.Rebind(query), args...)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error querying alerts by kit ID", "kit_id", kitID, "error", err)
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	alerts := []entities.Alert{}
+	for rows.Next() {
+		var alert entities.Alert
+		if err := rows.Scan(&alert.AlertID, &alert.KitID, &alert.AlertType, &alert.Message, &alert.Timestamp); err != nil {
+			logging.FromContext(ctx).Error("Error scanning alert row", "error", err)
+			return nil, false, err
+		}
+		alerts = append(alerts, alert)
+	}
+	if err = rows.Err(); err != nil {
+		logging.FromContext(ctx).Error("Error after iterating alert rows", "error", err)
+		return nil, false, err
+	}
+
+	alerts, hasMore := pagination.TrimPage(alerts, page.Limit)
+	return alerts, hasMore, nil
+}
+
+// CountByKitIDBetween implements ports.IAlert
+func (r *AlertRepositoryPostgres) CountByKitIDBetween(ctx context.Context, kitID int, from, to time.Time) (int, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	query := database.Rebind("SELECT COUNT(*) FROM alerts WHERE kit_id = ? AND timestamp >= ? AND timestamp < ?")
+	var count int
+	if err := r.DB.QueryRowContext(ctx, query, kitID, from, to).Scan(&count); err != nil {
+		logging.FromContext(ctx).Error("Error counting alerts for kit ID", "kit_id", kitID, "error", err)
+		return 0, err
+	}
+	return count, nil
+}
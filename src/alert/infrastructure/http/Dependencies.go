@@ -3,39 +3,27 @@ package http
 import (
 	"api-order/src/alert/application"                     // Adjusted import path
 	"api-order/src/alert/domain/ports"                    // Adjusted import path
-	"api-order/src/alert/infrastructure/adapters"         // Adjusted import path
 	"api-order/src/alert/infrastructure/http/controllers" // Adjusted import path
-	"log"
 )
 
-// Declare repository variable specific to alerts
-var (
-	alertRepository ports.IAlert
-)
+// Dependencies holds what the alert controllers need. It is built by the
+// application container and handed to AlertRoutes.
+type Dependencies struct {
+	AlertRepository ports.IAlert
+}
 
-// Initialize alert dependencies
-func InitializeAlertDependencies() {
-	var err error
-	alertRepository, err = adapters.NewAlertRepositoryMysql()
-	if err != nil {
-		log.Fatalf("Error initializing alert repository: %v", err)
-	}
+func NewDependencies(alertRepository ports.IAlert) *Dependencies {
+	return &Dependencies{AlertRepository: alertRepository}
 }
 
 // Setup function for RegisterAlertController
-func SetUpRegisterAlertController() *controllers.RegisterAlertController {
-	if alertRepository == nil {
-		InitializeAlertDependencies()
-	}
-	registerAlertService := application.NewRegisterAlertUseCase(alertRepository)
+func (d *Dependencies) SetUpRegisterAlertController() *controllers.RegisterAlertController {
+	registerAlertService := application.NewRegisterAlertUseCase(d.AlertRepository)
 	return controllers.NewRegisterAlertController(registerAlertService)
 }
 
 // Setup function for GetAlertsByKitIDController
-func SetUpGetAlertsByKitIDController() *controllers.GetAlertsByKitIDController {
-	if alertRepository == nil {
-		InitializeAlertDependencies()
-	}
-	getAlertsService := application.NewGetAlertsByKitIDUseCase(alertRepository)
+func (d *Dependencies) SetUpGetAlertsByKitIDController() *controllers.GetAlertsByKitIDController {
+	getAlertsService := application.NewGetAlertsByKitIDUseCase(d.AlertRepository)
 	return controllers.NewGetAlertsByKitIDController(getAlertsService)
 }
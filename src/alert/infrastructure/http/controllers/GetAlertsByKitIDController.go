@@ -2,8 +2,11 @@ package controllers
 
 import (
 	"api-order/src/alert/application" // Adjusted import path
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/logging"
+	"api-order/src/shared/pagination"
 	"api-order/src/shared/responses"
-	"log"
 	"net/http"
 	"strconv" // For parsing kit_id from URL
 
@@ -19,15 +22,20 @@ func NewGetAlertsByKitIDController(service *application.GetAlertsByKitIDUseCase)
 }
 
 // @Summary      Get alerts for a specific kit
-// @Description  Retrieves all alerts for a given kit ID, ordered by timestamp descending.
+// @Description  Retrieves a page of alerts for a given kit ID, newest first by default. Pass the returned next_cursor as "cursor" to get the following page.
 // @Tags         Alerts
 // @Produce      json
-// @Param        kit_id path int true "Kit ID" Format(int64)
+// @Param        kit_id              path   int     true   "Kit ID" Format(int64)
+// @Param        limit               query  int     false  "Page size (max 500)" default(50)
+// @Param        cursor              query  string  false  "next_cursor of the previous page"
+// @Param        sort                query  string  false  "Sort order" Enums(-timestamp, timestamp) default(-timestamp)
+// @Param        filter[alert_type]  query  string  false  "Only alerts of this type"
 // @Security     BearerAuth
 // @Success      200  {object}  responses.Response{data=[]entities.Alert} "Alerts retrieved successfully"
-// @Failure      400  {object}  responses.Response "Invalid Kit ID provided"
+// @Failure      400  {object}  responses.Response "Invalid Kit ID or pagination parameters"
 // @Failure      401  {object}  responses.Response "Unauthorized"
 // @Failure      500  {object}  responses.Response "Failed to retrieve alerts"
+// @Failure      504  {object}  responses.Response "Operation timed out"
 // @Router       /v1/alerts/{kit_id} [get]
 func (ctr *GetAlertsByKitIDController) Run(ctx *gin.Context) {
 	// 1. Get kit_id from URL parameter
@@ -35,35 +43,30 @@ func (ctr *GetAlertsByKitIDController) Run(ctx *gin.Context) {
 	kitID, err := strconv.Atoi(kitIDParam) // Use Atoi for int conversion
 
 	if err != nil || kitID <= 0 { // Also check if kitID is positive
-		log.Printf("Invalid kit_id parameter received: %s", kitIDParam)
-		ctx.JSON(http.StatusBadRequest, responses.Response{
-			Success: false,
-			Message: "Invalid Kit ID provided in URL.",
-			Data:    nil,
-			Error:   "Kit ID must be a positive integer.",
-		})
+		logging.FromContext(ctx.Request.Context()).Warn("Invalid kit_id parameter received", "kit_id", kitIDParam)
+		ctx.Error(domainerrors.InvalidParameter("kit_id", "must be a positive integer"))
+		return
+	}
+
+	params, err := pagination.ParseCursor(ctx.Request.URL.Query(), application.AlertsPageSpec)
+	if err != nil {
+		ctx.Error(err)
 		return
 	}
 
 	// 2. Call the Use Case
-	alerts, err := ctr.AlertService.Run(kitID)
+	alerts, page, err := ctr.AlertService.Run(ctx.Request.Context(), kitID, params)
 	if err != nil {
-		// Log error, but don't necessarily expose DB details
-		log.Printf("Error retrieving alerts for kit %d: %v", kitID, err)
-		ctx.JSON(http.StatusInternalServerError, responses.Response{
-			Success: false,
-			Message: "Failed to retrieve alerts.",
-			Error:   "An internal error occurred.", // Keep error message generic
-			Data:    nil,
-		})
+		ctx.Error(err)
 		return
 	}
 
 	// 3. Return Success Response (even if alerts slice is empty)
 	ctx.JSON(http.StatusOK, responses.Response{
-		Success: true,
-		Message: "Alerts retrieved successfully.",
-		Data:    alerts, // Will be [] if no alerts found
-		Error:   nil,
+		Success:    true,
+		Message:    i18n.T(ctx.Request.Context(), "alert.list_retrieved"),
+		Data:       alerts, // Will be [] if no alerts found
+		Error:      nil,
+		Pagination: page,
 	})
 }
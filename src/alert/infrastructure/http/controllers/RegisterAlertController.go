@@ -3,24 +3,22 @@ package controllers
 import (
 	"api-order/src/alert/application"                 // Adjusted import path
 	"api-order/src/alert/infrastructure/http/request" // Adjusted import path
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/logging"
 	"api-order/src/shared/responses"
-	"log"
+	"api-order/src/shared/validation"
 	"net/http"
-	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
 )
 
 type RegisterAlertController struct {
 	AlertService *application.RegisterAlertUseCase
-	Validator    *validator.Validate
 }
 
 func NewRegisterAlertController(service *application.RegisterAlertUseCase) *RegisterAlertController {
 	return &RegisterAlertController{
 		AlertService: service,
-		Validator:    validator.New(),
 	}
 }
 
@@ -31,79 +29,36 @@ func NewRegisterAlertController(service *application.RegisterAlertUseCase) *Regi
 // @Produce      json
 // @Param        alert body request.RegisterAlertRequest true "Alert data to register"
 // @Success      201  {object}  responses.Response{data=entities.Alert} "Alert registered successfully"
-// @Failure      400  {object}  responses.Response "Invalid request body, validation failed, invalid Kit ID, or invalid alert type"
+// @Failure      400  {object}  responses.Response "Invalid request body, validation failed, or invalid alert type"
 // @Failure      401  {object}  responses.Response "Unauthorized (token missing or invalid)"
+// @Failure      404  {object}  responses.Response "Kit ID not found"
 // @Failure      500  {object}  responses.Response "Internal server error while registering alert"
+// @Failure      504  {object}  responses.Response "Operation timed out"
 // @Router       /v1/alerts/ [post]
 func (ctr *RegisterAlertController) Run(ctx *gin.Context) {
 	var req request.RegisterAlertRequest
 
-	// 1. Bind JSON request body
-	if err := ctx.ShouldBindJSON(&req); err != nil {
-		log.Printf("Error binding RegisterAlertRequest: %v", err)
-		ctx.JSON(http.StatusBadRequest, responses.Response{
-			Success: false,
-			Message: "Invalid request body format.",
-			Error:   err.Error(),
-			Data:    nil,
-		})
+	// 1. Bind and validate the JSON body
+	if err := validation.BindJSON(ctx, &req); err != nil {
+		logging.FromContext(ctx.Request.Context()).Warn("Validation failed for RegisterAlertRequest", "error", err)
+		ctx.Error(err)
 		return
 	}
+	ctx.Request = ctx.Request.WithContext(logging.With(ctx.Request.Context(), "kit_id", req.KitID))
 
-	// 2. Validate request struct fields (includes alert_type via 'oneof')
-	if err := ctr.Validator.Struct(req); err != nil {
-		log.Printf("Validation failed for RegisterAlertRequest: %v", err)
-		ctx.JSON(http.StatusBadRequest, responses.Response{
-			Success: false,
-			Message: "Validation failed. Check kit_id, alert_type, and message.",
-			Data:    nil,
-			Error:   err.Error(), // Provides details on which validation failed
-		})
-		return
-	}
-
-	// 3. Call the Use Case
+	// 2. Call the Use Case
 	// Note: Use case already validates alertType internally, but validator catches it earlier.
-	createdAlert, err := ctr.AlertService.Run(req.KitID, req.AlertType, req.Message)
+	createdAlert, err := ctr.AlertService.Run(ctx.Request.Context(), req.KitID, req.AlertType, req.Message)
 	if err != nil {
-		log.Printf("Error registering alert for kit %d: %v", req.KitID, err)
-
-		// Check for foreign key constraint error
-		if strings.Contains(err.Error(), "FOREIGN KEY constraint failed") ||
-			strings.Contains(err.Error(), "1452") { // MySQL FK error
-			ctx.JSON(http.StatusBadRequest, responses.Response{
-				Success: false,
-				Message: "Failed to register alert: Invalid Kit ID.",
-				Error:   "The provided kit_id does not exist.",
-				Data:    nil,
-			})
-			return
-		}
-		// Handle specific use case validation error
-		if err.Error() == "invalid alert_type provided" {
-			ctx.JSON(http.StatusBadRequest, responses.Response{
-				Success: false,
-				Message: "Invalid alert type provided.",
-				Error:   err.Error(),
-				Data:    nil,
-			})
-			return
-		}
-
-		// Generic internal server error
-		ctx.JSON(http.StatusInternalServerError, responses.Response{
-			Success: false,
-			Message: "Failed to register alert.",
-			Error:   err.Error(),
-			Data:    nil,
-		})
+		// An unknown kit_id answers 404
+		ctx.Error(err)
 		return
 	}
 
-	// 4. Return Success Response
+	// 3. Return Success Response
 	ctx.JSON(http.StatusCreated, responses.Response{
 		Success: true,
-		Message: "Alert registered successfully.",
+		Message: i18n.T(ctx.Request.Context(), "alert.registered"),
 		Data:    createdAlert,
 		Error:   nil,
 	})
@@ -8,10 +8,10 @@ import (
 )
 
 // AlertRoutes configures routes for the alerts feature
-func AlertRoutes(router *gin.RouterGroup) {
+func AlertRoutes(router *gin.RouterGroup, deps *alerthttp.Dependencies) {
 	// Initialize controllers
-	registerAlertController := alerthttp.SetUpRegisterAlertController()
-	getAlertsController := alerthttp.SetUpGetAlertsByKitIDController()
+	registerAlertController := deps.SetUpRegisterAlertController()
+	getAlertsController := deps.SetUpGetAlertsByKitIDController()
 
 	// POST / -> Register a new alert
 	router.POST("/", registerAlertController.Run)
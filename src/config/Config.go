@@ -0,0 +1,315 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Config holds everything the API needs to start. It is read once at startup
+// and passed down explicitly, so the server can run against any database.
+type Config struct {
+	Server     ServerConfig
+	Logging    LoggingConfig
+	Tracing    TracingConfig
+	Cors       CorsConfig
+	JWT        JWTConfig
+	Database   DatabaseConfig
+	Metric     MetricConfig
+	GardenData GardenDataConfig
+}
+
+// LoggingConfig selects the format and the minimum level of the logs.
+type LoggingConfig struct {
+	Format string
+	Level  string
+}
+
+// ServerConfig is the address the HTTP server listens on and the deadlines
+// of its connections. Zero timeouts disable the corresponding deadline.
+type ServerConfig struct {
+	Host string
+	Port string
+
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	IdleTimeout  time.Duration
+	// DrainDelay is how long the server keeps accepting requests while
+	// /readyz reports it draining, before the shutdown proper starts.
+	DrainDelay time.Duration
+	// ShutdownTimeout bounds how long in-flight requests and background
+	// workers get to finish after SIGINT or SIGTERM.
+	ShutdownTimeout time.Duration
+}
+
+// Addr returns host:port.
+func (c ServerConfig) Addr() string {
+	return c.Host + ":" + c.Port
+}
+
+// Supported database drivers.
+const (
+	DriverMySQL    = "mysql"
+	DriverSQLite   = "sqlite"
+	DriverPostgres = "postgres"
+)
+
+// DatabaseConfig describes the database connection and the deadlines of each
+// class of query. Path is only used by SQLite; the other connection fields
+// by MySQL and PostgreSQL. SSLMode and Timescale only apply to PostgreSQL:
+// Timescale turns garden_data and metric_samples into TimescaleDB hypertables
+// and downsamples them with time_bucket.
+type DatabaseConfig struct {
+	Driver    string
+	Path      string
+	SSLMode   string
+	Timescale bool
+
+	// AutoMigrate applies pending schema migrations when the database is opened.
+	AutoMigrate bool
+
+	User     string
+	Password string
+	Host     string
+	Port     string
+	Name     string
+
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+
+	ReadTimeout  time.Duration
+	WriteTimeout time.Duration
+	BatchTimeout time.Duration
+}
+
+// DSN returns the data source name of the configured driver.
+func (c DatabaseConfig) DSN() string {
+	if c.Driver == DriverSQLite {
+		// Foreign keys are off by default in SQLite, and writers wait for each
+		// other instead of failing with SQLITE_BUSY.
+		return "file:" + c.Path + "?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)"
+	}
+	if c.Driver == DriverPostgres {
+		host := c.Host
+		if c.Port != "" {
+			host = net.JoinHostPort(c.Host, c.Port)
+		}
+		dsn := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(c.User, c.Password),
+			Host:     host,
+			Path:     "/" + c.Name,
+			RawQuery: url.Values{"sslmode": {c.SSLMode}}.Encode(),
+		}
+		return dsn.String()
+	}
+	return c.User + ":" + c.Password + "@tcp(" + c.Host + ":" + c.Port + ")/" + c.Name + "?charset=utf8mb4&parseTime=True&loc=Local"
+}
+
+// Load reads the configuration from environment variables, the .env file in
+// the working directory and the optional YAML or TOML file named by
+// CONFIG_FILE, in that order of precedence. Every setting is validated and
+// all the problems are reported together.
+func Load() (Config, error) {
+	src, err := newSource()
+	if err != nil {
+		return Config{}, err
+	}
+
+	var cfg Config
+	var errs []error
+	if cfg.Server, err = loadServer(src); err != nil {
+		errs = append(errs, err)
+	}
+	if cfg.Logging, err = loadLogging(src); err != nil {
+		errs = append(errs, err)
+	}
+	if cfg.Tracing, err = loadTracing(src); err != nil {
+		errs = append(errs, err)
+	}
+	if cfg.Cors, err = loadCors(src); err != nil {
+		errs = append(errs, err)
+	}
+	if cfg.JWT, err = loadJWT(src); err != nil {
+		errs = append(errs, err)
+	}
+	if cfg.Database, err = loadDatabase(src); err != nil {
+		errs = append(errs, err)
+	}
+	if cfg.Metric, err = loadMetric(src); err != nil {
+		errs = append(errs, err)
+	}
+	if cfg.GardenData, err = loadGardenData(src); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return Config{}, fmt.Errorf("invalid configuration:\n%w", errors.Join(errs...))
+	}
+	return cfg, nil
+}
+
+// loadLogging reads the logging configuration:
+//
+//	LOG_FORMAT  json (default) or text
+//	LOG_LEVEL   debug, info (default), warn or error
+func loadLogging(src source) (LoggingConfig, error) {
+	cfg := LoggingConfig{Format: src.get("LOG_FORMAT"), Level: src.get("LOG_LEVEL")}
+	if cfg.Format == "" {
+		cfg.Format = "json"
+	}
+	if cfg.Format != "json" && cfg.Format != "text" {
+		return LoggingConfig{}, fmt.Errorf("LOG_FORMAT must be json or text, got %q", cfg.Format)
+	}
+	if cfg.Level == "" {
+		cfg.Level = "info"
+	}
+	switch cfg.Level {
+	case "debug", "info", "warn", "error":
+	default:
+		return LoggingConfig{}, fmt.Errorf("LOG_LEVEL must be debug, info, warn or error, got %q", cfg.Level)
+	}
+	return cfg, nil
+}
+
+// loadServer reads the HTTP server configuration:
+//
+//	HOST_SERVER, PORT_SERVER  listen address (required)
+//	SERVER_READ_TIMEOUT       deadline to read a whole request, body included (default 1m, CSV imports are large)
+//	SERVER_WRITE_TIMEOUT      deadline to write a response (default 5m, exports stream for a while)
+//	SERVER_IDLE_TIMEOUT       how long keep-alive connections wait for the next request (default 2m)
+//	SHUTDOWN_DRAIN_DELAY      time /readyz fails before connections stop being accepted (default 0)
+//	SHUTDOWN_TIMEOUT          graceful shutdown deadline (default 30s)
+func loadServer(src source) (ServerConfig, error) {
+	cfg := ServerConfig{
+		Host: src.get("HOST_SERVER"),
+		Port: src.get("PORT_SERVER"),
+	}
+	if cfg.Host == "" || cfg.Port == "" {
+		return ServerConfig{}, errors.New("HOST_SERVER or PORT_SERVER is not set")
+	}
+	if port, err := strconv.Atoi(cfg.Port); err != nil || port < 0 || port > 65535 {
+		return ServerConfig{}, fmt.Errorf("PORT_SERVER must be a port number, got %q", cfg.Port)
+	}
+
+	var err error
+	if cfg.ReadTimeout, err = src.positiveDuration("SERVER_READ_TIMEOUT", time.Minute); err != nil {
+		return ServerConfig{}, err
+	}
+	if cfg.WriteTimeout, err = src.positiveDuration("SERVER_WRITE_TIMEOUT", 5*time.Minute); err != nil {
+		return ServerConfig{}, err
+	}
+	if cfg.IdleTimeout, err = src.positiveDuration("SERVER_IDLE_TIMEOUT", 2*time.Minute); err != nil {
+		return ServerConfig{}, err
+	}
+	if value := src.get("SHUTDOWN_DRAIN_DELAY"); value != "" {
+		if cfg.DrainDelay, err = time.ParseDuration(value); err != nil || cfg.DrainDelay < 0 {
+			return ServerConfig{}, fmt.Errorf("SHUTDOWN_DRAIN_DELAY must be a non negative duration, got %q", value)
+		}
+	}
+	if cfg.ShutdownTimeout, err = src.positiveDuration("SHUTDOWN_TIMEOUT", 30*time.Second); err != nil {
+		return ServerConfig{}, err
+	}
+	return cfg, nil
+}
+
+// LoadDatabase reads only the database settings, for commands that do not
+// start the HTTP server. Like Load, it reads the environment, .env and
+// CONFIG_FILE:
+//
+//	DB_DRIVER             mysql (default), sqlite or postgres
+//	DB_PATH               SQLite database file (default api-order.db)
+//	DB_USER, DB_PASSWORD, DB_HOST, DB_PORT, DB_NAME  MySQL or PostgreSQL connection
+//	DB_SSLMODE            PostgreSQL sslmode (default disable)
+//	DB_TIMESCALE          store PostgreSQL time series in TimescaleDB hypertables (default false)
+//	DB_AUTO_MIGRATE       apply pending migrations on startup (default true for sqlite, false otherwise)
+//	DB_MAX_OPEN_CONNS     connection pool size (default 25, always 1 for sqlite)
+//	DB_MAX_IDLE_CONNS     idle connections kept in the pool (default 25, always 1 for sqlite)
+//	DB_CONN_MAX_LIFETIME  time before a connection is recycled (default 1m)
+//	DB_READ_TIMEOUT       deadline of single queries and short lists (default 5s)
+//	DB_WRITE_TIMEOUT      deadline of inserts and updates (default 5s)
+//	DB_BATCH_TIMEOUT      deadline of rollups, pruning, exports and long scans (default 2m)
+func LoadDatabase() (DatabaseConfig, error) {
+	src, err := newSource()
+	if err != nil {
+		return DatabaseConfig{}, err
+	}
+	return loadDatabase(src)
+}
+
+func loadDatabase(src source) (DatabaseConfig, error) {
+	cfg := DatabaseConfig{
+		Driver:   src.get("DB_DRIVER"),
+		Path:     src.get("DB_PATH"),
+		SSLMode:  src.get("DB_SSLMODE"),
+		User:     src.get("DB_USER"),
+		Password: src.get("DB_PASSWORD"),
+		Host:     src.get("DB_HOST"),
+		Port:     src.get("DB_PORT"),
+		Name:     src.get("DB_NAME"),
+	}
+	switch cfg.Driver {
+	case "", DriverMySQL:
+		cfg.Driver = DriverMySQL
+		if cfg.Host == "" || cfg.Name == "" {
+			return DatabaseConfig{}, errors.New("DB_HOST or DB_NAME is not set")
+		}
+	case DriverSQLite:
+		if cfg.Path == "" {
+			cfg.Path = "api-order.db"
+		}
+	case DriverPostgres:
+		if cfg.Host == "" || cfg.Name == "" {
+			return DatabaseConfig{}, errors.New("DB_HOST or DB_NAME is not set")
+		}
+		if cfg.SSLMode == "" {
+			cfg.SSLMode = "disable"
+		}
+	default:
+		return DatabaseConfig{}, fmt.Errorf("DB_DRIVER must be %s, %s or %s, got %q", DriverMySQL, DriverSQLite, DriverPostgres, cfg.Driver)
+	}
+
+	var err error
+	if cfg.Timescale, err = src.boolean("DB_TIMESCALE", false); err != nil {
+		return DatabaseConfig{}, err
+	}
+	if cfg.Timescale && cfg.Driver != DriverPostgres {
+		return DatabaseConfig{}, errors.New("DB_TIMESCALE requires DB_DRIVER=postgres")
+	}
+	// A local SQLite file is expected to just work; shared servers are
+	// migrated deliberately with `api migrate up` unless asked otherwise.
+	if cfg.AutoMigrate, err = src.boolean("DB_AUTO_MIGRATE", cfg.Driver == DriverSQLite); err != nil {
+		return DatabaseConfig{}, err
+	}
+
+	if cfg.MaxOpenConns, err = src.integer("DB_MAX_OPEN_CONNS", 25, 1); err != nil {
+		return DatabaseConfig{}, err
+	}
+	if cfg.MaxIdleConns, err = src.integer("DB_MAX_IDLE_CONNS", 25, 0); err != nil {
+		return DatabaseConfig{}, err
+	}
+	if cfg.MaxIdleConns > cfg.MaxOpenConns {
+		return DatabaseConfig{}, fmt.Errorf("DB_MAX_IDLE_CONNS (%d) must not exceed DB_MAX_OPEN_CONNS (%d)", cfg.MaxIdleConns, cfg.MaxOpenConns)
+	}
+	if cfg.ConnMaxLifetime, err = src.positiveDuration("DB_CONN_MAX_LIFETIME", time.Minute); err != nil {
+		return DatabaseConfig{}, err
+	}
+	if cfg.Driver == DriverSQLite {
+		// SQLite allows a single writer; one connection avoids lock contention.
+		cfg.MaxOpenConns, cfg.MaxIdleConns, cfg.ConnMaxLifetime = 1, 1, 0
+	}
+
+	if cfg.ReadTimeout, err = src.positiveDuration("DB_READ_TIMEOUT", 5*time.Second); err != nil {
+		return DatabaseConfig{}, err
+	}
+	if cfg.WriteTimeout, err = src.positiveDuration("DB_WRITE_TIMEOUT", 5*time.Second); err != nil {
+		return DatabaseConfig{}, err
+	}
+	if cfg.BatchTimeout, err = src.positiveDuration("DB_BATCH_TIMEOUT", 2*time.Minute); err != nil {
+		return DatabaseConfig{}, err
+	}
+	return cfg, nil
+}
@@ -1,16 +1,56 @@
 package config
 
 import (
+	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 )
 
-func ConfigurationCors() gin.HandlerFunc {
+// CorsConfig lists the browser origins allowed to call the API.
+type CorsConfig struct {
+	// AllowedOrigins is empty when every origin is allowed.
+	AllowedOrigins []string
+	MaxAge         time.Duration
+}
+
+// loadCors reads the CORS configuration:
+//
+//	CORS_ALLOWED_ORIGINS  comma separated origins, e.g. https://app.example.com (unset or * allows all)
+//	CORS_MAX_AGE          how long browsers cache preflight responses (default 24h)
+func loadCors(src source) (CorsConfig, error) {
+	var cfg CorsConfig
+	for _, origin := range strings.Split(src.get("CORS_ALLOWED_ORIGINS"), ",") {
+		origin = strings.TrimSpace(origin)
+		if origin == "" {
+			continue
+		}
+		if origin == "*" {
+			cfg.AllowedOrigins = nil
+			break
+		}
+		parsed, err := url.Parse(origin)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" || strings.Trim(parsed.Path, "/") != "" {
+			return CorsConfig{}, fmt.Errorf("CORS_ALLOWED_ORIGINS must list origins like https://app.example.com, got %q", origin)
+		}
+		cfg.AllowedOrigins = append(cfg.AllowedOrigins, strings.TrimSuffix(origin, "/"))
+	}
+
+	var err error
+	if cfg.MaxAge, err = src.positiveDuration("CORS_MAX_AGE", 24*time.Hour); err != nil {
+		return CorsConfig{}, err
+	}
+	return cfg, nil
+}
+
+func ConfigurationCors(cfg CorsConfig) gin.HandlerFunc {
 	config := cors.Config{
-		// Permite todos los orígenes
-		AllowAllOrigins: true,
+		// Permite todos los orígenes salvo que se configuren explícitamente
+		AllowAllOrigins: len(cfg.AllowedOrigins) == 0,
+		AllowOrigins:    cfg.AllowedOrigins,
 
 		// Permite todos los métodos HTTP
 		AllowMethods: []string{
@@ -38,7 +78,7 @@ func ConfigurationCors() gin.HandlerFunc {
 		AllowCredentials: true,
 
 		// Tiempo máximo de cache para preflight requests
-		MaxAge: 24 * time.Hour,
+		MaxAge: cfg.MaxAge,
 	}
 
 	return cors.New(config)
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"api-order/src/gardendata/domain/entities"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// GardenDataConfig tunes retention, caching and the background jobs of the
+// garden data module.
+type GardenDataConfig struct {
+	Retention          entities.RetentionPolicy
+	CompactionInterval time.Duration
+	StatisticsCacheTTL time.Duration
+	// GapAlertAfter is zero when data gap alerts are disabled.
+	GapAlertAfter      time.Duration
+	GapCheckInterval   time.Duration
+	ClockSkewTolerance time.Duration
+	// ReadingsByKit labels the ingested readings metric with each kit ID. It is
+	// off by default because every kit adds a time series.
+	ReadingsByKit bool
+}
+
+func loadGardenData(src source) (GardenDataConfig, error) {
+	var cfg GardenDataConfig
+	var err error
+
+	if cfg.StatisticsCacheTTL, err = statisticsCacheTTLFromEnv(src); err != nil {
+		return cfg, fmt.Errorf("invalid statistics cache configuration: %w", err)
+	}
+	if cfg.Retention, cfg.CompactionInterval, err = retentionFromEnv(src); err != nil {
+		return cfg, fmt.Errorf("invalid retention configuration: %w", err)
+	}
+	if cfg.GapAlertAfter, cfg.GapCheckInterval, err = gapAlertsFromEnv(src); err != nil {
+		return cfg, fmt.Errorf("invalid gap alert configuration: %w", err)
+	}
+	if cfg.ClockSkewTolerance, err = clockSkewToleranceFromEnv(src); err != nil {
+		return cfg, fmt.Errorf("invalid clock skew configuration: %w", err)
+	}
+	if cfg.ReadingsByKit, err = src.boolean("METRICS_READINGS_BY_KIT", false); err != nil {
+		return cfg, fmt.Errorf("invalid monitoring configuration: %w", err)
+	}
+	return cfg, nil
+}
+
+// clockSkewToleranceFromEnv reads CLOCK_SKEW_TOLERANCE, the largest difference
+// between device and server clocks before a kit is flagged and its readings are
+// stamped with the server time instead (default 5m).
+func clockSkewToleranceFromEnv(src source) (time.Duration, error) {
+	value := src.get("CLOCK_SKEW_TOLERANCE")
+	if value == "" {
+		return 5 * time.Minute, nil
+	}
+	tolerance, err := time.ParseDuration(value)
+	if err != nil || tolerance < time.Second {
+		return 0, fmt.Errorf("CLOCK_SKEW_TOLERANCE must be a duration of at least 1s, got %q", value)
+	}
+	return tolerance, nil
+}
+
+// gapAlertsFromEnv reads the data gap alert configuration:
+//
+//	GAP_ALERT_AFTER     silence after which a kit raises a data_gap alert (unset disables the alerts)
+//	GAP_CHECK_INTERVAL  how often kits are checked (default 5m)
+func gapAlertsFromEnv(src source) (time.Duration, time.Duration, error) {
+	var after time.Duration
+	if value := src.get("GAP_ALERT_AFTER"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed < time.Minute {
+			return 0, 0, fmt.Errorf("GAP_ALERT_AFTER must be a duration of at least 1m, got %q", value)
+		}
+		after = parsed
+	}
+
+	interval := 5 * time.Minute
+	if value := src.get("GAP_CHECK_INTERVAL"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed < time.Minute {
+			return 0, 0, fmt.Errorf("GAP_CHECK_INTERVAL must be a duration of at least 1m, got %q", value)
+		}
+		interval = parsed
+	}
+	return after, interval, nil
+}
+
+// statisticsCacheTTLFromEnv reads STATISTICS_CACHE_TTL, the longest time kit
+// statistics are served from cache when no new readings arrive (default 5m).
+func statisticsCacheTTLFromEnv(src source) (time.Duration, error) {
+	value := src.get("STATISTICS_CACHE_TTL")
+	if value == "" {
+		return 5 * time.Minute, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		return 0, fmt.Errorf("STATISTICS_CACHE_TTL must be a positive duration, got %q", value)
+	}
+	return ttl, nil
+}
+
+// retentionFromEnv reads the retention policy:
+//
+//	RETENTION_RAW_DAYS     days of raw readings to keep (0 or unset keeps everything)
+//	RETENTION_HOURLY_DAYS  days of hourly rollups to keep (0 keeps them forever)
+//	RETENTION_DAILY_DAYS   days of daily rollups to keep (0 keeps them forever)
+//	COMPACTION_INTERVAL    how often the compaction job runs (default 1h)
+func retentionFromEnv(src source) (entities.RetentionPolicy, time.Duration, error) {
+	var policy entities.RetentionPolicy
+	for name, target := range map[string]*int{
+		"RETENTION_RAW_DAYS":    &policy.RawDays,
+		"RETENTION_HOURLY_DAYS": &policy.HourlyDays,
+		"RETENTION_DAILY_DAYS":  &policy.DailyDays,
+	} {
+		value := src.get(name)
+		if value == "" {
+			continue
+		}
+		days, err := strconv.Atoi(value)
+		if err != nil || days < 0 {
+			return policy, 0, fmt.Errorf("%s must be a non negative number of days, got %q", name, value)
+		}
+		*target = days
+	}
+
+	if policy.HourlyDays > 0 && policy.HourlyDays < policy.RawDays {
+		return policy, 0, fmt.Errorf("RETENTION_HOURLY_DAYS (%d) must be at least RETENTION_RAW_DAYS (%d)", policy.HourlyDays, policy.RawDays)
+	}
+	if policy.DailyDays > 0 && (policy.HourlyDays == 0 || policy.DailyDays < policy.HourlyDays) {
+		return policy, 0, fmt.Errorf("RETENTION_DAILY_DAYS (%d) must be at least RETENTION_HOURLY_DAYS (%d), and hourly rollups must expire", policy.DailyDays, policy.HourlyDays)
+	}
+
+	interval := time.Hour
+	if value := src.get("COMPACTION_INTERVAL"); value != "" {
+		parsed, err := time.ParseDuration(value)
+		if err != nil || parsed < time.Minute {
+			return policy, 0, fmt.Errorf("COMPACTION_INTERVAL must be a duration of at least 1m, got %q", value)
+		}
+		interval = parsed
+	}
+	return policy, interval, nil
+}
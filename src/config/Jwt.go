@@ -0,0 +1,47 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// JWTConfig signs and validates the tokens issued at login.
+type JWTConfig struct {
+	SecretKey string
+	TTL       time.Duration
+	Issuer    string
+	// UserCacheTTL bounds how long the user behind a token, and so their
+	// preferences, is served from memory before it is read again.
+	UserCacheTTL time.Duration
+}
+
+// loadJWT reads the token configuration:
+//
+//	JWT_SECRET_KEY  HMAC key tokens are signed with (required, at least 32 bytes)
+//	JWT_TTL         how long a token stays valid (default 72h)
+//	JWT_ISSUER      iss claim of the tokens (default myapp)
+//	USER_CACHE_TTL  how long the user of a token is cached in memory (default 30s)
+func loadJWT(src source) (JWTConfig, error) {
+	cfg := JWTConfig{
+		SecretKey: src.get("JWT_SECRET_KEY"),
+		Issuer:    src.get("JWT_ISSUER"),
+	}
+	if cfg.SecretKey == "" {
+		return JWTConfig{}, errors.New("JWT_SECRET_KEY is not set")
+	}
+	if len(cfg.SecretKey) < 32 {
+		return JWTConfig{}, errors.New("JWT_SECRET_KEY must be at least 32 bytes long")
+	}
+	if cfg.Issuer == "" {
+		cfg.Issuer = "myapp"
+	}
+
+	var err error
+	if cfg.TTL, err = src.positiveDuration("JWT_TTL", 72*time.Hour); err != nil {
+		return JWTConfig{}, err
+	}
+	if cfg.UserCacheTTL, err = src.positiveDuration("USER_CACHE_TTL", 30*time.Second); err != nil {
+		return JWTConfig{}, err
+	}
+	return cfg, nil
+}
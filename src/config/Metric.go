@@ -0,0 +1,53 @@
+package config
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// MetricConfig controls who may change the metric registry, which is shared
+// by every kit.
+type MetricConfig struct {
+	// AdminEmails are the users allowed to register metrics. Registration is
+	// closed when it is empty.
+	AdminEmails []string
+	// RegistryCacheTTL bounds how long a definition is served from memory
+	// before the registry is read again.
+	RegistryCacheTTL time.Duration
+}
+
+// IsAdmin reports whether email belongs to a metric administrator.
+func (c MetricConfig) IsAdmin(email string) bool {
+	for _, admin := range c.AdminEmails {
+		if strings.EqualFold(admin, email) {
+			return true
+		}
+	}
+	return false
+}
+
+// loadMetric reads the metric registry configuration:
+//
+//	METRIC_ADMIN_EMAILS        comma separated emails of the users who may register metrics (default none)
+//	METRIC_REGISTRY_CACHE_TTL  how long metric definitions are cached in memory (default 1m)
+func loadMetric(src source) (MetricConfig, error) {
+	var cfg MetricConfig
+	for _, email := range strings.Split(src.get("METRIC_ADMIN_EMAILS"), ",") {
+		email = strings.TrimSpace(email)
+		if email == "" {
+			continue
+		}
+		if address, err := mail.ParseAddress(email); err != nil || address.Address != email {
+			return MetricConfig{}, fmt.Errorf("METRIC_ADMIN_EMAILS must list email addresses, got %q", email)
+		}
+		cfg.AdminEmails = append(cfg.AdminEmails, email)
+	}
+
+	var err error
+	if cfg.RegistryCacheTTL, err = src.positiveDuration("METRIC_REGISTRY_CACHE_TTL", time.Minute); err != nil {
+		return MetricConfig{}, err
+	}
+	return cfg, nil
+}
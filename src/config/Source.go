@@ -0,0 +1,202 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// fileKeys maps the keys of the optional configuration file to the
+// environment variable each one stands for. A file may only set these keys.
+var fileKeys = map[string]string{
+	"server.host":             "HOST_SERVER",
+	"server.port":             "PORT_SERVER",
+	"server.read_timeout":     "SERVER_READ_TIMEOUT",
+	"server.write_timeout":    "SERVER_WRITE_TIMEOUT",
+	"server.idle_timeout":     "SERVER_IDLE_TIMEOUT",
+	"server.drain_delay":      "SHUTDOWN_DRAIN_DELAY",
+	"server.shutdown_timeout": "SHUTDOWN_TIMEOUT",
+
+	"logging.format": "LOG_FORMAT",
+	"logging.level":  "LOG_LEVEL",
+
+	"tracing.exporter":     "TRACING_EXPORTER",
+	"tracing.service_name": "TRACING_SERVICE_NAME",
+	"tracing.endpoint":     "TRACING_ENDPOINT",
+	"tracing.insecure":     "TRACING_INSECURE",
+	"tracing.file":         "TRACING_FILE",
+	"tracing.sample_ratio": "TRACING_SAMPLE_RATIO",
+
+	"cors.allowed_origins": "CORS_ALLOWED_ORIGINS",
+	"cors.max_age":         "CORS_MAX_AGE",
+
+	"jwt.secret_key":     "JWT_SECRET_KEY",
+	"jwt.ttl":            "JWT_TTL",
+	"jwt.issuer":         "JWT_ISSUER",
+	"jwt.user_cache_ttl": "USER_CACHE_TTL",
+
+	"database.driver":            "DB_DRIVER",
+	"database.path":              "DB_PATH",
+	"database.user":              "DB_USER",
+	"database.password":          "DB_PASSWORD",
+	"database.host":              "DB_HOST",
+	"database.port":              "DB_PORT",
+	"database.name":              "DB_NAME",
+	"database.sslmode":           "DB_SSLMODE",
+	"database.timescale":         "DB_TIMESCALE",
+	"database.auto_migrate":      "DB_AUTO_MIGRATE",
+	"database.max_open_conns":    "DB_MAX_OPEN_CONNS",
+	"database.max_idle_conns":    "DB_MAX_IDLE_CONNS",
+	"database.conn_max_lifetime": "DB_CONN_MAX_LIFETIME",
+	"database.read_timeout":      "DB_READ_TIMEOUT",
+	"database.write_timeout":     "DB_WRITE_TIMEOUT",
+	"database.batch_timeout":     "DB_BATCH_TIMEOUT",
+
+	"metric.admin_emails":       "METRIC_ADMIN_EMAILS",
+	"metric.registry_cache_ttl": "METRIC_REGISTRY_CACHE_TTL",
+
+	"garden_data.retention_raw_days":    "RETENTION_RAW_DAYS",
+	"garden_data.retention_hourly_days": "RETENTION_HOURLY_DAYS",
+	"garden_data.retention_daily_days":  "RETENTION_DAILY_DAYS",
+	"garden_data.compaction_interval":   "COMPACTION_INTERVAL",
+	"garden_data.statistics_cache_ttl":  "STATISTICS_CACHE_TTL",
+	"garden_data.gap_alert_after":       "GAP_ALERT_AFTER",
+	"garden_data.gap_check_interval":    "GAP_CHECK_INTERVAL",
+	"garden_data.clock_skew_tolerance":  "CLOCK_SKEW_TOLERANCE",
+	"garden_data.readings_by_kit":       "METRICS_READINGS_BY_KIT",
+}
+
+// source resolves a setting by its environment variable name. The process
+// environment wins, then .env (loaded into the environment without
+// overriding it), then the configuration file.
+type source struct {
+	file map[string]string
+}
+
+// newSource loads .env from the working directory, if any, and the YAML or
+// TOML file named by CONFIG_FILE, if set.
+func newSource() (source, error) {
+	if err := godotenv.Load(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return source{}, fmt.Errorf("invalid .env file: %w", err)
+	}
+
+	src := source{file: map[string]string{}}
+	path := os.Getenv("CONFIG_FILE")
+	if path == "" {
+		return src, nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return source{}, fmt.Errorf("CONFIG_FILE: %w", err)
+	}
+
+	var tree map[string]interface{}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &tree)
+	case ".toml":
+		err = toml.NewDecoder(bytes.NewReader(content)).Decode(&tree)
+	default:
+		return source{}, fmt.Errorf("CONFIG_FILE must be a .yaml, .yml or .toml file, got %q", path)
+	}
+	if err != nil {
+		return source{}, fmt.Errorf("invalid configuration file %s: %w", path, err)
+	}
+
+	values := map[string]string{}
+	flatten("", tree, values)
+	var unknown []string
+	for key, value := range values {
+		env, ok := fileKeys[key]
+		if !ok {
+			unknown = append(unknown, key)
+			continue
+		}
+		src.file[env] = value
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return source{}, fmt.Errorf("unknown settings in %s: %s", path, strings.Join(unknown, ", "))
+	}
+	return src, nil
+}
+
+// flatten turns nested sections into dotted keys. Lists become comma
+// separated values, like their environment variable counterparts.
+func flatten(prefix string, tree map[string]interface{}, values map[string]string) {
+	for key, value := range tree {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			flatten(key, v, values)
+		case []interface{}:
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = fmt.Sprint(item)
+			}
+			values[key] = strings.Join(items, ",")
+		default:
+			values[key] = fmt.Sprint(v)
+		}
+	}
+}
+
+// get returns the raw value of a setting, or "" when it is not set anywhere.
+// An empty environment variable counts as unset.
+func (s source) get(key string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return s.file[key]
+}
+
+// positiveDuration reads a duration greater than zero, using fallback when the setting is unset.
+func (s source) positiveDuration(key string, fallback time.Duration) (time.Duration, error) {
+	value := s.get(key)
+	if value == "" {
+		return fallback, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("%s must be a positive duration, got %q", key, value)
+	}
+	return duration, nil
+}
+
+// boolean reads a boolean, using fallback when the setting is unset.
+func (s source) boolean(key string, fallback bool) (bool, error) {
+	value := s.get(key)
+	if value == "" {
+		return fallback, nil
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s must be a boolean, got %q", key, value)
+	}
+	return parsed, nil
+}
+
+// integer reads a whole number of at least min, using fallback when the setting is unset.
+func (s source) integer(key string, fallback, min int) (int, error) {
+	value := s.get(key)
+	if value == "" {
+		return fallback, nil
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < min {
+		return 0, fmt.Errorf("%s must be a whole number of at least %d, got %q", key, min, value)
+	}
+	return parsed, nil
+}
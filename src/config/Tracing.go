@@ -0,0 +1,72 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Trace exporters.
+const (
+	TracingNone   = "none"
+	TracingOTLP   = "otlp"
+	TracingStdout = "stdout"
+	TracingFile   = "file"
+)
+
+// TracingConfig selects where OpenTelemetry spans are sent.
+type TracingConfig struct {
+	Exporter    string
+	ServiceName string
+	// Endpoint is the host:port of an OTLP/HTTP collector. When empty the
+	// standard OTEL_EXPORTER_OTLP_* variables apply.
+	Endpoint string
+	Insecure bool
+	// File receives the spans of the file exporter, one JSON document each.
+	File        string
+	SampleRatio float64
+}
+
+// loadTracing reads the tracing configuration:
+//
+//	TRACING_EXPORTER      none (default), otlp, stdout or file
+//	TRACING_SERVICE_NAME  service.name of the spans (default api-order)
+//	TRACING_ENDPOINT      OTLP/HTTP collector, e.g. otel-collector:4318
+//	TRACING_INSECURE      send OTLP over plain HTTP (default false)
+//	TRACING_FILE          output of the file exporter (default traces.json)
+//	TRACING_SAMPLE_RATIO  share of new traces that are recorded, 0 to 1 (default 1)
+func loadTracing(src source) (TracingConfig, error) {
+	cfg := TracingConfig{
+		Exporter:    src.get("TRACING_EXPORTER"),
+		ServiceName: src.get("TRACING_SERVICE_NAME"),
+		Endpoint:    src.get("TRACING_ENDPOINT"),
+		File:        src.get("TRACING_FILE"),
+		SampleRatio: 1,
+	}
+	switch cfg.Exporter {
+	case "":
+		cfg.Exporter = TracingNone
+	case TracingNone, TracingOTLP, TracingStdout:
+	case TracingFile:
+		if cfg.File == "" {
+			cfg.File = "traces.json"
+		}
+	default:
+		return TracingConfig{}, fmt.Errorf("TRACING_EXPORTER must be %s, %s, %s or %s, got %q", TracingNone, TracingOTLP, TracingStdout, TracingFile, cfg.Exporter)
+	}
+	if cfg.ServiceName == "" {
+		cfg.ServiceName = "api-order"
+	}
+
+	var err error
+	if cfg.Insecure, err = src.boolean("TRACING_INSECURE", false); err != nil {
+		return TracingConfig{}, err
+	}
+	if value := src.get("TRACING_SAMPLE_RATIO"); value != "" {
+		ratio, err := strconv.ParseFloat(value, 64)
+		if err != nil || ratio < 0 || ratio > 1 {
+			return TracingConfig{}, fmt.Errorf("TRACING_SAMPLE_RATIO must be a number between 0 and 1, got %q", value)
+		}
+		cfg.SampleRatio = ratio
+	}
+	return cfg, nil
+}
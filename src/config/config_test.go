@@ -0,0 +1,138 @@
+package config_test
+
+import (
+	"api-order/src/config"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// clearConfigEnv isolates a test from the settings of the machine running it.
+func clearConfigEnv(t *testing.T) {
+	t.Helper()
+	for _, key := range []string{
+		"CONFIG_FILE", "HOST_SERVER", "PORT_SERVER", "CORS_ALLOWED_ORIGINS", "CORS_MAX_AGE",
+		"JWT_SECRET_KEY", "JWT_TTL", "JWT_ISSUER", "USER_CACHE_TTL", "DB_DRIVER", "DB_PATH", "DB_HOST", "DB_NAME",
+		"DB_TIMESCALE", "DB_AUTO_MIGRATE", "DB_MAX_OPEN_CONNS", "DB_MAX_IDLE_CONNS",
+		"DB_CONN_MAX_LIFETIME", "DB_READ_TIMEOUT", "STATISTICS_CACHE_TTL", "RETENTION_RAW_DAYS",
+		"METRICS_READINGS_BY_KIT",
+	} {
+		t.Setenv(key, "")
+	}
+}
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+func TestLoadConfigFromYAML(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv("CONFIG_FILE", writeConfigFile(t, "config.yaml", `
+server:
+  host: 0.0.0.0
+  port: 9090
+cors:
+  allowed_origins: [https://app.example.com, http://localhost:5173]
+  max_age: 1h
+jwt:
+  secret_key: yaml-secret-key-of-at-least-32-bytes
+  ttl: 12h
+database:
+  driver: sqlite
+  path: /tmp/api.db
+  read_timeout: 3s
+garden_data:
+  retention_raw_days: 30
+  readings_by_kit: true
+`))
+	// The environment takes precedence over the file.
+	t.Setenv("JWT_ISSUER", "env-issuer")
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("failed to load configuration: %v", err)
+	}
+	if cfg.Server.Addr() != "0.0.0.0:9090" {
+		t.Fatalf("server address: got %q", cfg.Server.Addr())
+	}
+	if strings.Join(cfg.Cors.AllowedOrigins, ",") != "https://app.example.com,http://localhost:5173" || cfg.Cors.MaxAge != time.Hour {
+		t.Fatalf("cors: got %+v", cfg.Cors)
+	}
+	if cfg.JWT.TTL != 12*time.Hour || cfg.JWT.Issuer != "env-issuer" || cfg.JWT.UserCacheTTL != 30*time.Second {
+		t.Fatalf("jwt: got ttl %s and issuer %q", cfg.JWT.TTL, cfg.JWT.Issuer)
+	}
+	if cfg.Database.Driver != config.DriverSQLite || cfg.Database.Path != "/tmp/api.db" || cfg.Database.ReadTimeout != 3*time.Second || !cfg.Database.AutoMigrate {
+		t.Fatalf("database: got %+v", cfg.Database)
+	}
+	if cfg.GardenData.Retention.RawDays != 30 || cfg.GardenData.StatisticsCacheTTL != 5*time.Minute || !cfg.GardenData.ReadingsByKit {
+		t.Fatalf("garden data: got %+v", cfg.GardenData)
+	}
+}
+
+func TestLoadConfigFromTOML(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv("CONFIG_FILE", writeConfigFile(t, "config.toml", `
+[server]
+host = "127.0.0.1"
+port = 8080
+
+[jwt]
+secret_key = "toml-secret-key-of-at-least-32-bytes"
+
+[database]
+host = "db"
+name = "api"
+max_open_conns = 10
+max_idle_conns = 5
+conn_max_lifetime = "5m"
+`))
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("failed to load configuration: %v", err)
+	}
+	db := cfg.Database
+	if db.Driver != config.DriverMySQL || db.MaxOpenConns != 10 || db.MaxIdleConns != 5 || db.ConnMaxLifetime != 5*time.Minute {
+		t.Fatalf("database: got %+v", db)
+	}
+	if cfg.Cors.AllowedOrigins != nil || cfg.Cors.MaxAge != 24*time.Hour || cfg.JWT.TTL != 72*time.Hour {
+		t.Fatalf("defaults: got cors %+v and jwt ttl %s", cfg.Cors, cfg.JWT.TTL)
+	}
+}
+
+func TestLoadConfigReportsEveryError(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv("HOST_SERVER", "localhost")
+	t.Setenv("PORT_SERVER", "http")
+	t.Setenv("CORS_ALLOWED_ORIGINS", "app.example.com")
+	t.Setenv("DB_DRIVER", "sqlite")
+	t.Setenv("DB_MAX_OPEN_CONNS", "0")
+	t.Setenv("STATISTICS_CACHE_TTL", "soon")
+
+	_, err := config.Load()
+	if err == nil {
+		t.Fatal("invalid configuration was accepted")
+	}
+	for _, want := range []string{"PORT_SERVER", "CORS_ALLOWED_ORIGINS", "JWT_SECRET_KEY", "DB_MAX_OPEN_CONNS", "STATISTICS_CACHE_TTL"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error does not mention %s: %v", want, err)
+		}
+	}
+}
+
+func TestLoadConfigRejectsUnknownFileKeys(t *testing.T) {
+	clearConfigEnv(t)
+	t.Setenv("CONFIG_FILE", writeConfigFile(t, "config.yml", "server:\n  hots: localhost\n"))
+
+	_, err := config.Load()
+	if err == nil || !strings.Contains(err.Error(), "server.hots") {
+		t.Fatalf("got %v, want an unknown setting error", err)
+	}
+}
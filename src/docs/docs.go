@@ -68,7 +68,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body, validation failed, invalid Kit ID, or invalid alert type",
+                        "description": "Invalid request body, validation failed, or invalid alert type",
                         "schema": {
                             "$ref": "#/definitions/responses.Response"
                         }
@@ -79,11 +79,23 @@ const docTemplate = `{
                             "$ref": "#/definitions/responses.Response"
                         }
                     },
+                    "404": {
+                        "description": "Kit ID not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error while registering alert",
                         "schema": {
                             "$ref": "#/definitions/responses.Response"
                         }
+                    },
+                    "504": {
+                        "description": "Operation timed out",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
                     }
                 }
             }
@@ -95,7 +107,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves all alerts for a given kit ID, ordered by timestamp descending.",
+                "description": "Retrieves a page of alerts for a given kit ID, newest first by default. Pass the returned next_cursor as \"cursor\" to get the following page.",
                 "produces": [
                     "application/json"
                 ],
@@ -111,6 +123,36 @@ const docTemplate = `{
                         "name": "kit_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size (max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "-timestamp",
+                            "timestamp"
+                        ],
+                        "type": "string",
+                        "default": "-timestamp",
+                        "description": "Sort order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only alerts of this type",
+                        "name": "filter[alert_type]",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -136,7 +178,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid Kit ID provided",
+                        "description": "Invalid Kit ID or pagination parameters",
                         "schema": {
                             "$ref": "#/definitions/responses.Response"
                         }
@@ -152,13 +194,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/responses.Response"
                         }
+                    },
+                    "504": {
+                        "description": "Operation timed out",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
                     }
                 }
             }
         },
         "/v1/garden/data/": {
             "post": {
-                "description": "Receives and stores a new set of sensor readings for a specific kit.",
+                "description": "Receives and stores a new set of sensor readings for a specific kit. Besides the four fixed fields, any registered metric can be sent in \"metrics\".",
                 "consumes": [
                     "application/json"
                 ],
@@ -200,7 +248,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body or validation failed",
+                        "description": "Invalid request body, validation failed, or unknown/out of range metric",
                         "schema": {
                             "$ref": "#/definitions/responses.Response"
                         }
@@ -212,7 +260,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Kit ID not found (if validation added)",
+                        "description": "Kit ID not found",
                         "schema": {
                             "$ref": "#/definitions/responses.Response"
                         }
@@ -222,52 +270,50 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/responses.Response"
                         }
+                    },
+                    "504": {
+                        "description": "Operation timed out",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
                     }
                 }
             }
         },
-        "/v1/garden/data/kit/{kit_id}/minutes/{minutes}": {
+        "/v1/garden/data/import/jobs/{job_id}": {
             "get": {
                 "security": [
                     {
-                        "BearerAuth // Or appropriate scheme": []
+                        "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves garden sensor data records for a specific kit recorded within the last N minutes.",
+                "description": "Returns the progress counters and per-line errors of a bulk import job started by the authenticated user.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "GardenData"
                 ],
-                "summary": "Get Recent Garden Data",
+                "summary": "Get Import Job Progress",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Bearer Token or API Key",
+                        "description": "Bearer Token",
                         "name": "Authorization",
                         "in": "header",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "format": "int64",
-                        "description": "Kit ID",
-                        "name": "kit_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Number of past minutes to fetch data for",
-                        "name": "minutes",
+                        "type": "string",
+                        "description": "Import job ID",
+                        "name": "job_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Data retrieved successfully",
+                        "description": "Import job retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -277,42 +323,21 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/entities.GardenDataResponse"
-                                            }
+                                            "$ref": "#/definitions/entities.ImportJob"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Invalid Kit ID or Minutes parameter",
-                        "schema": {
-                            "$ref": "#/definitions/responses.Response"
-                        }
-                    },
                     "401": {
-                        "description": "Unauthorized - Invalid or missing token/key",
-                        "schema": {
-                            "$ref": "#/definitions/responses.Response"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden - User does not have access to this Kit ID",
+                        "description": "Unauthorized - Invalid or missing token",
                         "schema": {
                             "$ref": "#/definitions/responses.Response"
                         }
                     },
                     "404": {
-                        "description": "Kit ID not found (if validation added)",
-                        "schema": {
-                            "$ref": "#/definitions/responses.Response"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error while retrieving data",
+                        "description": "Import job not found",
                         "schema": {
                             "$ref": "#/definitions/responses.Response"
                         }
@@ -320,24 +345,41 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/kits/": {
+        "/v1/garden/data/kit/{kit_id}/anomaly-settings": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves all kits associated with the user identified by the JWT token.",
+                "description": "Returns the anomaly detector tuning of a kit, or the defaults if it was never changed.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Kits"
+                    "GardenData"
+                ],
+                "summary": "Get Anomaly Detector Settings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Kit ID",
+                        "name": "kit_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get kits for the authenticated user",
                 "responses": {
                     "200": {
-                        "description": "Kits retrieved successfully",
+                        "description": "Settings retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -347,98 +389,58 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/entities.Kit"
-                                            }
+                                            "$ref": "#/definitions/entities.AnomalySettings"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Invalid Kit ID",
                         "schema": {
                             "$ref": "#/definitions/responses.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing token",
                         "schema": {
                             "$ref": "#/definitions/responses.Response"
                         }
-                    }
-                }
-            }
-        },
-        "/v1/users/": {
-            "post": {
-                "description": "Registers a new user with validation against an existing kit code. The kit code must NOT exist in the kits table.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Users"
-                ],
-                "summary": "Register a new user",
-                "parameters": [
-                    {
-                        "description": "User Registration Data",
-                        "name": "user",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/request.RegisterUserRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "User registered successfully",
+                    },
+                    "403": {
+                        "description": "Kit belongs to another user",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/responses.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/entities.UserResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/responses.Response"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request body or validation failed",
+                    "404": {
+                        "description": "Kit not found",
                         "schema": {
                             "$ref": "#/definitions/responses.Response"
                         }
                     },
-                    "409": {
-                        "description": "Conflict - Email already exists OR Kit Code already exists",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/responses.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error during registration",
+                    "504": {
+                        "description": "Operation timed out",
                         "schema": {
                             "$ref": "#/definitions/responses.Response"
                         }
                     }
                 }
-            }
-        },
-        "/v1/users/login": {
-            "post": {
-                "description": "Logs in a user using email and password, returns user details and a JWT token upon success.",
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Tunes the sensitivity of the anomaly detector for a kit. Lower z_score_threshold and spike_fraction values, or a shorter flatline_minutes, raise more alerts; 0 disables a check. Omitted fields keep their current value.",
                 "consumes": [
                     "application/json"
                 ],
@@ -446,23 +448,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users Authentication"
+                    "GardenData"
                 ],
-                "summary": "Authenticate a user",
+                "summary": "Update Anomaly Detector Settings",
                 "parameters": [
                     {
-                        "description": "User Login Credentials",
-                        "name": "credentials",
+                        "type": "string",
+                        "description": "Bearer Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Kit ID",
+                        "name": "kit_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Detector settings",
+                        "name": "settings",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.LoginRequest"
+                            "$ref": "#/definitions/request.UpdateAnomalySettingsRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Login successful",
+                        "description": "Settings updated successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -472,7 +489,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/controllers.LoginResponseData"
+                                            "$ref": "#/definitions/entities.AnomalySettings"
                                         }
                                     }
                                 }
@@ -480,25 +497,37 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request body format or validation failed",
+                        "description": "Invalid Kit ID or settings",
                         "schema": {
                             "$ref": "#/definitions/responses.Response"
                         }
                     },
                     "401": {
-                        "description": "Incorrect password or invalid credentials",
+                        "description": "Unauthorized - Invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Kit belongs to another user",
                         "schema": {
                             "$ref": "#/definitions/responses.Response"
                         }
                     },
                     "404": {
-                        "description": "Email not found",
+                        "description": "Kit not found",
                         "schema": {
                             "$ref": "#/definitions/responses.Response"
                         }
                     },
                     "500": {
-                        "description": "Internal server error during login or token generation",
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Operation timed out",
                         "schema": {
                             "$ref": "#/definitions/responses.Response"
                         }
@@ -506,21 +535,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/users/{id}": {
+        "/v1/garden/data/kit/{kit_id}/completeness": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves the details of a specific user by their ID. Requires authentication.",
+                "description": "Compares the readings of a kit with its expected sampling interval: lists the missing intervals and the uptime percentage of every day. \"from\" and \"to\" accept RFC3339, YYYY-MM-DD (in the requested timezone) or unix seconds; the default range is the last 7 days and the maximum is 92 days.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "GardenData"
                 ],
-                "summary": "Get user by ID",
+                "summary": "Get Data Completeness Report",
                 "parameters": [
                     {
                         "type": "string",
@@ -532,15 +561,33 @@ const docTemplate = `{
                     {
                         "type": "integer",
                         "format": "int64",
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "Kit ID",
+                        "name": "kit_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the range (inclusive)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the range (exclusive)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone used to split days and parse dates; defaults to the user's timezone",
+                        "name": "tz",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User retrieved successfully",
+                        "description": "Report computed successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -550,7 +597,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/entities.UserResponse"
+                                            "$ref": "#/definitions/entities.CompletenessReport"
                                         }
                                     }
                                 }
@@ -558,7 +605,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid user ID provided",
+                        "description": "Invalid Kit ID, range or timezone",
                         "schema": {
                             "$ref": "#/definitions/responses.Response"
                         }
@@ -570,42 +617,49 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden - User attempting to access another user's data (if implemented)",
+                        "description": "Kit belongs to another user",
                         "schema": {
                             "$ref": "#/definitions/responses.Response"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Kit not found",
                         "schema": {
                             "$ref": "#/definitions/responses.Response"
                         }
                     },
                     "500": {
-                        "description": "Internal server error while retrieving user",
+                        "description": "Internal server error while computing the report",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Operation timed out",
                         "schema": {
                             "$ref": "#/definitions/responses.Response"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/v1/garden/data/kit/{kit_id}/export": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates the first name and last name for the specified user ID. Requires authentication, and users can typically only update their own data.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Streams the sensor history of a kit for a time range as CSV, NDJSON or XLSX. Rows are read with a cursor, so large ranges are never loaded in memory at once. Temperatures are in the user's preferred unit. \"from\" and \"to\" accept RFC3339, YYYY-MM-DD (in the requested timezone) or unix seconds; the default range is the last 24 hours.",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
                 ],
                 "tags": [
-                    "Users"
+                    "GardenData"
                 ],
-                "summary": "Update user information",
+                "summary": "Export Garden Data",
                 "parameters": [
                     {
                         "type": "string",
@@ -617,24 +671,1687 @@ const docTemplate = `{
                     {
                         "type": "integer",
                         "format": "int64",
-                        "description": "User ID",
-                        "name": "id",
+                        "description": "Kit ID",
+                        "name": "kit_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the range (inclusive)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of the range (exclusive)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "csv",
+                            "ndjson",
+                            "xlsx"
+                        ],
+                        "type": "string",
+                        "default": "csv",
+                        "description": "Output format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated columns: data_id, kit_id, temperature, ground_humidity, environment_humidity, ph_level, time, device_time, timestamp, event_time, clock_skew_seconds, and any other metric the kit declares",
+                        "name": "columns",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone used to render timestamps and parse dates; defaults to the user's timezone",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Exported data",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Kit ID, range, format, columns or timezone",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Kit belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Kit not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while exporting data",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Operation timed out",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/garden/data/kit/{kit_id}/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Uploads a CSV file of historical readings (e.g. recovered from a kit's SD card). The file is parsed immediately and imported in the background; poll the returned job for progress. Rows whose device time already exists for the kit are skipped as duplicates.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "GardenData"
+                ],
+                "summary": "Import Historical Garden Data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Kit ID",
+                        "name": "kit_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "CSV file with a header row",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Column mapping as field=column pairs, e.g. time=ts,temperature=temp_c. Defaults to using the header names.",
+                        "name": "mapping",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Import job accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/responses.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entities.ImportJob"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Kit ID, file or mapping",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Kit belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Kit not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while starting the import",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Operation timed out",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/garden/data/kit/{kit_id}/minutes/{minutes}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth // Or appropriate scheme": []
+                    }
+                ],
+                "description": "Retrieves garden sensor data records for a specific kit recorded within the last N minutes. GET /v1/garden/data/kit/{kit_id}/minutes uses the default dashboard range of the user instead. Temperatures and times follow the user's preferences.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "GardenData"
+                ],
+                "summary": "Get Recent Garden Data",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer Token or API Key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Kit ID",
+                        "name": "kit_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of past minutes to fetch data for",
+                        "name": "minutes",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 500,
+                        "description": "Page size (max 5000); rollup records are appended to the last page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "-timestamp",
+                            "-event_time"
+                        ],
+                        "type": "string",
+                        "default": "-timestamp",
+                        "description": "Column used for the window and descending order",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Data retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/responses.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entities.GardenDataResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Kit ID, Minutes or pagination parameters",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing token/key",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - User does not have access to this Kit ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Kit ID not found (if validation added)",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while retrieving data",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Operation timed out",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/garden/data/kit/{kit_id}/samples/minutes/{minutes}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves typed metric samples for a kit recorded within the last N minutes. Filter with one or more \"metric\" query parameters. GET /v1/garden/data/kit/{kit_id}/samples/minutes uses the default dashboard range of the user instead. Temperatures and times follow the user's preferences.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "GardenData"
+                ],
+                "summary": "Get Recent Metric Samples",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer Token or API Key",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Kit ID",
+                        "name": "kit_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of past minutes to fetch data for",
+                        "name": "minutes",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Metric names to include (repeat or comma separate)",
+                        "name": "metric",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1000,
+                        "description": "Page size (max 10000); rollup samples are appended to the last page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "-timestamp",
+                            "-event_time"
+                        ],
+                        "type": "string",
+                        "default": "-timestamp",
+                        "description": "Column used for the window and descending order",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Samples retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/responses.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entities.MetricSample"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Kit ID, Minutes or pagination parameters",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing token/key",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while retrieving data",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Operation timed out",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/garden/data/kit/{kit_id}/statistics": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Summarises the readings of a kit for the current day, week (from Monday) or month: per metric min, max, average and standard deviation, the percentage of samples within the kit's thresholds, the hours with readings out of range, and the number of alerts raised. Results are cached until the kit sends new readings. Temperatures follow the user's preferred unit.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "GardenData"
+                ],
+                "summary": "Get Kit Statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Kit ID",
+                        "name": "kit_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month"
+                        ],
+                        "type": "string",
+                        "default": "day",
+                        "description": "Statistics period",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "IANA timezone used to align the period; defaults to the user's timezone",
+                        "name": "tz",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Statistics computed successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/responses.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entities.KitStatistics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Kit ID, period or timezone",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Kit belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Kit not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while computing statistics",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Operation timed out",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/kits/": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a page of the kits associated with the user identified by the JWT token.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kits"
+                ],
+                "summary": "Get kits for the authenticated user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size (max 200)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Kits to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "-name",
+                            "created_at",
+                            "-created_at"
+                        ],
+                        "type": "string",
+                        "default": "name",
+                        "description": "Sort order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only kits whose name contains this text",
+                        "name": "filter[name]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Kits retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/responses.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entities.Kit"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid pagination parameters",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Operation timed out",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/kits/{kit_id}/sampling-interval": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sets how often the kit is expected to send readings. It is used to find gaps in the data.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Kits"
+                ],
+                "summary": "Update kit sampling interval",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Kit ID",
+                        "name": "kit_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Expected seconds between readings",
+                        "name": "interval",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateSamplingIntervalRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Sampling interval updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/responses.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entities.Kit"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Kit ID or interval",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Kit belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Kit not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Operation timed out",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/metrics/": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns a page of the metric definitions known to the registry, including the four built-in ones.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Metrics"
+                ],
+                "summary": "List registered metrics",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Page size (max 500)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Metrics to skip",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "name",
+                            "-name",
+                            "created_at",
+                            "-created_at"
+                        ],
+                        "type": "string",
+                        "default": "name",
+                        "description": "Sort order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only metrics whose name contains this text",
+                        "name": "filter[name]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Metrics retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/responses.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entities.Metric"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid pagination parameters",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Operation timed out",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Adds a new metric definition (name, unit and valid range) to the registry so kits can report it. Only the administrators listed in METRIC_ADMIN_EMAILS may register metrics.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Metrics"
+                ],
+                "summary": "Register a metric",
+                "parameters": [
+                    {
+                        "description": "Metric definition",
+                        "name": "metric",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RegisterMetricRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Metric registered successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/responses.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entities.Metric"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "User is not a metric administrator",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Metric already registered",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Operation timed out",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/metrics/kit/{kit_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the metrics a kit declared to carry.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Metrics"
+                ],
+                "summary": "Get kit sensors",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Kit ID",
+                        "name": "kit_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Kit sensors retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/responses.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entities.KitSensor"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Kit ID provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Kit belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Kit not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Operation timed out",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces the list of metrics a kit carries. Ingestion rejects non built-in metrics the kit did not declare.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Metrics"
+                ],
+                "summary": "Declare kit sensors",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Kit ID",
+                        "name": "kit_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Metric names carried by the kit",
+                        "name": "sensors",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SetKitSensorsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Kit sensors updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/responses.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/entities.KitSensor"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Kit ID, request body or unknown metric",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Kit belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Kit not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Operation timed out",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/metrics/kit/{kit_id}/thresholds/{metric}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sets the healthy min/max range of a metric for a kit, declaring the sensor if needed. Statistics report the percentage of time inside this range.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Metrics"
+                ],
+                "summary": "Set kit sensor thresholds",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "Kit ID",
+                        "name": "kit_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Metric name",
+                        "name": "metric",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Threshold values",
+                        "name": "thresholds",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.SetKitSensorThresholdsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Thresholds updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/responses.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entities.KitSensor"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid Kit ID, unknown metric or invalid thresholds",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Kit belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Kit not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Operation timed out",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/": {
+            "post": {
+                "description": "Registers a new user with validation against an existing kit code. The kit code must NOT exist in the kits table.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "User Registration Data",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.RegisterUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User registered successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/responses.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entities.UserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - Email already exists OR Kit Code already exists",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error during registration",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Operation timed out",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/login": {
+            "post": {
+                "description": "Logs in a user using email and password, returns user details and a JWT token upon success.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users Authentication"
+                ],
+                "summary": "Authenticate a user",
+                "parameters": [
+                    {
+                        "description": "User Login Credentials",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Login successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/responses.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/controllers.LoginResponseData"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body format or validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Incorrect password or invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Email not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error during login or token generation",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Operation timed out",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the details of a specific user by their ID. Requires authentication.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/responses.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entities.UserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID provided",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - User attempting to access another user's data (if implemented)",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while retrieving user",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Operation timed out",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates the first name, last name and preferred language for the specified user ID. Requires authentication, and users can typically only update their own data.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User Data to Update",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/request.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/responses.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/entities.UserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID or request body validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - User attempting to update another user's data",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error during update",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Operation timed out",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/{id}/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the temperature unit, timezone, language and default dashboard range of the authenticated user. Garden data and statistics responses are rendered with them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user preferences",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Preferences retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/responses.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/preferences.Preferences"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - User attempting to read another user's preferences",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Operation timed out",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replaces the temperature unit (C or F), IANA timezone, language and default dashboard range in minutes of the authenticated user. They apply to the next request, without logging in again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user preferences",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bearer Token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "description": "User ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "User Data to Update",
-                        "name": "user",
+                        "description": "New preferences",
+                        "name": "preferences",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/request.UpdateUserRequest"
+                            "$ref": "#/definitions/request.UpdatePreferencesRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User updated successfully",
+                        "description": "Preferences updated successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -644,7 +2361,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/entities.UserResponse"
+                                            "$ref": "#/definitions/preferences.Preferences"
                                         }
                                     }
                                 }
@@ -652,7 +2369,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid user ID or request body validation failed",
+                        "description": "Invalid user ID, validation failed or unknown timezone",
                         "schema": {
                             "$ref": "#/definitions/responses.Response"
                         }
@@ -664,7 +2381,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "Forbidden - User attempting to update another user's data",
+                        "description": "Forbidden - User attempting to update another user's preferences",
                         "schema": {
                             "$ref": "#/definitions/responses.Response"
                         }
@@ -676,82 +2393,344 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Internal server error during update",
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.Response"
+                        }
+                    },
+                    "504": {
+                        "description": "Operation timed out",
                         "schema": {
                             "$ref": "#/definitions/responses.Response"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "controllers.LoginResponseData": {
+        }
+    },
+    "definitions": {
+        "controllers.LoginResponseData": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                },
+                "user": {
+                    "$ref": "#/definitions/entities.UserResponse"
+                }
+            }
+        },
+        "entities.Alert": {
+            "type": "object",
+            "properties": {
+                "alert_id": {
+                    "description": "Corresponds to alert_id PK",
+                    "type": "integer"
+                },
+                "alert_type": {
+                    "description": "Type of alert (e.g., \"under_min\", \"higher_max\")",
+                    "type": "string"
+                },
+                "kit_id": {
+                    "description": "Foreign key to kits table",
+                    "type": "integer"
+                },
+                "message": {
+                    "description": "Detailed message for the alert",
+                    "type": "string"
+                },
+                "timestamp": {
+                    "description": "Timestamp from DB default",
+                    "type": "string"
+                }
+            }
+        },
+        "entities.AnomalySettings": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "flatline_minutes": {
+                    "type": "integer"
+                },
+                "kit_id": {
+                    "type": "integer"
+                },
+                "spike_fraction": {
+                    "type": "number"
+                },
+                "z_score_threshold": {
+                    "type": "number"
+                }
+            }
+        },
+        "entities.CompletenessReport": {
+            "type": "object",
+            "properties": {
+                "days": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.DailyUptime"
+                    }
+                },
+                "expected_samples": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "gaps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.DataGap"
+                    }
+                },
+                "kit_id": {
+                    "type": "integer"
+                },
+                "received_samples": {
+                    "type": "integer"
+                },
+                "sampling_interval_seconds": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "uptime_percent": {
+                    "type": "number"
+                }
+            }
+        },
+        "entities.DailyUptime": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "YYYY-MM-DD in the report timezone",
+                    "type": "string"
+                },
+                "downtime_seconds": {
+                    "type": "integer"
+                },
+                "expected_samples": {
+                    "type": "integer"
+                },
+                "longest_gap_seconds": {
+                    "type": "integer"
+                },
+                "received_samples": {
+                    "type": "integer"
+                },
+                "uptime_percent": {
+                    "type": "number"
+                }
+            }
+        },
+        "entities.DataGap": {
+            "type": "object",
+            "properties": {
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "end": {
+                    "type": "string"
+                },
+                "missing_samples": {
+                    "type": "integer"
+                },
+                "start": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.GardenDataResponse": {
+            "type": "object",
+            "properties": {
+                "aggregation": {
+                    "type": "string"
+                },
+                "clock_skew_seconds": {
+                    "type": "integer"
+                },
+                "data_id": {
+                    "type": "integer"
+                },
+                "environment_humidity": {
+                    "type": "number"
+                },
+                "event_time": {
+                    "type": "string"
+                },
+                "ground_humidity": {
+                    "type": "number"
+                },
+                "kit_id": {
+                    "type": "integer"
+                },
+                "metrics": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
+                "ph_level": {
+                    "type": "number"
+                },
+                "temperature": {
+                    "type": "number"
+                },
+                "temperature_unit": {
+                    "type": "string"
+                },
+                "time": {
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.ImportJob": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "duplicate_rows": {
+                    "type": "integer"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.ImportLineError"
+                    }
+                },
+                "failed_rows": {
+                    "type": "integer"
+                },
+                "failure": {
+                    "type": "string"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "inserted_rows": {
+                    "type": "integer"
+                },
+                "kit_id": {
+                    "type": "integer"
+                },
+                "processed_rows": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total_rows": {
+                    "type": "integer"
+                }
+            }
+        },
+        "entities.ImportLineError": {
             "type": "object",
             "properties": {
-                "token": {
-                    "type": "string"
+                "line": {
+                    "type": "integer"
                 },
-                "user": {
-                    "$ref": "#/definitions/entities.UserResponse"
+                "message": {
+                    "type": "string"
                 }
             }
         },
-        "entities.Alert": {
+        "entities.Kit": {
             "type": "object",
             "properties": {
-                "alert_id": {
-                    "description": "Corresponds to alert_id PK",
+                "clock_drift_flagged": {
+                    "description": "Device clock is too far from server time",
+                    "type": "boolean"
+                },
+                "clock_skew_seconds": {
+                    "description": "Device minus server time, kept within 30s of the last reading",
                     "type": "integer"
                 },
-                "alert_type": {
-                    "description": "Type of alert (e.g., \"under_min\", \"higher_max\")",
+                "created_at": {
                     "type": "string"
                 },
-                "kit_id": {
-                    "description": "Foreign key to kits table",
+                "description": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "message": {
-                    "description": "Detailed message for the alert",
+                "name": {
                     "type": "string"
                 },
-                "timestamp": {
-                    "description": "Timestamp from DB default",
-                    "type": "string"
+                "sampling_interval_seconds": {
+                    "description": "Expected time between readings",
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "entities.GardenDataResponse": {
+        "entities.KitSensor": {
             "type": "object",
             "properties": {
-                "data_id": {
+                "created_at": {
+                    "type": "string"
+                },
+                "kit_id": {
                     "type": "integer"
                 },
-                "environment_humidity": {
+                "max_threshold": {
                     "type": "number"
                 },
-                "ground_humidity": {
+                "metric_name": {
+                    "type": "string"
+                },
+                "min_threshold": {
                     "type": "number"
+                }
+            }
+        },
+        "entities.KitStatistics": {
+            "type": "object",
+            "properties": {
+                "alert_count": {
+                    "type": "integer"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "generated_at": {
+                    "type": "string"
                 },
                 "kit_id": {
                     "type": "integer"
                 },
-                "ph_level": {
-                    "type": "number"
+                "metrics": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entities.MetricStatistics"
+                    }
                 },
-                "temperature": {
-                    "type": "number"
+                "period": {
+                    "type": "string"
                 },
-                "time": {
-                    "type": "integer"
+                "temperature_unit": {
+                    "type": "string"
                 },
-                "timestamp": {
+                "to": {
                     "type": "string"
                 }
             }
         },
-        "entities.Kit": {
+        "entities.Metric": {
             "type": "object",
             "properties": {
                 "created_at": {
@@ -763,11 +2742,88 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
+                "max_value": {
+                    "type": "number"
+                },
+                "min_value": {
+                    "type": "number"
+                },
                 "name": {
                     "type": "string"
                 },
-                "user_id": {
+                "unit": {
+                    "type": "string"
+                }
+            }
+        },
+        "entities.MetricSample": {
+            "type": "object",
+            "properties": {
+                "aggregation": {
+                    "description": "Aggregation is \"hour\" or \"day\" when Value is the average of a rollup bucket.",
+                    "type": "string"
+                },
+                "data_id": {
+                    "type": "integer"
+                },
+                "event_time": {
+                    "description": "When the reading was taken, corrected for device clock skew",
+                    "type": "string"
+                },
+                "kit_id": {
+                    "type": "integer"
+                },
+                "metric": {
+                    "type": "string"
+                },
+                "sample_id": {
+                    "type": "integer"
+                },
+                "time": {
+                    "description": "Unix timestamp from device",
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "description": "DB insertion timestamp",
+                    "type": "string"
+                },
+                "value": {
+                    "type": "number"
+                }
+            }
+        },
+        "entities.MetricStatistics": {
+            "type": "object",
+            "properties": {
+                "avg_value": {
+                    "type": "number"
+                },
+                "hours_out_of_range": {
+                    "type": "integer"
+                },
+                "max_threshold": {
+                    "type": "number"
+                },
+                "max_value": {
+                    "type": "number"
+                },
+                "metric": {
+                    "type": "string"
+                },
+                "min_threshold": {
+                    "type": "number"
+                },
+                "min_value": {
+                    "type": "number"
+                },
+                "percent_within_thresholds": {
+                    "type": "number"
+                },
+                "sample_count": {
                     "type": "integer"
+                },
+                "stddev": {
+                    "type": "number"
                 }
             }
         },
@@ -788,6 +2844,53 @@ const docTemplate = `{
                 },
                 "last_name": {
                     "type": "string"
+                },
+                "locale": {
+                    "type": "string"
+                }
+            }
+        },
+        "pagination.Page": {
+            "type": "object",
+            "properties": {
+                "has_more": {
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "sort": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "preferences.Preferences": {
+            "type": "object",
+            "properties": {
+                "dashboard_range_minutes": {
+                    "description": "DashboardRangeMinutes is the window of recent data when none is requested",
+                    "type": "integer"
+                },
+                "locale": {
+                    "description": "Locale is the language of the messages; empty follows Accept-Language",
+                    "type": "string"
+                },
+                "temperature_unit": {
+                    "description": "TemperatureUnit is \"C\" or \"F\"",
+                    "type": "string"
+                },
+                "timezone": {
+                    "description": "Timezone is an IANA name, e.g. \"Europe/Madrid\", used for local times",
+                    "type": "string"
                 }
             }
         },
@@ -848,6 +2951,12 @@ const docTemplate = `{
                     "description": "Ensure KitID is positive",
                     "type": "integer"
                 },
+                "metrics": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                },
                 "ph_level": {
                     "type": "number"
                 },
@@ -861,6 +2970,34 @@ const docTemplate = `{
                 }
             }
         },
+        "request.RegisterMetricRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "unit"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "max_value": {
+                    "type": "number"
+                },
+                "min_value": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 2
+                },
+                "unit": {
+                    "type": "string",
+                    "maxLength": 20
+                }
+            }
+        },
         "request.RegisterUserRequest": {
             "type": "object",
             "required": [
@@ -884,12 +3021,113 @@ const docTemplate = `{
                 "last_name": {
                     "type": "string"
                 },
+                "locale": {
+                    "description": "Locale is the preferred language; empty follows Accept-Language",
+                    "type": "string",
+                    "enum": [
+                        "es",
+                        "en"
+                    ]
+                },
                 "password": {
                     "type": "string",
                     "minLength": 6
                 }
             }
         },
+        "request.SetKitSensorThresholdsRequest": {
+            "type": "object",
+            "properties": {
+                "max_threshold": {
+                    "type": "number"
+                },
+                "min_threshold": {
+                    "type": "number"
+                }
+            }
+        },
+        "request.SetKitSensorsRequest": {
+            "type": "object",
+            "required": [
+                "metrics"
+            ],
+            "properties": {
+                "metrics": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "request.UpdateAnomalySettingsRequest": {
+            "type": "object",
+            "properties": {
+                "enabled": {
+                    "type": "boolean"
+                },
+                "flatline_minutes": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "spike_fraction": {
+                    "type": "number",
+                    "maximum": 1,
+                    "minimum": 0
+                },
+                "z_score_threshold": {
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "request.UpdatePreferencesRequest": {
+            "type": "object",
+            "required": [
+                "dashboard_range_minutes",
+                "temperature_unit",
+                "timezone"
+            ],
+            "properties": {
+                "dashboard_range_minutes": {
+                    "type": "integer",
+                    "maximum": 43200,
+                    "minimum": 1
+                },
+                "locale": {
+                    "description": "Locale is the preferred language; empty follows Accept-Language",
+                    "type": "string",
+                    "enum": [
+                        "es",
+                        "en"
+                    ]
+                },
+                "temperature_unit": {
+                    "type": "string",
+                    "enum": [
+                        "C",
+                        "F"
+                    ]
+                },
+                "timezone": {
+                    "description": "Nombre IANA, p. ej. Europe/Madrid",
+                    "type": "string"
+                }
+            }
+        },
+        "request.UpdateSamplingIntervalRequest": {
+            "type": "object",
+            "required": [
+                "sampling_interval_seconds"
+            ],
+            "properties": {
+                "sampling_interval_seconds": {
+                    "type": "integer",
+                    "maximum": 86400,
+                    "minimum": 10
+                }
+            }
+        },
         "request.UpdateUserRequest": {
             "type": "object",
             "required": [
@@ -902,17 +3140,65 @@ const docTemplate = `{
                 },
                 "last_name": {
                     "type": "string"
+                },
+                "locale": {
+                    "description": "Locale keeps the stored preference when omitted",
+                    "type": "string",
+                    "enum": [
+                        "es",
+                        "en"
+                    ]
+                }
+            }
+        },
+        "responses.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "Field is the JSON path of the field, e.g. \"email\" or \"metrics[0]\"",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Message explains the failure to the user",
+                    "type": "string"
+                },
+                "param": {
+                    "description": "Param is the argument of the rule, e.g. \"6\" for min=6",
+                    "type": "string"
+                },
+                "rule": {
+                    "description": "Rule is the validation rule that failed, e.g. \"required\" or \"min\"",
+                    "type": "string"
                 }
             }
         },
         "responses.Response": {
             "type": "object",
             "properties": {
+                "code": {
+                    "description": "Code identifies the error for clients, e.g. \"kit_not_found\"; empty on success",
+                    "type": "string"
+                },
                 "data": {},
                 "error": {},
+                "fields": {
+                    "description": "Fields lists the rejected fields of an invalid request body",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responses.FieldError"
+                    }
+                },
                 "message": {
                     "type": "string"
                 },
+                "pagination": {
+                    "description": "Pagination is set by list endpoints",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/pagination.Page"
+                        }
+                    ]
+                },
                 "success": {
                     "type": "boolean"
                 }
@@ -936,7 +3222,7 @@ var SwaggerInfo = &swag.Spec{
 	BasePath:         "/v1             // Base path de tu API v1",
 	Schemes:          []string{},
 	Title:            "API Hexagonal Go (Sensor Kits)",
-	Description:      "API para gestionar kits de sensores y sus datos.",
+	Description:      "API para gestionar kits de sensores y sus datos. Los mensajes se devuelven en español (es) o inglés (en) según la preferencia del usuario o Accept-Language.",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
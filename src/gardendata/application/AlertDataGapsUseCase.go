@@ -0,0 +1,97 @@
+package application
+
+import (
+	alertEntities "api-order/src/alert/domain/entities"
+	alert "api-order/src/alert/domain/ports"
+	"api-order/src/gardendata/domain/ports"
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/tracing"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// gapCheckPageSize is the number of kits whose last record is read per query.
+const gapCheckPageSize = 500
+
+type AlertDataGapsUseCase struct {
+	GardenDataRepository ports.IGardenData
+	AlertRepository      alert.IAlert
+	Threshold            time.Duration
+	Locales              *AlertLocales
+	StatisticsCache      ports.IStatisticsCache
+
+	// alerted remembers the last record each kit was alerted for, so a gap
+	// raises one alert. It is kept in memory, so a restart may repeat an alert.
+	mu      sync.Mutex
+	alerted map[int64]time.Time
+}
+
+func NewAlertDataGapsUseCase(repo ports.IGardenData, alertRepo alert.IAlert, locales *AlertLocales, cache ports.IStatisticsCache, threshold time.Duration) *AlertDataGapsUseCase {
+	return &AlertDataGapsUseCase{
+		GardenDataRepository: repo,
+		AlertRepository:      alertRepo,
+		Threshold:            threshold,
+		Locales:              locales,
+		StatisticsCache:      cache,
+		alerted:              make(map[int64]time.Time),
+	}
+}
+
+// Run raises a data_gap alert for every kit whose latest reading is older than
+// the threshold and returns how many alerts were raised. Kits are read in pages
+// of gapCheckPageSize, so a tick never loads every kit at once.
+func (uc *AlertDataGapsUseCase) Run(ctx context.Context, now time.Time) (int, error) {
+	ctx, span := tracing.Start(ctx, "AlertDataGapsUseCase.Run")
+	defer span.End()
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	raised := 0
+	for afterKitID := int64(0); ; {
+		last, err := uc.GardenDataRepository.GetLastRecordTimes(ctx, afterKitID, gapCheckPageSize)
+		if err != nil {
+			return raised, fmt.Errorf("failed to retrieve last record times: %w", err)
+		}
+		for kitID, lastRecord := range last {
+			afterKitID = max(afterKitID, kitID)
+			alerted, err := uc.checkKit(ctx, kitID, lastRecord, now)
+			if err != nil {
+				return raised, err
+			}
+			if alerted {
+				raised++
+			}
+		}
+		if len(last) < gapCheckPageSize {
+			return raised, nil
+		}
+	}
+}
+
+// checkKit raises a data_gap alert when the last record of a kit is older than
+// the threshold and the gap was not alerted yet. Kits without data are skipped.
+func (uc *AlertDataGapsUseCase) checkKit(ctx context.Context, kitID int64, lastRecord, now time.Time) (bool, error) {
+	silence := now.Sub(lastRecord)
+	if lastRecord.IsZero() || silence < uc.Threshold || uc.alerted[kitID].Equal(lastRecord) {
+		return false, nil
+	}
+
+	_, err := uc.AlertRepository.Create(ctx, alertEntities.Alert{
+		KitID:     int(kitID),
+		AlertType: alertEntities.AlertTypeDataGap,
+		Message: i18n.Translate(uc.Locales.Locale(ctx, kitID), "alert.data_gap",
+			lastRecord.UTC().Format(time.RFC3339), silence.Round(time.Minute)),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to raise data gap alert for kit %d: %w", kitID, err)
+	}
+	uc.alerted[kitID] = lastRecord
+	// The statistics count the alerts of the kit
+	if uc.StatisticsCache != nil {
+		uc.StatisticsCache.Invalidate(kitID)
+	}
+	return true, nil
+}
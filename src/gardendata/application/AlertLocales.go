@@ -0,0 +1,42 @@
+package application
+
+import (
+	kit "api-order/src/kit/domain/ports"
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/logging"
+	user "api-order/src/user/domain/ports"
+	"context"
+)
+
+// AlertLocales finds the language the alerts of a kit are written in: the
+// preference of the kit's owner, or i18n.Default when the owner has none.
+type AlertLocales struct {
+	KitRepository  kit.IKit
+	UserRepository user.IUser
+}
+
+func NewAlertLocales(kitRepo kit.IKit, userRepo user.IUser) *AlertLocales {
+	return &AlertLocales{KitRepository: kitRepo, UserRepository: userRepo}
+}
+
+// Locale returns the locale of the owner of kitID. A failed lookup falls back
+// to i18n.Default, so the alert is still raised.
+func (l *AlertLocales) Locale(ctx context.Context, kitID int64) string {
+	if l == nil {
+		return i18n.Default
+	}
+	k, err := l.KitRepository.GetByID(ctx, kitID)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Cannot resolve alert recipient, using default locale", "kit_id", kitID, "error", err)
+		return i18n.Default
+	}
+	owner, err := l.UserRepository.GetById(ctx, k.UserID)
+	if err != nil {
+		logging.FromContext(ctx).Warn("Cannot resolve alert recipient, using default locale", "kit_id", kitID, "user_id", k.UserID, "error", err)
+		return i18n.Default
+	}
+	if !i18n.Supported(owner.Locale) {
+		return i18n.Default
+	}
+	return owner.Locale
+}
@@ -0,0 +1,72 @@
+package application
+
+import (
+	"api-order/src/gardendata/domain/entities"
+	kit "api-order/src/kit/domain/ports"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// clockSkewStep is how far the measured skew of a kit may move before it is
+// stored again, so network jitter does not cause a kit write per reading.
+const clockSkewStep = 30 * time.Second
+
+// ClockDriftTracker reconciles device times of live readings with the server
+// clock and keeps the drift flag of each kit up to date.
+type ClockDriftTracker struct {
+	KitRepository kit.IKit
+	Tolerance     time.Duration
+
+	// stored caches the last skew and flag written, or being written, for each
+	// kit, so the kit row is only written when the flag changes, the skew moves
+	// by more than clockSkewStep, or the first time a kit is seen.
+	mu     sync.Mutex
+	stored map[int64]storedClockDrift
+}
+
+type storedClockDrift struct {
+	skewSeconds int64
+	flagged     bool
+}
+
+func NewClockDriftTracker(kitRepo kit.IKit, tolerance time.Duration) *ClockDriftTracker {
+	return &ClockDriftTracker{
+		KitRepository: kitRepo,
+		Tolerance:     tolerance,
+		stored:        make(map[int64]storedClockDrift),
+	}
+}
+
+// Reconcile sets the event time and clock skew of data received at received.
+func (t *ClockDriftTracker) Reconcile(ctx context.Context, data *entities.GardenData, received time.Time) error {
+	eventTime, skew, drifted := entities.ReconcileClock(data.Time, received, t.Tolerance)
+	data.EventTime = eventTime
+	data.ClockSkewSeconds = skew
+
+	// The kit row is written outside the lock so a slow database does not
+	// stall the readings of every other kit. The new value is cached first so
+	// concurrent readings of the same kit do not write it again.
+	current := storedClockDrift{skewSeconds: skew, flagged: drifted}
+	t.mu.Lock()
+	previous, seen := t.stored[data.KitID]
+	moved := time.Duration(previous.skewSeconds-skew).Abs() * time.Second
+	if seen && previous.flagged == drifted && moved <= clockSkewStep {
+		t.mu.Unlock()
+		return nil
+	}
+	t.stored[data.KitID] = current
+	t.mu.Unlock()
+
+	if err := t.KitRepository.UpdateClockDrift(ctx, data.KitID, skew, drifted); err != nil {
+		// Forget the value unless a newer one replaced it, so the next reading retries
+		t.mu.Lock()
+		if t.stored[data.KitID] == current {
+			delete(t.stored, data.KitID)
+		}
+		t.mu.Unlock()
+		return fmt.Errorf("failed to update clock drift of kit %d: %w", data.KitID, err)
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+package application
+
+import (
+	"api-order/src/gardendata/domain/entities"
+	"api-order/src/gardendata/domain/ports"
+	"api-order/src/shared/tracing"
+	"context"
+	"fmt"
+	"time"
+)
+
+// CompactionResult summarises one run of the retention job.
+type CompactionResult struct {
+	HourlyBuckets int64
+	DailyBuckets  int64
+	PrunedRaw     int64
+	PrunedHourly  int64
+	PrunedDaily   int64
+}
+
+type CompactGardenDataUseCase struct {
+	RollupRepository ports.IGardenDataRollup
+	Policy           entities.RetentionPolicy
+}
+
+func NewCompactGardenDataUseCase(rollups ports.IGardenDataRollup, policy entities.RetentionPolicy) *CompactGardenDataUseCase {
+	return &CompactGardenDataUseCase{RollupRepository: rollups, Policy: policy}
+}
+
+// Run rolls every reading stored before the current hour into its hourly and
+// daily buckets, and then prunes each resolution past its retention. Readings
+// are rolled up once each however late they were stored, e.g. by an import
+// into hours that already have a rollup, and raw readings are only pruned
+// once they are in the rollups, so no data is lost between runs.
+func (uc *CompactGardenDataUseCase) Run(ctx context.Context, now time.Time) (CompactionResult, error) {
+	ctx, span := tracing.Start(ctx, "CompactGardenDataUseCase.Run")
+	defer span.End()
+
+	var (
+		result CompactionResult
+		err    error
+	)
+	if !uc.Policy.Enabled() {
+		return result, nil
+	}
+
+	currentHour := time.Date(now.Year(), now.Month(), now.Day(), now.Hour(), 0, 0, 0, now.Location())
+
+	// 1. Roll up the pending readings one day at a time to keep each transaction small.
+	for {
+		earliest, pending, err := uc.RollupRepository.GetEarliestPendingTime(ctx)
+		if err != nil {
+			return result, fmt.Errorf("failed to read earliest pending reading: %w", err)
+		}
+		if !pending || !earliest.Before(currentHour) {
+			break
+		}
+		earliest = earliest.In(now.Location())
+		before := time.Date(earliest.Year(), earliest.Month(), earliest.Day()+1, 0, 0, 0, 0, now.Location())
+		if before.After(currentHour) {
+			before = currentHour
+		}
+		hourly, daily, err := uc.RollupRepository.RollupPending(ctx, before)
+		if err != nil {
+			return result, fmt.Errorf("failed to roll up readings: %w", err)
+		}
+		result.HourlyBuckets += hourly
+		result.DailyBuckets += daily
+		if hourly == 0 {
+			// Nothing was claimed, so another run would read the same reading again
+			break
+		}
+	}
+
+	// 2. Prune every resolution past its retention.
+	if result.PrunedRaw, err = uc.RollupRepository.PruneRawBefore(ctx, uc.Policy.RawCutoff(now)); err != nil {
+		return result, fmt.Errorf("failed to prune raw data: %w", err)
+	}
+	if cutoff := uc.Policy.HourlyCutoff(now); !cutoff.IsZero() {
+		if result.PrunedHourly, err = uc.RollupRepository.PruneRollupsBefore(ctx, entities.GranularityHour, cutoff); err != nil {
+			return result, fmt.Errorf("failed to prune hourly rollups: %w", err)
+		}
+	}
+	if cutoff := uc.Policy.DailyCutoff(now); !cutoff.IsZero() {
+		if result.PrunedDaily, err = uc.RollupRepository.PruneRollupsBefore(ctx, entities.GranularityDay, cutoff); err != nil {
+			return result, fmt.Errorf("failed to prune daily rollups: %w", err)
+		}
+	}
+
+	return result, nil
+}
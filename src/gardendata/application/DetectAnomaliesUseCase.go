@@ -0,0 +1,124 @@
+package application
+
+import (
+	alertEntities "api-order/src/alert/domain/entities"
+	alert "api-order/src/alert/domain/ports"
+	"api-order/src/gardendata/domain/entities"
+	"api-order/src/gardendata/domain/ports"
+	metricEntities "api-order/src/metric/domain/entities"
+	metric "api-order/src/metric/domain/ports"
+	"api-order/src/shared/tracing"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+type DetectAnomaliesUseCase struct {
+	SettingsRepository ports.IAnomalySettings
+	State              ports.IAnomalyState
+	MetricRepository   metric.IMetric
+	AlertRepository    alert.IAlert
+	Locales            *AlertLocales
+
+	// locks serialise the updates of the rolling state of each kit and metric,
+	// which is read and written per reading. mu guards the map.
+	mu    sync.Mutex
+	locks map[anomalyStateKey]*sync.Mutex
+}
+
+type anomalyStateKey struct {
+	kitID  int64
+	metric string
+}
+
+func NewDetectAnomaliesUseCase(settings ports.IAnomalySettings, state ports.IAnomalyState, metricRepo metric.IMetric, alertRepo alert.IAlert, locales *AlertLocales) *DetectAnomaliesUseCase {
+	return &DetectAnomaliesUseCase{
+		SettingsRepository: settings,
+		State:              state,
+		MetricRepository:   metricRepo,
+		AlertRepository:    alertRepo,
+		Locales:            locales,
+		locks:              make(map[anomalyStateKey]*sync.Mutex),
+	}
+}
+
+// Run feeds one reading of a kit into the rolling detector state of each metric
+// and raises an alert for every anomaly found: values far from the EWMA of the
+// recent readings, sudden jumps between consecutive readings, and sensors stuck
+// at the same value. time is the event time of the reading in unix seconds.
+func (uc *DetectAnomaliesUseCase) Run(ctx context.Context, kitID int64, readings map[string]float64, time int64) ([]entities.Anomaly, error) {
+	ctx, span := tracing.Start(ctx, "DetectAnomaliesUseCase.Run")
+	defer span.End()
+
+	settings, err := uc.SettingsRepository.GetByKitID(ctx, kitID)
+	if err != nil {
+		if !errors.Is(err, ports.ErrAnomalySettingsNotFound) {
+			return nil, fmt.Errorf("failed to load anomaly settings: %w", err)
+		}
+		settings = entities.DefaultAnomalySettings(kitID)
+	}
+	if !settings.Enabled {
+		return nil, nil
+	}
+
+	names := make([]string, 0, len(readings))
+	for name := range readings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	definitions := make([]metricEntities.Metric, len(names))
+	for i, name := range names {
+		if definitions[i], err = uc.MetricRepository.GetByName(ctx, name); err != nil {
+			return nil, fmt.Errorf("failed to resolve metric %s: %w", name, err)
+		}
+	}
+
+	var anomalies []entities.Anomaly
+	for i, name := range names {
+		definition := definitions[i]
+		unlock := uc.lock(kitID, name)
+		state, _ := uc.State.Get(kitID, name)
+		found := state.Observe(name, definition.Unit, readings[name], time, definition.MaxValue-definition.MinValue, settings)
+		uc.State.Save(kitID, name, state)
+		unlock()
+
+		for _, anomaly := range found {
+			anomaly.KitID = kitID
+			anomalies = append(anomalies, anomaly)
+		}
+	}
+
+	if len(anomalies) == 0 {
+		return nil, nil
+	}
+	locale := uc.Locales.Locale(ctx, kitID)
+	for _, anomaly := range anomalies {
+		_, err := uc.AlertRepository.Create(ctx, alertEntities.Alert{
+			KitID:     int(kitID),
+			AlertType: anomaly.Kind,
+			Message:   anomaly.Message.In(locale),
+		})
+		if err != nil {
+			return anomalies, fmt.Errorf("failed to raise %s alert for %s: %w", anomaly.Kind, anomaly.Metric, err)
+		}
+	}
+	return anomalies, nil
+}
+
+// lock locks the rolling state of a metric of a kit and returns its unlock.
+func (uc *DetectAnomaliesUseCase) lock(kitID int64, metric string) func() {
+	key := anomalyStateKey{kitID: kitID, metric: metric}
+	uc.mu.Lock()
+	l, ok := uc.locks[key]
+	if !ok {
+		l = &sync.Mutex{}
+		uc.locks[key] = l
+	}
+	uc.mu.Unlock()
+
+	l.Lock()
+	return l.Unlock
+}
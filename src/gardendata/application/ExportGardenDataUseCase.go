@@ -0,0 +1,97 @@
+package application
+
+import (
+	"api-order/src/gardendata/domain/entities"
+	"api-order/src/gardendata/domain/ports"
+	kit "api-order/src/kit/domain/ports"
+	metricEntities "api-order/src/metric/domain/entities"
+	metric "api-order/src/metric/domain/ports"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/tracing"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// exportBatchSize is the number of rows fetched per cursor step while exporting.
+const exportBatchSize = 500
+
+var ErrInvalidExportRange = domainerrors.Validation("invalid_export_range", "export range must have from before to")
+
+type ExportGardenDataUseCase struct {
+	GardenDataRepository ports.IGardenData
+	MetricRepository     metric.IMetric
+	KitRepository        kit.IKit
+}
+
+func NewExportGardenDataUseCase(repo ports.IGardenData, metricRepo metric.IMetric, kitRepo kit.IKit) *ExportGardenDataUseCase {
+	return &ExportGardenDataUseCase{GardenDataRepository: repo, MetricRepository: metricRepo, KitRepository: kitRepo}
+}
+
+// KitMetrics returns the registry metrics a kit owned by userID declares
+// besides the four built-in ones, in name order. They can be exported as
+// extra columns.
+func (uc *ExportGardenDataUseCase) KitMetrics(ctx context.Context, kitID, userID int64) ([]string, error) {
+	ctx, span := tracing.Start(ctx, "ExportGardenDataUseCase.KitMetrics")
+	defer span.End()
+
+	if kitID <= 0 {
+		return nil, ErrInvalidKitID
+	}
+	if _, err := kit.OwnedKit(ctx, uc.KitRepository, kitID, userID); err != nil {
+		return nil, err
+	}
+
+	sensors, err := uc.MetricRepository.GetKitSensors(ctx, kitID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read kit sensors: %w", err)
+	}
+	metrics := make([]string, 0, len(sensors))
+	for _, sensor := range sensors {
+		if !metricEntities.IsBuiltinMetric(sensor.MetricName) {
+			metrics = append(metrics, sensor.MetricName)
+		}
+	}
+	sort.Strings(metrics)
+	return metrics, nil
+}
+
+// Run walks every record of a kit owned by userID in [from, to) with a keyset cursor and hands
+// each batch to emit, with the samples of every record, so the whole range is never held in
+// memory at once.
+// It stops at the first error returned by the repository or by emit.
+func (uc *ExportGardenDataUseCase) Run(ctx context.Context, kitID, userID int64, from, to time.Time, emit func([]entities.GardenData) error) error {
+	ctx, span := tracing.Start(ctx, "ExportGardenDataUseCase.Run")
+	defer span.End()
+
+	if kitID <= 0 {
+		return ErrInvalidKitID
+	}
+	if !from.Before(to) {
+		return ErrInvalidExportRange
+	}
+	if _, err := kit.OwnedKit(ctx, uc.KitRepository, kitID, userID); err != nil {
+		return err
+	}
+
+	var cursor int64
+	for {
+		batch, err := uc.GardenDataRepository.GetRecordsPage(ctx, kitID, from, to, cursor, exportBatchSize)
+		if err != nil {
+			return fmt.Errorf("failed to read garden data page: %w", err)
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+
+		if err := emit(batch); err != nil {
+			return err
+		}
+
+		if len(batch) < exportBatchSize {
+			return nil
+		}
+		cursor = batch[len(batch)-1].DataID
+	}
+}
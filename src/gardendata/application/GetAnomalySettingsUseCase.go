@@ -0,0 +1,43 @@
+package application
+
+import (
+	"api-order/src/gardendata/domain/entities"
+	"api-order/src/gardendata/domain/ports"
+	kit "api-order/src/kit/domain/ports"
+	"api-order/src/shared/tracing"
+	"context"
+	"errors"
+	"fmt"
+)
+
+type GetAnomalySettingsUseCase struct {
+	SettingsRepository ports.IAnomalySettings
+	KitRepository      kit.IKit
+}
+
+func NewGetAnomalySettingsUseCase(repo ports.IAnomalySettings, kitRepo kit.IKit) *GetAnomalySettingsUseCase {
+	return &GetAnomalySettingsUseCase{SettingsRepository: repo, KitRepository: kitRepo}
+}
+
+// Run returns the anomaly detector settings of a kit owned by userID, or the
+// defaults if it never tuned them.
+func (uc *GetAnomalySettingsUseCase) Run(ctx context.Context, kitID, userID int64) (entities.AnomalySettings, error) {
+	ctx, span := tracing.Start(ctx, "GetAnomalySettingsUseCase.Run")
+	defer span.End()
+
+	if kitID <= 0 {
+		return entities.AnomalySettings{}, ErrInvalidKitID
+	}
+	if _, err := kit.OwnedKit(ctx, uc.KitRepository, kitID, userID); err != nil {
+		return entities.AnomalySettings{}, err
+	}
+
+	settings, err := uc.SettingsRepository.GetByKitID(ctx, kitID)
+	if err != nil {
+		if errors.Is(err, ports.ErrAnomalySettingsNotFound) {
+			return entities.DefaultAnomalySettings(kitID), nil
+		}
+		return entities.AnomalySettings{}, fmt.Errorf("failed to load anomaly settings: %w", err)
+	}
+	return settings, nil
+}
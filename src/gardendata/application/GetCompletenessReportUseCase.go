@@ -0,0 +1,167 @@
+package application
+
+import (
+	"api-order/src/gardendata/domain/entities"
+	"api-order/src/gardendata/domain/ports"
+	kit "api-order/src/kit/domain/ports"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/logging"
+	"api-order/src/shared/tracing"
+	"context"
+	"fmt"
+	"time"
+)
+
+// maxCompletenessRange bounds a report so the record times fit comfortably in memory.
+const maxCompletenessRange = 92 * 24 * time.Hour
+
+var ErrInvalidCompletenessRange = domainerrors.Validation("invalid_completeness_range", "from must be before to and the range must not exceed 92 days")
+
+type GetCompletenessReportUseCase struct {
+	GardenDataRepository ports.IGardenData
+	KitRepository        kit.IKit
+}
+
+func NewGetCompletenessReportUseCase(repo ports.IGardenData, kitRepo kit.IKit) *GetCompletenessReportUseCase {
+	return &GetCompletenessReportUseCase{GardenDataRepository: repo, KitRepository: kitRepo}
+}
+
+// Run compares the records a kit owned by userID sent in [from, to) with its
+// expected sampling interval. A gap is reported whenever no reading arrived for
+// more than one and a half intervals; days are split in loc. Server timestamps
+// are used, so a wrong device clock does not hide or invent gaps.
+func (uc *GetCompletenessReportUseCase) Run(ctx context.Context, kitID, userID int64, from, to time.Time, loc *time.Location) (entities.CompletenessReport, error) {
+	ctx, span := tracing.Start(ctx, "GetCompletenessReportUseCase.Run")
+	defer span.End()
+
+	if kitID <= 0 {
+		return entities.CompletenessReport{}, ErrInvalidKitID
+	}
+	if !from.Before(to) || to.Sub(from) > maxCompletenessRange {
+		return entities.CompletenessReport{}, ErrInvalidCompletenessRange
+	}
+
+	kitInfo, err := kit.OwnedKit(ctx, uc.KitRepository, kitID, userID)
+	if err != nil {
+		return entities.CompletenessReport{}, err
+	}
+	interval := kitInfo.SamplingInterval()
+
+	times, err := uc.GardenDataRepository.GetRecordTimes(ctx, kitID, from, to)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error calling repository GetRecordTimes for GardenData", "error", err)
+		return entities.CompletenessReport{}, fmt.Errorf("failed to retrieve record times: %w", err)
+	}
+
+	gaps := findGaps(times, from, to, interval)
+
+	report := entities.CompletenessReport{
+		KitID:                   kitID,
+		From:                    from,
+		To:                      to,
+		SamplingIntervalSeconds: int(interval / time.Second),
+		ReceivedSamples:         int64(len(times)),
+		ExpectedSamples:         int64(to.Sub(from) / interval),
+		UptimePercent:           uptimePercent(from, to, gaps),
+		Gaps:                    gaps,
+		Days:                    []entities.DailyUptime{},
+	}
+
+	next := 0
+	for day := startOfDay(from.In(loc)); day.Before(to); day = day.AddDate(0, 0, 1) {
+		start, end := day, day.AddDate(0, 0, 1)
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+
+		daily := entities.DailyUptime{
+			Date:            day.Format("2006-01-02"),
+			ExpectedSamples: int64(end.Sub(start) / interval),
+			UptimePercent:   uptimePercent(start, end, gaps),
+		}
+		for ; next < len(times) && times[next].Before(end); next++ {
+			daily.ReceivedSamples++
+		}
+		for _, gap := range gaps {
+			if overlap := overlapOf(gap, start, end); overlap > 0 {
+				daily.DowntimeSeconds += int64(overlap / time.Second)
+				if seconds := int64(overlap / time.Second); seconds > daily.LongestGapSeconds {
+					daily.LongestGapSeconds = seconds
+				}
+			}
+		}
+		report.Days = append(report.Days, daily)
+	}
+
+	return report, nil
+}
+
+// findGaps returns the periods in [from, to) longer than 1.5 intervals without readings.
+// times must be sorted in ascending order.
+func findGaps(times []time.Time, from, to time.Time, interval time.Duration) []entities.DataGap {
+	tolerance := interval * 3 / 2
+	gaps := []entities.DataGap{}
+	add := func(start, end time.Time) {
+		duration := end.Sub(start)
+		missing := int64(duration / interval)
+		if missing < 1 {
+			missing = 1
+		}
+		gaps = append(gaps, entities.DataGap{
+			Start:           start,
+			End:             end,
+			DurationSeconds: int64(duration / time.Second),
+			MissingSamples:  missing,
+		})
+	}
+
+	if len(times) == 0 {
+		add(from, to)
+		return gaps
+	}
+	if times[0].Sub(from) > tolerance {
+		add(from, times[0])
+	}
+	for i := 1; i < len(times); i++ {
+		if times[i].Sub(times[i-1]) > tolerance {
+			add(times[i-1].Add(interval), times[i])
+		}
+	}
+	if last := times[len(times)-1]; to.Sub(last) > tolerance {
+		add(last.Add(interval), to)
+	}
+	return gaps
+}
+
+// uptimePercent is the share of [start, end) not covered by gaps.
+func uptimePercent(start, end time.Time, gaps []entities.DataGap) float64 {
+	total := end.Sub(start)
+	if total <= 0 {
+		return 0
+	}
+	var down time.Duration
+	for _, gap := range gaps {
+		down += overlapOf(gap, start, end)
+	}
+	return float64(total-down) * 100 / float64(total)
+}
+
+func overlapOf(gap entities.DataGap, start, end time.Time) time.Duration {
+	if gap.Start.After(start) {
+		start = gap.Start
+	}
+	if gap.End.Before(end) {
+		end = gap.End
+	}
+	if !end.After(start) {
+		return 0
+	}
+	return end.Sub(start)
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,32 @@
+package application
+
+import (
+	"api-order/src/gardendata/domain/entities"
+	"api-order/src/gardendata/domain/ports"
+	"api-order/src/shared/tracing"
+	"context"
+)
+
+type GetImportJobUseCase struct {
+	JobStore ports.IImportJobs
+}
+
+func NewGetImportJobUseCase(jobs ports.IImportJobs) *GetImportJobUseCase {
+	return &GetImportJobUseCase{JobStore: jobs}
+}
+
+// Run returns the current state of an import job started by userID. Jobs of
+// other users are reported as not found, so their ids cannot be probed.
+func (uc *GetImportJobUseCase) Run(ctx context.Context, userID int64, id string) (entities.ImportJob, error) {
+	_, span := tracing.Start(ctx, "GetImportJobUseCase.Run")
+	defer span.End()
+
+	job, err := uc.JobStore.Get(id)
+	if err != nil {
+		return entities.ImportJob{}, err
+	}
+	if job.UserID != userID {
+		return entities.ImportJob{}, ports.ErrImportJobNotFound
+	}
+	return job, nil
+}
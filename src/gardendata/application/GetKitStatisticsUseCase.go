@@ -0,0 +1,141 @@
+package application
+
+import (
+	alert "api-order/src/alert/domain/ports"
+	"api-order/src/gardendata/domain/entities"
+	"api-order/src/gardendata/domain/ports"
+	kit "api-order/src/kit/domain/ports"
+	metric "api-order/src/metric/domain/ports"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/logging"
+	"api-order/src/shared/tracing"
+	"context"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidStatisticsPeriod = domainerrors.Validation("invalid_statistics_period", "period must be one of day, week, month")
+
+type GetKitStatisticsUseCase struct {
+	GardenDataRepository ports.IGardenData
+	MetricRepository     metric.IMetric
+	AlertRepository      alert.IAlert
+	KitRepository        kit.IKit
+	Cache                ports.IStatisticsCache
+}
+
+func NewGetKitStatisticsUseCase(repo ports.IGardenData, metricRepo metric.IMetric, alertRepo alert.IAlert, kitRepo kit.IKit, cache ports.IStatisticsCache) *GetKitStatisticsUseCase {
+	return &GetKitStatisticsUseCase{
+		GardenDataRepository: repo,
+		MetricRepository:     metricRepo,
+		AlertRepository:      alertRepo,
+		KitRepository:        kitRepo,
+		Cache:                cache,
+	}
+}
+
+// Run computes the statistics of a kit owned by userID for the current day, week or month in loc.
+// The percentage within thresholds is the share of samples inside the kit's
+// thresholds, which matches the share of time for kits reporting at a steady rate.
+// Statistics are computed from raw samples, so periods longer than the raw
+// retention only cover the readings still stored.
+func (uc *GetKitStatisticsUseCase) Run(ctx context.Context, kitID, userID int64, period string, loc *time.Location) (entities.KitStatistics, error) {
+	ctx, span := tracing.Start(ctx, "GetKitStatisticsUseCase.Run")
+	defer span.End()
+
+	if kitID <= 0 {
+		return entities.KitStatistics{}, ErrInvalidKitID
+	}
+	if !entities.IsValidStatisticsPeriod(period) {
+		return entities.KitStatistics{}, ErrInvalidStatisticsPeriod
+	}
+	if _, err := kit.OwnedKit(ctx, uc.KitRepository, kitID, userID); err != nil {
+		return entities.KitStatistics{}, err
+	}
+
+	key := period + "|" + loc.String()
+	if uc.Cache != nil {
+		if stats, ok := uc.Cache.Get(kitID, key); ok {
+			return stats, nil
+		}
+	}
+
+	now := time.Now().In(loc)
+	from := periodStart(period, now)
+
+	aggregates, err := uc.GardenDataRepository.GetMetricAggregates(ctx, kitID, from, now)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error calling repository GetMetricAggregates for GardenData", "error", err)
+		return entities.KitStatistics{}, fmt.Errorf("failed to aggregate metric samples: %w", err)
+	}
+
+	sensors, err := uc.MetricRepository.GetKitSensors(ctx, kitID)
+	if err != nil {
+		return entities.KitStatistics{}, fmt.Errorf("failed to load kit sensors: %w", err)
+	}
+	thresholds := make(map[string][2]*float64, len(sensors))
+	for _, sensor := range sensors {
+		if sensor.MinThreshold != nil || sensor.MaxThreshold != nil {
+			thresholds[sensor.MetricName] = [2]*float64{sensor.MinThreshold, sensor.MaxThreshold}
+		}
+	}
+
+	stats := entities.KitStatistics{
+		KitID:       kitID,
+		Period:      period,
+		From:        from,
+		To:          now,
+		Metrics:     make([]entities.MetricStatistics, 0, len(aggregates)),
+		GeneratedAt: now,
+	}
+
+	for _, aggregate := range aggregates {
+		metricStats := entities.MetricStatistics{
+			Metric:      aggregate.Metric,
+			SampleCount: aggregate.SampleCount,
+			MinValue:    aggregate.MinValue,
+			MaxValue:    aggregate.MaxValue,
+			AvgValue:    aggregate.AvgValue,
+			StdDev:      aggregate.StdDev,
+		}
+
+		if bounds, ok := thresholds[aggregate.Metric]; ok && aggregate.SampleCount > 0 {
+			compliance, err := uc.GardenDataRepository.GetThresholdCompliance(ctx, kitID, aggregate.Metric, bounds[0], bounds[1], from, now)
+			if err != nil {
+				return entities.KitStatistics{}, fmt.Errorf("failed to compute threshold compliance of %s: %w", aggregate.Metric, err)
+			}
+			percent := float64(compliance.WithinCount) * 100 / float64(aggregate.SampleCount)
+			hours := compliance.HoursOutOfRange
+			metricStats.MinThreshold = bounds[0]
+			metricStats.MaxThreshold = bounds[1]
+			metricStats.PercentWithinThresholds = &percent
+			metricStats.HoursOutOfRange = &hours
+		}
+
+		stats.Metrics = append(stats.Metrics, metricStats)
+	}
+
+	stats.AlertCount, err = uc.AlertRepository.CountByKitIDBetween(ctx, int(kitID), from, now)
+	if err != nil {
+		return entities.KitStatistics{}, fmt.Errorf("failed to count alerts: %w", err)
+	}
+
+	if uc.Cache != nil {
+		uc.Cache.Set(kitID, key, stats)
+	}
+	return stats, nil
+}
+
+// periodStart returns the start of the calendar day, week (Monday) or month containing now.
+func periodStart(period string, now time.Time) time.Time {
+	day := startOfDay(now)
+	switch period {
+	case entities.StatisticsPeriodWeek:
+		offset := (int(day.Weekday()) + 6) % 7
+		return day.AddDate(0, 0, -offset)
+	case entities.StatisticsPeriodMonth:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	default:
+		return day
+	}
+}
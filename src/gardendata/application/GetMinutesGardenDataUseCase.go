@@ -3,39 +3,87 @@ package application
 import (
 	"api-order/src/gardendata/domain/entities" // Corrected path
 	"api-order/src/gardendata/domain/ports"    // Corrected path
-	"errors"
+	"api-order/src/shared/logging"
+	"api-order/src/shared/pagination"
+	"api-order/src/shared/tracing"
+	"context"
 	"fmt"
+	"time"
 )
 
 type GetMinutesGardenDataUseCase struct {
 	GardenDataRepository ports.IGardenData
+	RollupRepository     ports.IGardenDataRollup
+	Policy               entities.RetentionPolicy
 }
 
-func NewGetMinutesGardenDataUseCase(repo ports.IGardenData) *GetMinutesGardenDataUseCase {
-	return &GetMinutesGardenDataUseCase{GardenDataRepository: repo}
+func NewGetMinutesGardenDataUseCase(repo ports.IGardenData, rollups ports.IGardenDataRollup, policy entities.RetentionPolicy) *GetMinutesGardenDataUseCase {
+	return &GetMinutesGardenDataUseCase{GardenDataRepository: repo, RollupRepository: rollups, Policy: policy}
 }
 
-// Run executes the logic to retrieve garden data records within a time window.
-func (uc *GetMinutesGardenDataUseCase) Run(kitID int64, minutes int) ([]entities.GardenData, error) {
+// GardenDataPageSpec describes the paging and sorting accepted by the recent records list.
+// The sort selects whether the window and order use the insertion time or the reconciled
+// event time; only descending order is supported.
+var GardenDataPageSpec = pagination.Spec{
+	DefaultLimit: 500,
+	MaxLimit:     5000,
+	Sorts:        []string{"-" + entities.OrderByTimestamp, "-" + entities.OrderByEventTime},
+}
+
+// Run executes the logic to retrieve one page of garden data records within a time window.
+// The part of the window older than the raw retention is served from rollups; those
+// aggregated records are appended to the last page and do not count against the limit.
+// Raw records past the retention that are not pruned yet are left out, as the rollups
+// already include them.
+func (uc *GetMinutesGardenDataUseCase) Run(ctx context.Context, kitID int64, minutes int, params pagination.CursorParams) ([]entities.GardenData, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "GetMinutesGardenDataUseCase.Run")
+	defer span.End()
+
 	// Basic validation
 	if minutes <= 0 {
-		return nil, errors.New("minutes parameter must be positive")
+		return nil, nil, ErrInvalidMinutes
 	}
 	if kitID <= 0 {
-		return nil, errors.New("kitID parameter must be positive")
+		return nil, nil, ErrInvalidKitID
 	}
 
-	records, err := uc.GardenDataRepository.GetRecordsByKitIDAndTime(kitID, minutes)
+	records, hasMore, err := uc.GardenDataRepository.GetRecordsByKitIDAndTime(ctx, kitID, minutes, params)
 	if err != nil {
 		// Log internal error details if necessary
-		fmt.Printf("Error calling repository GetRecordsByKitIDAndTime for GardenData: %v\n", err)
-		return nil, fmt.Errorf("failed to retrieve garden data: %w", err)
+		logging.FromContext(ctx).Error("Error calling repository GetRecordsByKitIDAndTime for GardenData", "error", err)
+		return nil, nil, fmt.Errorf("failed to retrieve garden data: %w", err)
+	}
+
+	var last pagination.Cursor
+	if len(records) > 0 {
+		record := records[len(records)-1]
+		last = pagination.Cursor{Time: record.Timestamp, ID: record.DataID}
+		if params.Sort.Field == entities.OrderByEventTime {
+			last.Time = record.EventTime
+		}
+	}
+	now := time.Now()
+	reader := rollupReader{rollups: uc.RollupRepository, policy: uc.Policy}
+	if cutoff, ok := reader.rawCutoff(now); ok {
+		records, hasMore = dropRolledUp(records, hasMore, params.Sort.Field != entities.OrderByEventTime, cutoff,
+			func(row entities.GardenData) time.Time { return row.Timestamp })
+	}
+	page := pagination.CursorPage(params, hasMore, last)
+	if hasMore {
+		return records, page, nil
+	}
+
+	aggregated, err := reader.records(ctx, kitID, now.Add(-time.Duration(minutes)*time.Minute), now)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error reading rollups for GardenData", "error", err)
+		return nil, nil, fmt.Errorf("failed to retrieve garden data rollups: %w", err)
 	}
+	records = append(records, aggregated...)
 
 	// Return empty slice if no records found (this is valid)
 	if records == nil {
-		return []entities.GardenData{}, nil
+		return []entities.GardenData{}, page, nil
 	}
 
-	return records, nil
+	return records, page, nil
 }
@@ -0,0 +1,84 @@
+package application
+
+import (
+	"api-order/src/gardendata/domain/entities"
+	"api-order/src/gardendata/domain/ports"
+	"api-order/src/shared/logging"
+	"api-order/src/shared/pagination"
+	"api-order/src/shared/tracing"
+	"context"
+	"fmt"
+	"time"
+)
+
+type GetMinutesMetricSamplesUseCase struct {
+	GardenDataRepository ports.IGardenData
+	RollupRepository     ports.IGardenDataRollup
+	Policy               entities.RetentionPolicy
+}
+
+func NewGetMinutesMetricSamplesUseCase(repo ports.IGardenData, rollups ports.IGardenDataRollup, policy entities.RetentionPolicy) *GetMinutesMetricSamplesUseCase {
+	return &GetMinutesMetricSamplesUseCase{GardenDataRepository: repo, RollupRepository: rollups, Policy: policy}
+}
+
+// MetricSamplesPageSpec describes the paging and sorting accepted by the recent samples list.
+// Sorting works as in GardenDataPageSpec.
+var MetricSamplesPageSpec = pagination.Spec{
+	DefaultLimit: 1000,
+	MaxLimit:     10000,
+	Sorts:        []string{"-" + entities.OrderByTimestamp, "-" + entities.OrderByEventTime},
+}
+
+// Run retrieves one page of the metric samples of a kit recorded in the last N minutes,
+// optionally restricted to the given metric names. Samples older than the raw
+// retention are hourly or daily averages taken from the rollups; as in
+// GetMinutesGardenDataUseCase they are appended to the last page only, and replace
+// the raw samples past the retention that are not pruned yet.
+func (uc *GetMinutesMetricSamplesUseCase) Run(ctx context.Context, kitID int64, minutes int, metrics []string, params pagination.CursorParams) ([]entities.MetricSample, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "GetMinutesMetricSamplesUseCase.Run")
+	defer span.End()
+
+	if minutes <= 0 {
+		return nil, nil, ErrInvalidMinutes
+	}
+	if kitID <= 0 {
+		return nil, nil, ErrInvalidKitID
+	}
+
+	samples, hasMore, err := uc.GardenDataRepository.GetSamplesByKitIDAndTime(ctx, kitID, minutes, metrics, params)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error calling repository GetSamplesByKitIDAndTime for GardenData", "error", err)
+		return nil, nil, fmt.Errorf("failed to retrieve metric samples: %w", err)
+	}
+
+	var last pagination.Cursor
+	if len(samples) > 0 {
+		sample := samples[len(samples)-1]
+		last = pagination.Cursor{Time: sample.Timestamp, ID: sample.SampleID}
+		if params.Sort.Field == entities.OrderByEventTime {
+			last.Time = sample.EventTime
+		}
+	}
+	now := time.Now()
+	reader := rollupReader{rollups: uc.RollupRepository, policy: uc.Policy}
+	if cutoff, ok := reader.rawCutoff(now); ok {
+		samples, hasMore = dropRolledUp(samples, hasMore, params.Sort.Field != entities.OrderByEventTime, cutoff,
+			func(row entities.MetricSample) time.Time { return row.Timestamp })
+	}
+	page := pagination.CursorPage(params, hasMore, last)
+	if hasMore {
+		return samples, page, nil
+	}
+
+	aggregated, err := reader.samples(ctx, kitID, now.Add(-time.Duration(minutes)*time.Minute), now, metrics)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error reading rollups for metric samples", "error", err)
+		return nil, nil, fmt.Errorf("failed to retrieve metric rollups: %w", err)
+	}
+	samples = append(samples, aggregated...)
+
+	if samples == nil {
+		return []entities.MetricSample{}, page, nil
+	}
+	return samples, page, nil
+}
@@ -0,0 +1,189 @@
+package application
+
+import (
+	"api-order/src/gardendata/domain/entities"
+	"api-order/src/gardendata/domain/ports"
+	kit "api-order/src/kit/domain/ports"
+	"api-order/src/shared/logging"
+	"api-order/src/shared/tracing"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// importBatchSize is the number of rows deduplicated and inserted per step.
+const importBatchSize = 200
+
+type ImportGardenDataUseCase struct {
+	GardenDataRepository ports.IGardenData
+	KitRepository        kit.IKit
+	JobStore             ports.IImportJobs
+	Runner               ports.IImportRunner
+	RegisterUseCase      *RegisterGardenDataUseCase
+}
+
+// NewImportGardenDataUseCase creates the use case. kitRepo and runner may be
+// nil when only RunSync is used, e.g. by the command line importer, whose
+// operator is not a user of the API.
+func NewImportGardenDataUseCase(repo ports.IGardenData, kitRepo kit.IKit, jobs ports.IImportJobs, runner ports.IImportRunner, register *RegisterGardenDataUseCase) *ImportGardenDataUseCase {
+	return &ImportGardenDataUseCase{
+		GardenDataRepository: repo,
+		KitRepository:        kitRepo,
+		JobStore:             jobs,
+		Runner:               runner,
+		RegisterUseCase:      register,
+	}
+}
+
+// Start registers a new import job into a kit owned by userID and processes
+// the rows in the background. The returned job can be polled through
+// GetImportJobUseCase. The job outlives the request that started it, so the
+// runner keeps ctx values but not its cancellation; a job cancelled by the
+// runner on shutdown ends as failed.
+func (uc *ImportGardenDataUseCase) Start(ctx context.Context, kitID, userID int64, rows []entities.ImportRow) (entities.ImportJob, error) {
+	if kitID <= 0 {
+		return entities.ImportJob{}, ErrInvalidKitID
+	}
+	if _, err := kit.OwnedKit(ctx, uc.KitRepository, kitID, userID); err != nil {
+		return entities.ImportJob{}, err
+	}
+
+	job, err := uc.newJob(kitID, userID, rows)
+	if err != nil {
+		return entities.ImportJob{}, err
+	}
+
+	err = uc.Runner.Go(ctx, func(ctx context.Context) {
+		uc.process(ctx, job, rows, nil)
+	})
+	if err != nil {
+		uc.finish(ctx, job, fmt.Sprintf("failed to start import: %v", err), nil)
+		return entities.ImportJob{}, err
+	}
+
+	return job, nil
+}
+
+// RunSync processes the rows in the calling goroutine and reports progress after
+// every batch. It is used by the command line importer.
+func (uc *ImportGardenDataUseCase) RunSync(ctx context.Context, kitID int64, rows []entities.ImportRow, progress func(entities.ImportJob)) (entities.ImportJob, error) {
+	job, err := uc.newJob(kitID, 0, rows)
+	if err != nil {
+		return entities.ImportJob{}, err
+	}
+	return uc.process(ctx, job, rows, progress), nil
+}
+
+func (uc *ImportGardenDataUseCase) newJob(kitID, userID int64, rows []entities.ImportRow) (entities.ImportJob, error) {
+	if kitID <= 0 {
+		return entities.ImportJob{}, ErrInvalidKitID
+	}
+
+	id, err := newImportJobID()
+	if err != nil {
+		return entities.ImportJob{}, fmt.Errorf("failed to create import job id: %w", err)
+	}
+
+	job := entities.ImportJob{
+		ID:        id,
+		KitID:     kitID,
+		UserID:    userID,
+		Status:    entities.ImportStatusPending,
+		TotalRows: len(rows),
+		Errors:    []entities.ImportLineError{},
+		CreatedAt: time.Now(),
+	}
+	if err := uc.JobStore.Save(job); err != nil {
+		return entities.ImportJob{}, fmt.Errorf("failed to save import job: %w", err)
+	}
+	return job, nil
+}
+
+func (uc *ImportGardenDataUseCase) process(ctx context.Context, job entities.ImportJob, rows []entities.ImportRow, progress func(entities.ImportJob)) entities.ImportJob {
+	ctx, span := tracing.Start(ctx, "ImportGardenDataUseCase.process")
+	defer span.End()
+
+	job.Status = entities.ImportStatusRunning
+	uc.save(ctx, job, progress)
+
+	// Times already seen in this file, so duplicated lines are not inserted twice.
+	seen := make(map[int64]bool, len(rows))
+
+	for start := 0; start < len(rows); start += importBatchSize {
+		end := start + importBatchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+		batch := rows[start:end]
+
+		times := make([]int64, 0, len(batch))
+		for _, row := range batch {
+			if row.ParseError == "" {
+				times = append(times, row.Time)
+			}
+		}
+		existing, err := uc.GardenDataRepository.GetExistingTimes(ctx, job.KitID, times)
+		if err != nil {
+			logging.FromContext(ctx).Error("Error checking existing rows for import", "job_id", job.ID, "error", err)
+			return uc.finish(ctx, job, fmt.Sprintf("failed to check existing rows: %v", err), progress)
+		}
+
+		for _, row := range batch {
+			if ctx.Err() != nil {
+				return uc.finish(ctx, job, fmt.Sprintf("import interrupted: %v", context.Cause(ctx)), progress)
+			}
+			job.ProcessedRows++
+
+			if row.ParseError != "" {
+				job.AddLineError(row.Line, row.ParseError)
+				continue
+			}
+			if existing[row.Time] || seen[row.Time] {
+				job.DuplicateRows++
+				continue
+			}
+
+			if _, err := uc.RegisterUseCase.Run(ctx, job.KitID, row.Readings, row.Time); err != nil {
+				job.AddLineError(row.Line, err.Error())
+				continue
+			}
+			seen[row.Time] = true
+			job.InsertedRows++
+		}
+
+		uc.save(ctx, job, progress)
+	}
+
+	return uc.finish(ctx, job, "", progress)
+}
+
+func (uc *ImportGardenDataUseCase) finish(ctx context.Context, job entities.ImportJob, failure string, progress func(entities.ImportJob)) entities.ImportJob {
+	now := time.Now()
+	job.FinishedAt = &now
+	job.Status = entities.ImportStatusCompleted
+	if failure != "" {
+		job.Status = entities.ImportStatusFailed
+		job.Failure = failure
+	}
+	uc.save(ctx, job, progress)
+	return job
+}
+
+func (uc *ImportGardenDataUseCase) save(ctx context.Context, job entities.ImportJob, progress func(entities.ImportJob)) {
+	if err := uc.JobStore.Save(job); err != nil {
+		logging.FromContext(ctx).Error("Error saving import job", "job_id", job.ID, "error", err)
+	}
+	if progress != nil {
+		progress(job)
+	}
+}
+
+func newImportJobID() (string, error) {
+	buf := make([]byte, 12)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -3,43 +3,160 @@ package application
 import (
 	"api-order/src/gardendata/domain/entities" // Corrected path
 	"api-order/src/gardendata/domain/ports"    // Corrected path
+	metricEntities "api-order/src/metric/domain/entities"
+	metric "api-order/src/metric/domain/ports"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/logging"
+	"api-order/src/shared/tracing"
+	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"time"
 )
 
+var ErrNoReadings = domainerrors.Validation("no_readings", "at least one metric reading is required")
+var ErrUnknownMetric = domainerrors.Validation("unknown_metric", "metric is not registered")
+var ErrMetricOutOfRange = domainerrors.Validation("metric_out_of_range", "metric value is outside the valid range")
+var ErrUndeclaredSensor = domainerrors.Validation("undeclared_sensor", "kit did not declare a sensor for this metric")
+
+// ErrInvalidKitID and ErrInvalidMinutes reject arguments that are not positive.
+var ErrInvalidKitID = domainerrors.Validation("invalid_kit_id", "kitID parameter must be positive")
+var ErrInvalidMinutes = domainerrors.Validation("invalid_minutes", "minutes parameter must be positive")
+
 type RegisterGardenDataUseCase struct {
 	GardenDataRepository ports.IGardenData
+	MetricRepository     metric.IMetric
+	StatisticsCache      ports.IStatisticsCache
+	AnomalyDetector      *DetectAnomaliesUseCase
+	ClockDrift           *ClockDriftTracker
 }
 
-func NewRegisterGardenDataUseCase(repo ports.IGardenData) *RegisterGardenDataUseCase {
-	return &RegisterGardenDataUseCase{GardenDataRepository: repo}
+// NewRegisterGardenDataUseCase creates the use case. cache may be nil when no
+// statistics are served from the same process. detector and clock may be nil
+// when readings are not live (e.g. historical imports): device times are then
+// trusted as event times, and the readings are filed at that time instead of
+// the time they were inserted, so ranges, rollups and retention place them
+// where they belong.
+func NewRegisterGardenDataUseCase(repo ports.IGardenData, metricRepo metric.IMetric, cache ports.IStatisticsCache, detector *DetectAnomaliesUseCase, clock *ClockDriftTracker) *RegisterGardenDataUseCase {
+	return &RegisterGardenDataUseCase{
+		GardenDataRepository: repo,
+		MetricRepository:     metricRepo,
+		StatisticsCache:      cache,
+		AnomalyDetector:      detector,
+		ClockDrift:           clock,
+	}
 }
 
 // Run executes the logic to register a new garden data record.
-func (uc *RegisterGardenDataUseCase) Run(kitID int64, temperature, groundHumidity, environmentHumidity, phLevel float64, time int64) (entities.GardenData, error) {
+// readings maps registered metric names to their values; the four built-in
+// metrics are also copied into the fixed garden_data columns.
+func (uc *RegisterGardenDataUseCase) Run(ctx context.Context, kitID int64, readings map[string]float64, deviceTime int64) (entities.GardenData, error) {
+	ctx, span := tracing.Start(ctx, "RegisterGardenDataUseCase.Run")
+	defer span.End()
+
 	// Basic validation (can be expanded)
 	if kitID <= 0 {
-		return entities.GardenData{}, errors.New("invalid kit_id provided")
+		return entities.GardenData{}, ErrInvalidKitID
+	}
+	if len(readings) == 0 {
+		return entities.GardenData{}, ErrNoReadings
+	}
+
+	if err := uc.validateReadings(ctx, kitID, readings); err != nil {
+		return entities.GardenData{}, err
 	}
-	// Add other validations if needed (e.g., range checks for sensor values)
 
 	data := entities.GardenData{
 		KitID:               kitID,
-		Temperature:         temperature,
-		GroundHumidity:      groundHumidity,
-		EnvironmentHumidity: environmentHumidity,
-		PhLevel:             phLevel,
-		Time:                time,
-		// Timestamp will be set by the database default or repository
+		Temperature:         readings[metricEntities.MetricTemperature],
+		GroundHumidity:      readings[metricEntities.MetricGroundHumidity],
+		EnvironmentHumidity: readings[metricEntities.MetricEnvironmentHumidity],
+		PhLevel:             readings[metricEntities.MetricPhLevel],
+		Time:                deviceTime,
+		// Timestamp of live readings is set by the database default or repository
+	}
+
+	if uc.ClockDrift != nil {
+		// A failure only leaves the kit's drift flag stale; the reading is still valid.
+		if err := uc.ClockDrift.Reconcile(ctx, &data, time.Now()); err != nil {
+			logging.FromContext(ctx).Error("Error tracking clock drift for kit", "kit_id", kitID, "error", err)
+		}
+	} else {
+		data.EventTime, data.ClockSkewSeconds, _ = entities.ReconcileClock(deviceTime, time.Now(), 0)
+		data.Timestamp = data.EventTime
 	}
 
-	createdRecord, err := uc.GardenDataRepository.Create(data)
+	names := make([]string, 0, len(readings))
+	for name := range readings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		data.Samples = append(data.Samples, entities.MetricSample{
+			KitID:  kitID,
+			Metric: name,
+			Value:  readings[name],
+			Time:   deviceTime,
+		})
+	}
+
+	createdRecord, err := uc.GardenDataRepository.Create(ctx, data)
 	if err != nil {
 		// Log internal error details if necessary
-		fmt.Printf("Error calling repository Create for GardenData: %v\n", err)
+		logging.FromContext(ctx).Error("Error calling repository Create for GardenData", "error", err)
 		// Return a generic error or the specific repository error if safe
 		return entities.GardenData{}, fmt.Errorf("failed to register garden data: %w", err)
 	}
 
+	// The reading is already stored, so detector failures are only logged.
+	if uc.AnomalyDetector != nil {
+		if _, err := uc.AnomalyDetector.Run(ctx, kitID, readings, data.EventTime.Unix()); err != nil {
+			logging.FromContext(ctx).Error("Error detecting anomalies for kit", "kit_id", kitID, "error", err)
+		}
+	}
+
+	// After the detector, as the statistics also count the alerts it raised
+	if uc.StatisticsCache != nil {
+		uc.StatisticsCache.Invalidate(kitID)
+	}
+
 	return createdRecord, nil
 }
+
+// validateReadings checks every metric against the registry and the kit's declared sensors.
+// Built-in metrics are always accepted so kits that never declared sensors keep working.
+func (uc *RegisterGardenDataUseCase) validateReadings(ctx context.Context, kitID int64, readings map[string]float64) error {
+	var declared map[string]bool
+
+	for name, value := range readings {
+		definition, err := uc.MetricRepository.GetByName(ctx, name)
+		if err != nil {
+			if errors.Is(err, metric.ErrMetricNotFound) {
+				return fmt.Errorf("%w: %s", ErrUnknownMetric, name)
+			}
+			return fmt.Errorf("failed to resolve metric %s: %w", name, err)
+		}
+		if !definition.InRange(value) {
+			return fmt.Errorf("%w: %s=%v (allowed %v..%v %s)", ErrMetricOutOfRange, name, value, definition.MinValue, definition.MaxValue, definition.Unit)
+		}
+
+		if metricEntities.IsBuiltinMetric(name) {
+			continue
+		}
+		if declared == nil {
+			sensors, err := uc.MetricRepository.GetKitSensors(ctx, kitID)
+			if err != nil {
+				return fmt.Errorf("failed to load kit sensors: %w", err)
+			}
+			declared = make(map[string]bool, len(sensors))
+			for _, sensor := range sensors {
+				declared[sensor.MetricName] = true
+			}
+		}
+		if len(declared) > 0 && !declared[name] {
+			return fmt.Errorf("%w: %s", ErrUndeclaredSensor, name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package application
+
+import (
+	"api-order/src/gardendata/domain/entities"
+	"api-order/src/gardendata/domain/ports"
+	metricEntities "api-order/src/metric/domain/entities"
+	"context"
+	"time"
+)
+
+// rollupReader serves the part of a time window that is older than the raw
+// retention from hourly rollups, and the part older than the hourly retention
+// from daily rollups.
+type rollupReader struct {
+	rollups ports.IGardenDataRollup
+	policy  entities.RetentionPolicy
+}
+
+// rawCutoff returns the time before which the window is served from rollups.
+// The boolean is false when rollups are not used.
+func (r rollupReader) rawCutoff(now time.Time) (time.Time, bool) {
+	if r.rollups == nil || !r.policy.Enabled() {
+		return time.Time{}, false
+	}
+	return r.policy.RawCutoff(now), true
+}
+
+// dropRolledUp removes the raw rows stored before cutoff. The retention job
+// rolls them up an hour before it prunes them, so until then they would be
+// counted twice. Rows sorted by storage time end at the first one dropped, so
+// no page follows it.
+func dropRolledUp[T any](rows []T, hasMore, byTimestamp bool, cutoff time.Time, stored func(T) time.Time) ([]T, bool) {
+	kept := make([]T, 0, len(rows))
+	for _, row := range rows {
+		if !stored(row).Before(cutoff) {
+			kept = append(kept, row)
+		} else if byTimestamp {
+			return kept, false
+		}
+	}
+	return kept, hasMore
+}
+
+// samples returns averaged samples of a kit for [from, rawCutoff), newest first.
+func (r rollupReader) samples(ctx context.Context, kitID int64, from, now time.Time, metrics []string) ([]entities.MetricSample, error) {
+	rawCutoff, ok := r.rawCutoff(now)
+	if !ok || !from.Before(rawCutoff) {
+		return nil, nil
+	}
+
+	var samples []entities.MetricSample
+	hourlyCutoff := r.policy.HourlyCutoff(now)
+
+	hourlyFrom := from
+	if hourlyCutoff.After(hourlyFrom) {
+		hourlyFrom = hourlyCutoff
+	}
+	if hourlyFrom.Before(rawCutoff) {
+		hourly, err := r.rollups.GetRollups(ctx, kitID, entities.GranularityHour, hourlyFrom, rawCutoff, metrics)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, rollupsToSamples(hourly)...)
+	}
+
+	if !hourlyCutoff.IsZero() && from.Before(hourlyCutoff) {
+		daily, err := r.rollups.GetRollups(ctx, kitID, entities.GranularityDay, from, hourlyCutoff, metrics)
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, rollupsToSamples(daily)...)
+	}
+
+	return samples, nil
+}
+
+// records returns one averaged GardenData per rollup bucket for [from, rawCutoff), newest first.
+func (r rollupReader) records(ctx context.Context, kitID int64, from, now time.Time) ([]entities.GardenData, error) {
+	samples, err := r.samples(ctx, kitID, from, now, nil)
+	if err != nil || len(samples) == 0 {
+		return nil, err
+	}
+
+	var records []entities.GardenData
+	for _, sample := range samples {
+		last := len(records) - 1
+		if last < 0 || !records[last].Timestamp.Equal(sample.Timestamp) || records[last].Aggregation != sample.Aggregation {
+			records = append(records, entities.GardenData{
+				KitID:       kitID,
+				Time:        sample.Time,
+				Timestamp:   sample.Timestamp,
+				EventTime:   sample.EventTime,
+				Aggregation: sample.Aggregation,
+			})
+			last++
+		}
+
+		record := &records[last]
+		switch sample.Metric {
+		case metricEntities.MetricTemperature:
+			record.Temperature = sample.Value
+		case metricEntities.MetricGroundHumidity:
+			record.GroundHumidity = sample.Value
+		case metricEntities.MetricEnvironmentHumidity:
+			record.EnvironmentHumidity = sample.Value
+		case metricEntities.MetricPhLevel:
+			record.PhLevel = sample.Value
+		}
+		record.Samples = append(record.Samples, sample)
+	}
+	return records, nil
+}
+
+func rollupsToSamples(rollups []entities.MetricRollup) []entities.MetricSample {
+	samples := make([]entities.MetricSample, len(rollups))
+	for i, rollup := range rollups {
+		samples[i] = entities.MetricSample{
+			KitID:       rollup.KitID,
+			Metric:      rollup.Metric,
+			Value:       rollup.AvgValue,
+			Time:        rollup.BucketStart.Unix(),
+			Timestamp:   rollup.BucketStart,
+			EventTime:   rollup.BucketStart,
+			Aggregation: rollup.Granularity,
+		}
+	}
+	return samples
+}
@@ -0,0 +1,43 @@
+package application
+
+import (
+	"api-order/src/gardendata/domain/entities"
+	"api-order/src/gardendata/domain/ports"
+	kit "api-order/src/kit/domain/ports"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/tracing"
+	"context"
+	"fmt"
+)
+
+var ErrInvalidAnomalySettings = domainerrors.Validation("invalid_anomaly_settings", "invalid anomaly settings")
+
+type UpdateAnomalySettingsUseCase struct {
+	SettingsRepository ports.IAnomalySettings
+	KitRepository      kit.IKit
+}
+
+func NewUpdateAnomalySettingsUseCase(repo ports.IAnomalySettings, kitRepo kit.IKit) *UpdateAnomalySettingsUseCase {
+	return &UpdateAnomalySettingsUseCase{SettingsRepository: repo, KitRepository: kitRepo}
+}
+
+// Run validates and stores the anomaly detector settings of a kit owned by userID.
+func (uc *UpdateAnomalySettingsUseCase) Run(ctx context.Context, userID int64, settings entities.AnomalySettings) (entities.AnomalySettings, error) {
+	ctx, span := tracing.Start(ctx, "UpdateAnomalySettingsUseCase.Run")
+	defer span.End()
+
+	if settings.KitID <= 0 {
+		return entities.AnomalySettings{}, ErrInvalidKitID
+	}
+	if _, err := kit.OwnedKit(ctx, uc.KitRepository, settings.KitID, userID); err != nil {
+		return entities.AnomalySettings{}, err
+	}
+	if err := settings.Validate(); err != nil {
+		return entities.AnomalySettings{}, fmt.Errorf("%w: %v", ErrInvalidAnomalySettings, err)
+	}
+
+	if err := uc.SettingsRepository.Save(ctx, settings); err != nil {
+		return entities.AnomalySettings{}, fmt.Errorf("failed to save anomaly settings: %w", err)
+	}
+	return settings, nil
+}
@@ -0,0 +1,150 @@
+package entities
+
+import (
+	alertEntities "api-order/src/alert/domain/entities"
+	"api-order/src/shared/i18n"
+	"errors"
+	"math"
+)
+
+// Detector tuning that is not exposed per kit.
+const (
+	// anomalyEWMAAlpha weights the newest reading in the rolling mean and variance.
+	anomalyEWMAAlpha = 0.1
+	// anomalyWarmupSamples readings are needed before z-scores are trusted.
+	anomalyWarmupSamples = 10
+	// flatlineTolerance is the fraction of the metric range still considered "no change".
+	flatlineTolerance = 1e-4
+)
+
+// AnomalySettings tunes the anomaly detector for one kit.
+//
+//	ZScoreThreshold  deviations from the rolling average, in standard deviations, that raise an anomaly
+//	SpikeFraction    jump between consecutive readings, as a fraction of the metric's valid range, that raises a spike
+//	FlatlineMinutes  minutes a sensor may report the same value before a flatline is raised
+//
+// A value of 0 disables that check.
+type AnomalySettings struct {
+	KitID           int64   `json:"kit_id"`
+	Enabled         bool    `json:"enabled"`
+	ZScoreThreshold float64 `json:"z_score_threshold"`
+	SpikeFraction   float64 `json:"spike_fraction"`
+	FlatlineMinutes int     `json:"flatline_minutes"`
+}
+
+// DefaultAnomalySettings are used for kits that never tuned the detector.
+func DefaultAnomalySettings(kitID int64) AnomalySettings {
+	return AnomalySettings{
+		KitID:           kitID,
+		Enabled:         true,
+		ZScoreThreshold: 3.5,
+		SpikeFraction:   0.08,
+		FlatlineMinutes: 180,
+	}
+}
+
+// Validate checks that the settings are usable by the detector.
+func (s AnomalySettings) Validate() error {
+	if s.ZScoreThreshold != 0 && s.ZScoreThreshold < 1 {
+		return errors.New("z_score_threshold must be 0 (disabled) or at least 1")
+	}
+	if s.SpikeFraction < 0 || s.SpikeFraction > 1 {
+		return errors.New("spike_fraction must be between 0 and 1")
+	}
+	if s.FlatlineMinutes < 0 {
+		return errors.New("flatline_minutes must not be negative")
+	}
+	return nil
+}
+
+// Anomaly is an unusual reading found by the detector. Kind is one of the
+// detector alert types of the alert module. Message is rendered in the
+// language of the kit's owner when the alert is raised.
+type Anomaly struct {
+	KitID   int64
+	Metric  string
+	Kind    string
+	Value   float64
+	Message i18n.Message
+}
+
+// MetricStreamState is the rolling state the detector keeps for one metric of a kit.
+type MetricStreamState struct {
+	Count        int
+	Mean         float64
+	Variance     float64
+	LastValue    float64
+	LastTime     int64
+	FlatSince    int64
+	FlatReported bool
+}
+
+// Observe feeds a reading taken at device time t (unix seconds) into the state
+// and returns the anomalies it shows. span is the width of the metric's valid
+// range, used to scale spike and flatline tolerances. Readings older than the
+// last one seen are ignored.
+func (s *MetricStreamState) Observe(metric, unit string, value float64, t int64, span float64, settings AnomalySettings) []Anomaly {
+	if s.Count == 0 {
+		*s = MetricStreamState{Count: 1, Mean: value, LastValue: value, LastTime: t, FlatSince: t}
+		return nil
+	}
+	if t < s.LastTime {
+		return nil
+	}
+
+	var anomalies []Anomaly
+	delta := value - s.LastValue
+
+	spike := settings.SpikeFraction > 0 && span > 0 && math.Abs(delta) >= settings.SpikeFraction*span
+	if spike {
+		anomalies = append(anomalies, Anomaly{
+			Metric: metric,
+			Kind:   alertEntities.AlertTypeSpike,
+			Value:  value,
+			Message: i18n.NewMessage("alert.spike",
+				metric, s.LastValue, unit, value, unit, delta, unit),
+		})
+	}
+
+	// A spike already explains a large deviation, so it is not reported twice.
+	if !spike && settings.ZScoreThreshold > 0 && s.Count >= anomalyWarmupSamples {
+		if std := math.Sqrt(s.Variance); std > 0 {
+			z := (value - s.Mean) / std
+			if math.Abs(z) >= settings.ZScoreThreshold {
+				anomalies = append(anomalies, Anomaly{
+					Metric: metric,
+					Kind:   alertEntities.AlertTypeAnomaly,
+					Value:  value,
+					Message: i18n.NewMessage("alert.anomaly",
+						metric, value, unit, z, s.Mean, unit),
+				})
+			}
+		}
+	}
+
+	if math.Abs(delta) <= flatlineTolerance*span {
+		if settings.FlatlineMinutes > 0 && !s.FlatReported && t-s.FlatSince >= int64(settings.FlatlineMinutes)*60 {
+			s.FlatReported = true
+			anomalies = append(anomalies, Anomaly{
+				Metric: metric,
+				Kind:   alertEntities.AlertTypeFlatline,
+				Value:  value,
+				Message: i18n.NewMessage("alert.flatline",
+					metric, value, unit, (t-s.FlatSince)/60),
+			})
+		}
+	} else {
+		s.FlatSince = t
+		s.FlatReported = false
+	}
+
+	diff := value - s.Mean
+	increment := anomalyEWMAAlpha * diff
+	s.Mean += increment
+	s.Variance = (1 - anomalyEWMAAlpha) * (s.Variance + diff*increment)
+	s.LastValue = value
+	s.LastTime = t
+	s.Count++
+
+	return anomalies
+}
@@ -0,0 +1,27 @@
+package entities
+
+import "time"
+
+// Record orderings supported by the read queries.
+const (
+	OrderByTimestamp = "timestamp"  // Server insertion time
+	OrderByEventTime = "event_time" // Reconciled time of the reading
+)
+
+// ReconcileClock compares the device time of a reading (unix seconds) with the
+// instant the server received it. The device time is kept as event time while
+// the skew stays within tolerance; otherwise the device clock is considered
+// wrong (e.g. a dead RTC reporting 1970) and the receive time is used instead.
+// A tolerance of 0 trusts the device time, as imports of historical data do.
+func ReconcileClock(deviceTime int64, received time.Time, tolerance time.Duration) (eventTime time.Time, skewSeconds int64, drifted bool) {
+	device := time.Unix(deviceTime, 0)
+	if tolerance <= 0 {
+		return device, 0, false
+	}
+
+	skew := device.Sub(received)
+	if skew < 0 && -skew > tolerance || skew > tolerance {
+		return received, int64(skew / time.Second), true
+	}
+	return device, int64(skew / time.Second), false
+}
@@ -0,0 +1,36 @@
+package entities
+
+import "time"
+
+// DataGap is a stretch of time in which a kit sent no readings although it was
+// expected to. Start is when the next reading was due and End when a reading
+// finally arrived (or the end of the analysed range).
+type DataGap struct {
+	Start           time.Time `json:"start"`
+	End             time.Time `json:"end"`
+	DurationSeconds int64     `json:"duration_seconds"`
+	MissingSamples  int64     `json:"missing_samples"`
+}
+
+// DailyUptime is the share of a day in which the kit reported at its expected rate.
+type DailyUptime struct {
+	Date              string  `json:"date"` // YYYY-MM-DD in the report timezone
+	ReceivedSamples   int64   `json:"received_samples"`
+	ExpectedSamples   int64   `json:"expected_samples"`
+	UptimePercent     float64 `json:"uptime_percent"`
+	DowntimeSeconds   int64   `json:"downtime_seconds"`
+	LongestGapSeconds int64   `json:"longest_gap_seconds"`
+}
+
+// CompletenessReport summarises how complete the data of a kit is over a range.
+type CompletenessReport struct {
+	KitID                   int64         `json:"kit_id"`
+	From                    time.Time     `json:"from"`
+	To                      time.Time     `json:"to"`
+	SamplingIntervalSeconds int           `json:"sampling_interval_seconds"`
+	ReceivedSamples         int64         `json:"received_samples"`
+	ExpectedSamples         int64         `json:"expected_samples"`
+	UptimePercent           float64       `json:"uptime_percent"`
+	Gaps                    []DataGap     `json:"gaps"`
+	Days                    []DailyUptime `json:"days"`
+}
@@ -1,6 +1,10 @@
 package entities
 
-import "time"
+import (
+	metric "api-order/src/metric/domain/entities"
+	"api-order/src/shared/preferences"
+	"time"
+)
 
 // GardenData represents a single record of sensor data from a garden kit.
 type GardenData struct {
@@ -10,26 +14,47 @@ type GardenData struct {
 	GroundHumidity      float64   `json:"ground_humidity"`
 	EnvironmentHumidity float64   `json:"environment_humidity"` // Corrected spelling
 	PhLevel             float64   `json:"ph_level"`
-	Time                int64     `json:"time"`      // Unix timestamp from device
-	Timestamp           time.Time `json:"timestamp"` // DB insertion timestamp
+	Time                int64     `json:"time"`               // Unix timestamp from device
+	Timestamp           time.Time `json:"timestamp"`          // DB insertion timestamp
+	EventTime           time.Time `json:"event_time"`         // When the reading was taken, corrected for device clock skew
+	ClockSkewSeconds    int64     `json:"clock_skew_seconds"` // Device clock minus server clock at ingestion
+
+	// Samples holds every metric reported with this record, including the four fixed ones.
+	Samples []MetricSample `json:"-"`
+	// Aggregation is "hour" or "day" when the record was rebuilt from rollups
+	// because the raw readings are past their retention.
+	Aggregation string `json:"-"`
 }
 
 // GardenDataResponse defines the structure returned by the API, potentially omitting fields if needed.
 // In this case, it's the same as GardenData.
 type GardenDataResponse struct {
-	DataID              int64     `json:"data_id"`
-	KitID               int64     `json:"kit_id"`
-	Temperature         float64   `json:"temperature"`
-	GroundHumidity      float64   `json:"ground_humidity"`
-	EnvironmentHumidity float64   `json:"environment_humidity"`
-	PhLevel             float64   `json:"ph_level"`
-	Time                int64     `json:"time"`
-	Timestamp           time.Time `json:"timestamp"`
+	DataID              int64              `json:"data_id"`
+	KitID               int64              `json:"kit_id"`
+	Temperature         float64            `json:"temperature"`
+	GroundHumidity      float64            `json:"ground_humidity"`
+	EnvironmentHumidity float64            `json:"environment_humidity"`
+	PhLevel             float64            `json:"ph_level"`
+	Time                int64              `json:"time"`
+	Timestamp           time.Time          `json:"timestamp"`
+	EventTime           time.Time          `json:"event_time"`
+	ClockSkewSeconds    int64              `json:"clock_skew_seconds"`
+	Metrics             map[string]float64 `json:"metrics,omitempty"`
+	Aggregation         string             `json:"aggregation,omitempty"`
+	TemperatureUnit     string             `json:"temperature_unit"`
 }
 
 // ToResponse converts GardenData to GardenDataResponse.
 // Useful if you ever want to hide certain fields in the future.
 func (gd *GardenData) ToResponse() GardenDataResponse {
+	var metrics map[string]float64
+	if len(gd.Samples) > 0 {
+		metrics = make(map[string]float64, len(gd.Samples))
+		for _, sample := range gd.Samples {
+			metrics[sample.Metric] = sample.Value
+		}
+	}
+
 	return GardenDataResponse{
 		DataID:              gd.DataID,
 		KitID:               gd.KitID,
@@ -39,5 +64,50 @@ func (gd *GardenData) ToResponse() GardenDataResponse {
 		PhLevel:             gd.PhLevel,
 		Time:                gd.Time,
 		Timestamp:           gd.Timestamp,
+		EventTime:           gd.EventTime,
+		ClockSkewSeconds:    gd.ClockSkewSeconds,
+		Metrics:             metrics,
+		Aggregation:         gd.Aggregation,
+		TemperatureUnit:     preferences.Celsius,
+	}
+}
+
+// RenderTemperatures converts the temperatures of a Celsius record, its
+// temperature sample included, to the unit of p.
+func (gd GardenData) RenderTemperatures(p preferences.Preferences) GardenData {
+	if !p.Fahrenheit() {
+		return gd
+	}
+	gd.Temperature = p.Temperature(gd.Temperature)
+	samples := make([]MetricSample, len(gd.Samples))
+	for i, sample := range gd.Samples {
+		if sample.Metric == metric.MetricTemperature {
+			sample.Value = p.Temperature(sample.Value)
+		}
+		samples[i] = sample
+	}
+	gd.Samples = samples
+	return gd
+}
+
+// Render converts the temperatures of a Celsius response to the unit of p and
+// its times to the timezone of p.
+func (r GardenDataResponse) Render(p preferences.Preferences) GardenDataResponse {
+	loc := p.Location()
+	r.Timestamp = r.Timestamp.In(loc)
+	r.EventTime = r.EventTime.In(loc)
+	if !p.Fahrenheit() {
+		return r
+	}
+	r.Temperature = p.Temperature(r.Temperature)
+	if value, ok := r.Metrics[metric.MetricTemperature]; ok {
+		metrics := make(map[string]float64, len(r.Metrics))
+		for name, v := range r.Metrics {
+			metrics[name] = v
+		}
+		metrics[metric.MetricTemperature] = p.Temperature(value)
+		r.Metrics = metrics
 	}
+	r.TemperatureUnit = preferences.Fahrenheit
+	return r
 }
@@ -0,0 +1,56 @@
+package entities
+
+import "time"
+
+// Import job states
+const (
+	ImportStatusPending   = "pending"
+	ImportStatusRunning   = "running"
+	ImportStatusCompleted = "completed"
+	ImportStatusFailed    = "failed"
+)
+
+// MaxImportLineErrors bounds how many per-line errors a job keeps in memory.
+const MaxImportLineErrors = 1000
+
+// ImportRow is one parsed line of a historical readings file.
+type ImportRow struct {
+	Line     int
+	Time     int64
+	Readings map[string]float64
+	// ParseError is set when the line could not be mapped into readings.
+	ParseError string
+}
+
+// ImportLineError describes why a given line of the file was rejected.
+type ImportLineError struct {
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// ImportJob tracks the progress of an asynchronous bulk import. UserID is the
+// user who started it, the only one who may poll it; it is zero for jobs run
+// by the command line importer.
+type ImportJob struct {
+	ID            string            `json:"id"`
+	KitID         int64             `json:"kit_id"`
+	UserID        int64             `json:"-"`
+	Status        string            `json:"status"`
+	TotalRows     int               `json:"total_rows"`
+	ProcessedRows int               `json:"processed_rows"`
+	InsertedRows  int               `json:"inserted_rows"`
+	DuplicateRows int               `json:"duplicate_rows"`
+	FailedRows    int               `json:"failed_rows"`
+	Errors        []ImportLineError `json:"errors"`
+	Failure       string            `json:"failure,omitempty"`
+	CreatedAt     time.Time         `json:"created_at"`
+	FinishedAt    *time.Time        `json:"finished_at,omitempty"`
+}
+
+// AddLineError records a rejected line, keeping at most MaxImportLineErrors details.
+func (j *ImportJob) AddLineError(line int, message string) {
+	j.FailedRows++
+	if len(j.Errors) < MaxImportLineErrors {
+		j.Errors = append(j.Errors, ImportLineError{Line: line, Message: message})
+	}
+}
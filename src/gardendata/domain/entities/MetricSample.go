@@ -0,0 +1,34 @@
+package entities
+
+import (
+	metric "api-order/src/metric/domain/entities"
+	"api-order/src/shared/preferences"
+	"time"
+)
+
+// MetricSample is a single typed reading of a registered metric.
+// Every ingested GardenData produces one sample per reported metric.
+type MetricSample struct {
+	SampleID  int64     `json:"sample_id"`
+	DataID    int64     `json:"data_id"`
+	KitID     int64     `json:"kit_id"`
+	Metric    string    `json:"metric"`
+	Value     float64   `json:"value"`
+	Time      int64     `json:"time"`       // Unix timestamp from device
+	Timestamp time.Time `json:"timestamp"`  // DB insertion timestamp
+	EventTime time.Time `json:"event_time"` // When the reading was taken, corrected for device clock skew
+	// Aggregation is "hour" or "day" when Value is the average of a rollup bucket.
+	Aggregation string `json:"aggregation,omitempty"`
+}
+
+// Render converts a temperature sample to the unit of p and the times of the
+// sample to the timezone of p.
+func (s MetricSample) Render(p preferences.Preferences) MetricSample {
+	loc := p.Location()
+	s.Timestamp = s.Timestamp.In(loc)
+	s.EventTime = s.EventTime.In(loc)
+	if s.Metric == metric.MetricTemperature {
+		s.Value = p.Temperature(s.Value)
+	}
+	return s
+}
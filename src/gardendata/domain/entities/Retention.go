@@ -0,0 +1,66 @@
+package entities
+
+import "time"
+
+// Rollup granularities
+const (
+	GranularityHour = "hour"
+	GranularityDay  = "day"
+)
+
+// MetricRollup aggregates the samples of one metric of a kit over an hour or a day.
+type MetricRollup struct {
+	KitID       int64     `json:"kit_id"`
+	Metric      string    `json:"metric"`
+	Granularity string    `json:"granularity"`
+	BucketStart time.Time `json:"bucket_start"`
+	SampleCount int64     `json:"sample_count"`
+	MinValue    float64   `json:"min_value"`
+	MaxValue    float64   `json:"max_value"`
+	AvgValue    float64   `json:"avg_value"`
+}
+
+// RetentionPolicy tells how long each resolution of data is kept, in days.
+// RawDays of 0 disables retention (raw data is kept forever and no rollups are
+// produced); HourlyDays or DailyDays of 0 keep that resolution forever.
+type RetentionPolicy struct {
+	RawDays    int
+	HourlyDays int
+	DailyDays  int
+}
+
+// Enabled reports whether raw data is pruned at all.
+func (p RetentionPolicy) Enabled() bool {
+	return p.RawDays > 0
+}
+
+// RawCutoff is the instant before which raw readings are no longer available.
+func (p RetentionPolicy) RawCutoff(now time.Time) time.Time {
+	return truncateHour(now.AddDate(0, 0, -p.RawDays))
+}
+
+// HourlyCutoff is the instant before which only daily rollups are available.
+// The zero time means hourly rollups are kept forever.
+func (p RetentionPolicy) HourlyCutoff(now time.Time) time.Time {
+	if p.HourlyDays <= 0 {
+		return time.Time{}
+	}
+	return truncateDay(now.AddDate(0, 0, -p.HourlyDays))
+}
+
+// DailyCutoff is the instant before which no data is available at all.
+// The zero time means daily rollups are kept forever.
+func (p RetentionPolicy) DailyCutoff(now time.Time) time.Time {
+	if p.DailyDays <= 0 {
+		return time.Time{}
+	}
+	return truncateDay(now.AddDate(0, 0, -p.DailyDays))
+}
+
+func truncateHour(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), 0, 0, 0, t.Location())
+}
+
+func truncateDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
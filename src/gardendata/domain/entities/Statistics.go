@@ -0,0 +1,107 @@
+package entities
+
+import (
+	metric "api-order/src/metric/domain/entities"
+	"api-order/src/shared/preferences"
+	"time"
+)
+
+// Statistics periods. Each one starts at the beginning of the current calendar
+// day, week (Monday) or month in the requested timezone and ends now.
+const (
+	StatisticsPeriodDay   = "day"
+	StatisticsPeriodWeek  = "week"
+	StatisticsPeriodMonth = "month"
+)
+
+// IsValidStatisticsPeriod checks if a given string is a supported statistics period
+func IsValidStatisticsPeriod(period string) bool {
+	switch period {
+	case StatisticsPeriodDay, StatisticsPeriodWeek, StatisticsPeriodMonth:
+		return true
+	default:
+		return false
+	}
+}
+
+// MetricAggregate holds the aggregate values of one metric's samples over a range.
+type MetricAggregate struct {
+	Metric      string
+	SampleCount int64
+	MinValue    float64
+	MaxValue    float64
+	AvgValue    float64
+	StdDev      float64
+}
+
+// ThresholdCompliance counts the samples of a metric inside the kit's thresholds
+// and the distinct hours with at least one sample outside them.
+type ThresholdCompliance struct {
+	WithinCount     int64
+	HoursOutOfRange int64
+}
+
+// MetricStatistics summarises one metric of a kit over a statistics period.
+// Threshold fields are only present when the kit configured thresholds for the metric.
+type MetricStatistics struct {
+	Metric                  string   `json:"metric"`
+	SampleCount             int64    `json:"sample_count"`
+	MinValue                float64  `json:"min_value"`
+	MaxValue                float64  `json:"max_value"`
+	AvgValue                float64  `json:"avg_value"`
+	StdDev                  float64  `json:"stddev"`
+	MinThreshold            *float64 `json:"min_threshold,omitempty"`
+	MaxThreshold            *float64 `json:"max_threshold,omitempty"`
+	PercentWithinThresholds *float64 `json:"percent_within_thresholds,omitempty"`
+	HoursOutOfRange         *int64   `json:"hours_out_of_range,omitempty"`
+}
+
+// KitStatistics is the summary of a kit's readings and alerts over a period.
+type KitStatistics struct {
+	KitID           int64              `json:"kit_id"`
+	Period          string             `json:"period"`
+	From            time.Time          `json:"from"`
+	To              time.Time          `json:"to"`
+	Metrics         []MetricStatistics `json:"metrics"`
+	AlertCount      int                `json:"alert_count"`
+	GeneratedAt     time.Time          `json:"generated_at"`
+	TemperatureUnit string             `json:"temperature_unit"`
+}
+
+// Render returns a copy of s with the temperature statistics in the unit of p
+// and its times in the timezone of p. s is left untouched, as it may be cached.
+func (s KitStatistics) Render(p preferences.Preferences) KitStatistics {
+	loc := p.Location()
+	s.From = s.From.In(loc)
+	s.To = s.To.In(loc)
+	s.GeneratedAt = s.GeneratedAt.In(loc)
+	s.TemperatureUnit = preferences.Celsius
+	if !p.Fahrenheit() {
+		return s
+	}
+
+	s.TemperatureUnit = preferences.Fahrenheit
+	metrics := make([]MetricStatistics, len(s.Metrics))
+	for i, m := range s.Metrics {
+		if m.Metric == metric.MetricTemperature {
+			m.MinValue = p.Temperature(m.MinValue)
+			m.MaxValue = p.Temperature(m.MaxValue)
+			m.AvgValue = p.Temperature(m.AvgValue)
+			// A deviation is a difference of temperatures, so it is only scaled
+			m.StdDev = p.TemperatureDelta(m.StdDev)
+			m.MinThreshold = convertTemperature(p, m.MinThreshold)
+			m.MaxThreshold = convertTemperature(p, m.MaxThreshold)
+		}
+		metrics[i] = m
+	}
+	s.Metrics = metrics
+	return s
+}
+
+func convertTemperature(p preferences.Preferences, celsius *float64) *float64 {
+	if celsius == nil {
+		return nil
+	}
+	value := p.Temperature(*celsius)
+	return &value
+}
@@ -0,0 +1,22 @@
+package ports
+
+import (
+	"api-order/src/gardendata/domain/entities"
+	"api-order/src/shared/domainerrors"
+	"context"
+)
+
+// ErrAnomalySettingsNotFound is returned when a kit never tuned the anomaly detector.
+var ErrAnomalySettingsNotFound = domainerrors.NotFound("anomaly_settings_not_found", "anomaly settings not found")
+
+// IAnomalySettings stores the per-kit tuning of the anomaly detector.
+type IAnomalySettings interface {
+	GetByKitID(ctx context.Context, kitID int64) (entities.AnomalySettings, error)
+	Save(ctx context.Context, settings entities.AnomalySettings) error
+}
+
+// IAnomalyState keeps the rolling detector state of each kit metric between readings.
+type IAnomalyState interface {
+	Get(kitID int64, metric string) (entities.MetricStreamState, bool)
+	Save(kitID int64, metric string, state entities.MetricStreamState)
+}
@@ -1,12 +1,47 @@
 package ports
 
-import "api-order/src/gardendata/domain/entities" // Corrected path
+import (
+	"api-order/src/gardendata/domain/entities" // Corrected path
+	"api-order/src/shared/pagination"
+	"context"
+	"time"
+)
 
 // IGardenData defines the interface for the garden data repository.
 type IGardenData interface {
-	// Create saves a new garden data record to the repository.
-	Create(data entities.GardenData) (entities.GardenData, error)
+	// Create saves a new garden data record, together with its metric samples, to the repository.
+	Create(ctx context.Context, data entities.GardenData) (entities.GardenData, error)
 
-	// GetRecordsByKitIDAndTime retrieves records for a specific kit within a given time window (in minutes).
-	GetRecordsByKitIDAndTime(kitID int64, minutesAgo int) ([]entities.GardenData, error)
+	// GetRecordsByKitIDAndTime retrieves one page of records for a specific kit within a given time
+	// window (in minutes). The sort field is entities.OrderByTimestamp or entities.OrderByEventTime;
+	// the window and the descending order both use that column. The bool reports whether more rows follow.
+	GetRecordsByKitIDAndTime(ctx context.Context, kitID int64, minutesAgo int, page pagination.CursorParams) ([]entities.GardenData, bool, error)
+
+	// GetSamplesByKitIDAndTime retrieves one page of metric samples for a kit within a time window (in minutes).
+	// An empty metrics slice returns samples of every metric. Paging works as in GetRecordsByKitIDAndTime.
+	GetSamplesByKitIDAndTime(ctx context.Context, kitID int64, minutesAgo int, metrics []string, page pagination.CursorParams) ([]entities.MetricSample, bool, error)
+
+	// GetRecordsPage returns up to limit records of a kit stored in [from, to) whose data_id is
+	// greater than afterID, ordered by data_id, each with its samples. It is used as a keyset
+	// cursor to stream large ranges.
+	GetRecordsPage(ctx context.Context, kitID int64, from, to time.Time, afterID int64, limit int) ([]entities.GardenData, error)
+
+	// GetExistingTimes returns which of the given device times already have a record for the kit.
+	GetExistingTimes(ctx context.Context, kitID int64, times []int64) (map[int64]bool, error)
+
+	// GetMetricAggregates returns count, min, max, average and population standard deviation
+	// of every metric of a kit with samples stored in [from, to).
+	GetMetricAggregates(ctx context.Context, kitID int64, from, to time.Time) ([]entities.MetricAggregate, error)
+
+	// GetThresholdCompliance counts the samples of a metric stored in [from, to) that respect
+	// the given bounds, and the distinct hours with samples outside them. Nil bounds are open.
+	GetThresholdCompliance(ctx context.Context, kitID int64, metric string, min, max *float64, from, to time.Time) (entities.ThresholdCompliance, error)
+
+	// GetRecordTimes returns the insertion timestamps of a kit's records stored in [from, to), in ascending order.
+	GetRecordTimes(ctx context.Context, kitID int64, from, to time.Time) ([]time.Time, error)
+
+	// GetLastRecordTimes returns the timestamp of the latest record of up to limit kits with an ID
+	// above afterKitID, taken in ID order. Kits without data map to the zero time, so a page with
+	// fewer than limit kits is the last one.
+	GetLastRecordTimes(ctx context.Context, afterKitID int64, limit int) (map[int64]time.Time, error)
 }
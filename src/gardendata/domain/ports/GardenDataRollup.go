@@ -0,0 +1,30 @@
+package ports
+
+import (
+	"api-order/src/gardendata/domain/entities"
+	"context"
+	"time"
+)
+
+// IGardenDataRollup defines the storage of aggregated metric data and the
+// pruning operations used by the retention policy.
+type IGardenDataRollup interface {
+	// RollupPending merges every raw reading stored before before that is not
+	// rolled up yet into its hourly and daily buckets, and marks it as rolled up.
+	// Legacy readings stored without samples count as samples of the four fixed metrics.
+	// Existing buckets are added to rather than recomputed, so readings stored
+	// late, e.g. by an import, still reach buckets that were already rolled up.
+	RollupPending(ctx context.Context, before time.Time) (hourly, daily int64, err error)
+	// GetEarliestPendingTime returns the oldest timestamp of the readings not rolled up yet, if any.
+	GetEarliestPendingTime(ctx context.Context) (time.Time, bool, error)
+
+	// PruneRawBefore deletes the rolled up raw readings and samples stored before cutoff.
+	// Readings still waiting for RollupPending are kept.
+	PruneRawBefore(ctx context.Context, cutoff time.Time) (int64, error)
+	// PruneRollupsBefore deletes buckets of a granularity starting before cutoff.
+	PruneRollupsBefore(ctx context.Context, granularity string, cutoff time.Time) (int64, error)
+
+	// GetRollups returns the buckets of a kit starting in [from, to), ordered by bucket start descending.
+	// An empty metrics slice returns every metric.
+	GetRollups(ctx context.Context, kitID int64, granularity string, from, to time.Time, metrics []string) ([]entities.MetricRollup, error)
+}
@@ -0,0 +1,24 @@
+package ports
+
+import (
+	"api-order/src/gardendata/domain/entities"
+	"api-order/src/shared/domainerrors"
+	"context"
+)
+
+// ErrImportJobNotFound is returned when an import job id is unknown.
+var ErrImportJobNotFound = domainerrors.NotFound("import_job_not_found", "import job not found")
+
+// IImportJobs stores the state of bulk import jobs so clients can poll their progress.
+type IImportJobs interface {
+	Save(job entities.ImportJob) error
+	Get(id string) (entities.ImportJob, error)
+}
+
+// IImportRunner runs import jobs in the background. It cancels the context of
+// the jobs still running when the server shuts down.
+type IImportRunner interface {
+	// Go runs job in a new goroutine, with the values but not the cancellation
+	// of ctx. It fails once the runner is stopped.
+	Go(ctx context.Context, job func(ctx context.Context)) error
+}
@@ -0,0 +1,16 @@
+package ports
+
+import "api-order/src/gardendata/domain/entities"
+
+// IStatisticsCache keeps computed kit statistics until new readings, alerts or
+// thresholds change them.
+type IStatisticsCache interface {
+	// Get returns the cached statistics stored under key for the kit, if any.
+	Get(kitID int64, key string) (entities.KitStatistics, bool)
+
+	// Set stores statistics for the kit under key.
+	Set(kitID int64, key string, stats entities.KitStatistics)
+
+	// Invalidate drops every cached entry of the kit.
+	Invalidate(kitID int64)
+}
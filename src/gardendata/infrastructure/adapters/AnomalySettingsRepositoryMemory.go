@@ -0,0 +1,40 @@
+package adapters
+
+import (
+	"api-order/src/gardendata/domain/entities"
+	"api-order/src/gardendata/domain/ports"
+	"context"
+	"sync"
+)
+
+// AnomalySettingsRepositoryMemory keeps the per-kit detector tuning in process
+// memory. It backs the HTTP test suite.
+type AnomalySettingsRepositoryMemory struct {
+	mu       sync.RWMutex
+	settings map[int64]entities.AnomalySettings
+}
+
+func NewAnomalySettingsRepositoryMemory() *AnomalySettingsRepositoryMemory {
+	return &AnomalySettingsRepositoryMemory{settings: make(map[int64]entities.AnomalySettings)}
+}
+
+// GetByKitID implements ports.IAnomalySettings
+func (r *AnomalySettingsRepositoryMemory) GetByKitID(ctx context.Context, kitID int64) (entities.AnomalySettings, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	settings, ok := r.settings[kitID]
+	if !ok {
+		return entities.AnomalySettings{}, ports.ErrAnomalySettingsNotFound
+	}
+	return settings, nil
+}
+
+// Save implements ports.IAnomalySettings
+func (r *AnomalySettingsRepositoryMemory) Save(ctx context.Context, settings entities.AnomalySettings) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.settings[settings.KitID] = settings
+	return nil
+}
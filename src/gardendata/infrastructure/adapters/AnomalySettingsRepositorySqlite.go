@@ -0,0 +1,66 @@
+package adapters
+
+import (
+	database "api-order/src/Database"
+	"api-order/src/gardendata/domain/entities"
+	"api-order/src/gardendata/domain/ports"
+	kit "api-order/src/kit/domain/ports"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/logging"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+type AnomalySettingsRepositorySqlite struct {
+	DB       *sql.DB
+	Timeouts database.Timeouts
+}
+
+// NewAnomalySettingsRepositorySqlite wraps the shared connection pool.
+func NewAnomalySettingsRepositorySqlite(db *sql.DB, timeouts database.Timeouts) *AnomalySettingsRepositorySqlite {
+	return &AnomalySettingsRepositorySqlite{DB: db, Timeouts: timeouts}
+}
+
+// GetByKitID implements ports.IAnomalySettings
+func (r *AnomalySettingsRepositorySqlite) GetByKitID(ctx context.Context, kitID int64) (entities.AnomalySettings, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	query := "SELECT kit_id, enabled, z_score_threshold, spike_fraction, flatline_minutes FROM anomaly_settings WHERE kit_id = ?"
+	var settings entities.AnomalySettings
+	err := r.DB.QueryRowContext(ctx, query, kitID).Scan(&settings.KitID, &settings.Enabled, &settings.ZScoreThreshold, &settings.SpikeFraction, &settings.FlatlineMinutes)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entities.AnomalySettings{}, ports.ErrAnomalySettingsNotFound
+		}
+		logging.FromContext(ctx).Error("Error querying anomaly settings for kit", "kit_id", kitID, "error", err)
+		return entities.AnomalySettings{}, fmt.Errorf("database query error: %w", err)
+	}
+	return settings, nil
+}
+
+// Save implements ports.IAnomalySettings
+func (r *AnomalySettingsRepositorySqlite) Save(ctx context.Context, settings entities.AnomalySettings) error {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpWrite)
+	defer cancel()
+
+	query := `
+        INSERT INTO anomaly_settings (kit_id, enabled, z_score_threshold, spike_fraction, flatline_minutes)
+        VALUES (?, ?, ?, ?, ?)
+        ON CONFLICT (kit_id) DO UPDATE SET
+            enabled = excluded.enabled,
+            z_score_threshold = excluded.z_score_threshold,
+            spike_fraction = excluded.spike_fraction,
+            flatline_minutes = excluded.flatline_minutes
+    `
+	if _, err := r.DB.ExecContext(ctx, query, settings.KitID, settings.Enabled, settings.ZScoreThreshold, settings.SpikeFraction, settings.FlatlineMinutes); err != nil {
+		logging.FromContext(ctx).Error("Error saving anomaly settings for kit", "kit_id", settings.KitID, "error", err)
+		if err = database.TranslateError(err); errors.Is(err, domainerrors.ErrMissingReference) {
+			return kit.ErrKitNotFound.Wrap(err)
+		}
+		return fmt.Errorf("database execution error: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package adapters
+
+import (
+	"api-order/src/gardendata/domain/entities"
+	"sync"
+)
+
+type anomalyStateKey struct {
+	kitID  int64
+	metric string
+}
+
+// AnomalyStateMemory keeps the detector state in process memory. After a
+// restart the detector warms up again from the next readings.
+type AnomalyStateMemory struct {
+	mu     sync.RWMutex
+	states map[anomalyStateKey]entities.MetricStreamState
+}
+
+func NewAnomalyStateMemory() *AnomalyStateMemory {
+	return &AnomalyStateMemory{states: make(map[anomalyStateKey]entities.MetricStreamState)}
+}
+
+// Get implements ports.IAnomalyState
+func (s *AnomalyStateMemory) Get(kitID int64, metric string) (entities.MetricStreamState, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	state, ok := s.states[anomalyStateKey{kitID, metric}]
+	return state, ok
+}
+
+// Save implements ports.IAnomalyState
+func (s *AnomalyStateMemory) Save(kitID int64, metric string, state entities.MetricStreamState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.states[anomalyStateKey{kitID, metric}] = state
+}
@@ -0,0 +1,34 @@
+package adapters
+
+import (
+	"api-order/src/gardendata/domain/entities"
+	"api-order/src/gardendata/domain/ports"
+	"api-order/src/shared/monitoring"
+	"context"
+	"strconv"
+)
+
+// GardenDataRepositoryInstrumented counts the records stored through another
+// repository, whether they come from live ingestion or imports. ByKit labels
+// the count with each kit ID instead of monitoring.AllKits.
+type GardenDataRepositoryInstrumented struct {
+	ports.IGardenData
+	ByKit bool
+}
+
+func NewGardenDataRepositoryInstrumented(inner ports.IGardenData, byKit bool) *GardenDataRepositoryInstrumented {
+	return &GardenDataRepositoryInstrumented{IGardenData: inner, ByKit: byKit}
+}
+
+// Create implements ports.IGardenData
+func (r *GardenDataRepositoryInstrumented) Create(ctx context.Context, data entities.GardenData) (entities.GardenData, error) {
+	created, err := r.IGardenData.Create(ctx, data)
+	if err == nil {
+		kitLabel := monitoring.AllKits
+		if r.ByKit {
+			kitLabel = strconv.FormatInt(created.KitID, 10)
+		}
+		monitoring.ReadingsIngested.WithLabelValues(kitLabel).Inc()
+	}
+	return created, err
+}
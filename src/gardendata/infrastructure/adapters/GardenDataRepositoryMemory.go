@@ -0,0 +1,289 @@
+package adapters
+
+import (
+	"api-order/src/gardendata/domain/entities"
+	"api-order/src/shared/pagination"
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+// GardenDataRepositoryMemory keeps readings and their metric samples in
+// process memory. It backs the HTTP test suite and follows the MySQL
+// repository: records fall back to their insertion time when they have no
+// event time, and time series are paged by keyset.
+type GardenDataRepositoryMemory struct {
+	mu           sync.RWMutex
+	nextDataID   int64
+	nextSampleID int64
+	records      []entities.GardenData
+	samples      []entities.MetricSample
+}
+
+func NewGardenDataRepositoryMemory() *GardenDataRepositoryMemory {
+	return &GardenDataRepositoryMemory{}
+}
+
+// sortTime returns the time a row is windowed and sorted on, like orderColumn.
+func sortTime(orderBy string, timestamp, eventTime time.Time) time.Time {
+	if orderBy == entities.OrderByEventTime && !eventTime.IsZero() {
+		return eventTime
+	}
+	return timestamp
+}
+
+// Create implements ports.IGardenData
+func (r *GardenDataRepositoryMemory) Create(ctx context.Context, data entities.GardenData) (entities.GardenData, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextDataID++
+	data.DataID = r.nextDataID
+	if data.Timestamp.IsZero() {
+		data.Timestamp = time.Now()
+	}
+
+	samples := make([]entities.MetricSample, len(data.Samples))
+	for i, sample := range data.Samples {
+		r.nextSampleID++
+		sample.SampleID = r.nextSampleID
+		sample.DataID = data.DataID
+		sample.KitID = data.KitID
+		sample.Time = data.Time
+		sample.Timestamp = data.Timestamp
+		sample.EventTime = data.EventTime
+		samples[i] = sample
+		r.samples = append(r.samples, sample)
+	}
+	data.Samples = samples
+
+	stored := data
+	stored.Samples = nil
+	r.records = append(r.records, stored)
+	return data, nil
+}
+
+// GetRecordsByKitIDAndTime implements ports.IGardenData
+func (r *GardenDataRepositoryMemory) GetRecordsByKitIDAndTime(ctx context.Context, kitID int64, minutesAgo int, page pagination.CursorParams) ([]entities.GardenData, bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	since := time.Now().Add(-time.Duration(minutesAgo) * time.Minute)
+	records := []entities.GardenData{}
+	for _, record := range r.records {
+		t := sortTime(page.Sort.Field, record.Timestamp, record.EventTime)
+		if record.KitID != kitID || t.Before(since) || !pagination.KeysetAfter(page, t, record.DataID) {
+			continue
+		}
+		if record.EventTime.IsZero() {
+			record.EventTime = record.Timestamp
+		}
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		a, b := records[i], records[j]
+		return pagination.KeysetLess(page, sortTime(page.Sort.Field, a.Timestamp, a.EventTime), a.DataID, sortTime(page.Sort.Field, b.Timestamp, b.EventTime), b.DataID)
+	})
+
+	if len(records) > page.Limit+1 {
+		records = records[:page.Limit+1]
+	}
+	records, hasMore := pagination.TrimPage(records, page.Limit)
+	return records, hasMore, nil
+}
+
+// GetSamplesByKitIDAndTime implements ports.IGardenData
+func (r *GardenDataRepositoryMemory) GetSamplesByKitIDAndTime(ctx context.Context, kitID int64, minutesAgo int, metrics []string, page pagination.CursorParams) ([]entities.MetricSample, bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wanted := make(map[string]bool, len(metrics))
+	for _, metric := range metrics {
+		wanted[metric] = true
+	}
+
+	since := time.Now().Add(-time.Duration(minutesAgo) * time.Minute)
+	samples := []entities.MetricSample{}
+	for _, sample := range r.samples {
+		t := sortTime(page.Sort.Field, sample.Timestamp, sample.EventTime)
+		if sample.KitID != kitID || t.Before(since) || (len(wanted) > 0 && !wanted[sample.Metric]) {
+			continue
+		}
+		if !pagination.KeysetAfter(page, t, sample.SampleID) {
+			continue
+		}
+		if sample.EventTime.IsZero() {
+			sample.EventTime = sample.Timestamp
+		}
+		samples = append(samples, sample)
+	}
+	sort.Slice(samples, func(i, j int) bool {
+		a, b := samples[i], samples[j]
+		return pagination.KeysetLess(page, sortTime(page.Sort.Field, a.Timestamp, a.EventTime), a.SampleID, sortTime(page.Sort.Field, b.Timestamp, b.EventTime), b.SampleID)
+	})
+
+	if len(samples) > page.Limit+1 {
+		samples = samples[:page.Limit+1]
+	}
+	samples, hasMore := pagination.TrimPage(samples, page.Limit)
+	return samples, hasMore, nil
+}
+
+// GetRecordsPage implements ports.IGardenData
+func (r *GardenDataRepositoryMemory) GetRecordsPage(ctx context.Context, kitID int64, from, to time.Time, afterID int64, limit int) ([]entities.GardenData, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Records are appended in data_id order
+	records := make([]entities.GardenData, 0, limit)
+	for _, record := range r.records {
+		if len(records) == limit {
+			break
+		}
+		if record.KitID != kitID || record.DataID <= afterID || !inRange(record.Timestamp, from, to) {
+			continue
+		}
+		if record.EventTime.IsZero() {
+			record.EventTime = record.Timestamp
+		}
+		records = append(records, record)
+	}
+
+	byID := make(map[int64]int, len(records))
+	for i := range records {
+		byID[records[i].DataID] = i
+	}
+	for _, sample := range r.samples {
+		if i, ok := byID[sample.DataID]; ok {
+			records[i].Samples = append(records[i].Samples, sample)
+		}
+	}
+	return records, nil
+}
+
+// GetExistingTimes implements ports.IGardenData
+func (r *GardenDataRepositoryMemory) GetExistingTimes(ctx context.Context, kitID int64, times []int64) (map[int64]bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wanted := make(map[int64]bool, len(times))
+	for _, t := range times {
+		wanted[t] = true
+	}
+	existing := make(map[int64]bool)
+	for _, record := range r.records {
+		if record.KitID == kitID && wanted[record.Time] {
+			existing[record.Time] = true
+		}
+	}
+	return existing, nil
+}
+
+// GetMetricAggregates implements ports.IGardenData
+func (r *GardenDataRepositoryMemory) GetMetricAggregates(ctx context.Context, kitID int64, from, to time.Time) ([]entities.MetricAggregate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	values := make(map[string][]float64)
+	for _, sample := range r.samples {
+		if sample.KitID == kitID && inRange(sample.Timestamp, from, to) {
+			values[sample.Metric] = append(values[sample.Metric], sample.Value)
+		}
+	}
+
+	aggregates := []entities.MetricAggregate{}
+	for metric, metricValues := range values {
+		aggregate := entities.MetricAggregate{
+			Metric:      metric,
+			SampleCount: int64(len(metricValues)),
+			MinValue:    metricValues[0],
+			MaxValue:    metricValues[0],
+		}
+		var sum float64
+		for _, value := range metricValues {
+			sum += value
+			aggregate.MinValue = math.Min(aggregate.MinValue, value)
+			aggregate.MaxValue = math.Max(aggregate.MaxValue, value)
+		}
+		aggregate.AvgValue = sum / float64(len(metricValues))
+		var squares float64
+		for _, value := range metricValues {
+			squares += (value - aggregate.AvgValue) * (value - aggregate.AvgValue)
+		}
+		aggregate.StdDev = math.Sqrt(squares / float64(len(metricValues)))
+		aggregates = append(aggregates, aggregate)
+	}
+	sort.Slice(aggregates, func(i, j int) bool { return aggregates[i].Metric < aggregates[j].Metric })
+	return aggregates, nil
+}
+
+// GetThresholdCompliance implements ports.IGardenData
+func (r *GardenDataRepositoryMemory) GetThresholdCompliance(ctx context.Context, kitID int64, metric string, min, max *float64, from, to time.Time) (entities.ThresholdCompliance, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var compliance entities.ThresholdCompliance
+	hoursOut := make(map[time.Time]bool)
+	for _, sample := range r.samples {
+		if sample.KitID != kitID || sample.Metric != metric || !inRange(sample.Timestamp, from, to) {
+			continue
+		}
+		if (min == nil || sample.Value >= *min) && (max == nil || sample.Value <= *max) {
+			compliance.WithinCount++
+		} else {
+			hoursOut[sample.Timestamp.Truncate(time.Hour)] = true
+		}
+	}
+	compliance.HoursOutOfRange = int64(len(hoursOut))
+	return compliance, nil
+}
+
+// GetRecordTimes implements ports.IGardenData
+func (r *GardenDataRepositoryMemory) GetRecordTimes(ctx context.Context, kitID int64, from, to time.Time) ([]time.Time, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	times := []time.Time{}
+	for _, record := range r.records {
+		if record.KitID == kitID && inRange(record.Timestamp, from, to) {
+			times = append(times, record.Timestamp)
+		}
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	return times, nil
+}
+
+// GetLastRecordTimes implements ports.IGardenData
+// Only kits with data are known here, so every page but the last is full.
+func (r *GardenDataRepositoryMemory) GetLastRecordTimes(ctx context.Context, afterKitID int64, limit int) (map[int64]time.Time, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	all := make(map[int64]time.Time)
+	for _, record := range r.records {
+		if record.KitID > afterKitID && record.Timestamp.After(all[record.KitID]) {
+			all[record.KitID] = record.Timestamp
+		}
+	}
+	kitIDs := make([]int64, 0, len(all))
+	for kitID := range all {
+		kitIDs = append(kitIDs, kitID)
+	}
+	sort.Slice(kitIDs, func(i, j int) bool { return kitIDs[i] < kitIDs[j] })
+	if len(kitIDs) > limit {
+		kitIDs = kitIDs[:limit]
+	}
+
+	last := make(map[int64]time.Time, len(kitIDs))
+	for _, kitID := range kitIDs {
+		last[kitID] = all[kitID]
+	}
+	return last, nil
+}
+
+// inRange reports whether t lies in [from, to).
+func inRange(t, from, to time.Time) bool {
+	return !t.Before(from) && t.Before(to)
+}
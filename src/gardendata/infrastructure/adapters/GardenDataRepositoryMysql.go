@@ -3,62 +3,119 @@ package adapters
 import (
 	database "api-order/src/Database"          // Adjust path if needed
 	"api-order/src/gardendata/domain/entities" // Corrected path
+	kit "api-order/src/kit/domain/ports"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/logging"
+	"api-order/src/shared/pagination"
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
-	"log"
+	"strings"
+	"time"
 )
 
-type GardenDataRepositoryMysql struct {
-	DB *sql.DB
+// orderColumn maps an entities.OrderBy* sort field to the SQL expression to filter and sort on.
+func orderColumn(orderBy string) string {
+	if orderBy == entities.OrderByEventTime {
+		return "event_time"
+	}
+	return "timestamp"
 }
 
-func NewGardenDataRepositoryMysql() (*GardenDataRepositoryMysql, error) {
-	// Assuming database.Connect() provides a configured *sql.DB
-	db, err := database.Connect()
-	if err != nil {
-		log.Printf("Error connecting to database for GardenDataRepository: %v", err)
-		return nil, fmt.Errorf("database connection failed: %w", err)
+// storedTimestamp is the timestamp argument of an insert: nil lets the
+// database file the row at the current time, which is the case of live
+// readings; imported readings carry the time they were taken.
+func storedTimestamp(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
 	}
-	return &GardenDataRepositoryMysql{DB: db}, nil
+	return t
+}
+
+type GardenDataRepositoryMysql struct {
+	DB       *sql.DB
+	Timeouts database.Timeouts
+}
+
+// NewGardenDataRepositoryMysql wraps the shared connection pool.
+func NewGardenDataRepositoryMysql(db *sql.DB, timeouts database.Timeouts) *GardenDataRepositoryMysql {
+	return &GardenDataRepositoryMysql{DB: db, Timeouts: timeouts}
 }
 
 // Create implements ports.IGardenData
-func (r *GardenDataRepositoryMysql) Create(data entities.GardenData) (entities.GardenData, error) {
+// The garden_data row and its metric samples are written in a single transaction.
+func (r *GardenDataRepositoryMysql) Create(ctx context.Context, data entities.GardenData) (entities.GardenData, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpWrite)
+	defer cancel()
+
 	query := `
         INSERT INTO garden_data
-        (kit_id, temperature, ground_humidity, enviroment_humidity, ph_level, time)
-        VALUES (?, ?, ?, ?, ?, ?)
+        (kit_id, temperature, ground_humidity, environment_humidity, ph_level, time, event_time, clock_skew_seconds, timestamp)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, COALESCE(?, CURRENT_TIMESTAMP(6)))
     `
-	stmt, err := r.DB.Prepare(query)
+	tx, err := r.DB.BeginTx(ctx, nil)
 	if err != nil {
-		log.Printf("Error preparing garden data insert statement: %v", err)
-		return entities.GardenData{}, fmt.Errorf("database prepare error: %w", err)
+		logging.FromContext(ctx).Error("Error starting garden data transaction", "error", err)
+		return entities.GardenData{}, fmt.Errorf("database transaction error: %w", err)
 	}
-	defer stmt.Close()
+	defer tx.Rollback()
 
-	result, err := stmt.Exec(
+	result, err := tx.ExecContext(ctx,
+		query,
 		data.KitID,
 		data.Temperature,
 		data.GroundHumidity,
 		data.EnvironmentHumidity,
 		data.PhLevel,
 		data.Time,
+		data.EventTime,
+		data.ClockSkewSeconds,
+		storedTimestamp(data.Timestamp),
 	)
 	if err != nil {
 		// Log the specific error for debugging
-		log.Printf("Error executing garden data insert for kit %d: %v", data.KitID, err)
-		// Check for foreign key constraints, etc., if needed
+		logging.FromContext(ctx).Error("Error executing garden data insert for kit", "kit_id", data.KitID, "error", err)
+		// kit_id is the only foreign key of garden_data
+		if err = database.TranslateError(err); errors.Is(err, domainerrors.ErrMissingReference) {
+			return entities.GardenData{}, kit.ErrKitNotFound.Wrap(err)
+		}
 		return entities.GardenData{}, fmt.Errorf("database execution error: %w", err)
 	}
 
 	id, err := result.LastInsertId()
 	if err != nil {
-		log.Printf("Error getting last insert ID for garden data: %v", err)
+		logging.FromContext(ctx).Error("Error getting last insert ID for garden data", "error", err)
 		// This usually indicates a more serious problem
 		return entities.GardenData{}, fmt.Errorf("failed to retrieve last insert ID: %w", err)
 	}
 
 	data.DataID = id
+
+	if len(data.Samples) > 0 {
+		placeholders := make([]string, len(data.Samples))
+		args := make([]interface{}, 0, len(data.Samples)*7)
+		for i := range data.Samples {
+			data.Samples[i].DataID = id
+			data.Samples[i].KitID = data.KitID
+			data.Samples[i].Time = data.Time
+			data.Samples[i].EventTime = data.EventTime
+			placeholders[i] = "(?, ?, ?, ?, ?, ?, COALESCE(?, CURRENT_TIMESTAMP(6)))"
+			args = append(args, id, data.KitID, data.Samples[i].Metric, data.Samples[i].Value, data.Time, data.EventTime, storedTimestamp(data.Timestamp))
+		}
+
+		sampleQuery := "INSERT INTO metric_samples (data_id, kit_id, metric_name, value, time, event_time, timestamp) VALUES " + strings.Join(placeholders, ", ")
+		if _, err := tx.ExecContext(ctx, sampleQuery, args...); err != nil {
+			logging.FromContext(ctx).Error("Error inserting metric samples for kit", "kit_id", data.KitID, "error", err)
+			return entities.GardenData{}, fmt.Errorf("database execution error: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logging.FromContext(ctx).Error("Error committing garden data for kit", "kit_id", data.KitID, "error", err)
+		return entities.GardenData{}, fmt.Errorf("database commit error: %w", err)
+	}
+
 	// We might need to fetch the timestamp generated by the DB if we want it immediately
 	// For simplicity now, we assume the controller doesn't need the exact DB timestamp right after creation
 
@@ -66,19 +123,25 @@ func (r *GardenDataRepositoryMysql) Create(data entities.GardenData) (entities.G
 }
 
 // GetRecordsByKitIDAndTime implements ports.IGardenData
-func (r *GardenDataRepositoryMysql) GetRecordsByKitIDAndTime(kitID int64, minutesAgo int) ([]entities.GardenData, error) {
+func (r *GardenDataRepositoryMysql) GetRecordsByKitIDAndTime(ctx context.Context, kitID int64, minutesAgo int, page pagination.CursorParams) ([]entities.GardenData, bool, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	column := orderColumn(page.Sort.Field)
+	condition, keysetArgs, orderBy := pagination.KeysetClause(column, "data_id", page)
 	// Use MySQL's NOW() and INTERVAL functions for filtering
 	query := `
-        SELECT data_id, kit_id, temperature, ground_humidity, enviroment_humidity, ph_level, time, timestamp
+        SELECT data_id, kit_id, temperature, ground_humidity, environment_humidity, ph_level, time, timestamp,
+               event_time, clock_skew_seconds
         FROM garden_data
         WHERE kit_id = ?
-          AND timestamp >= NOW() - INTERVAL ? MINUTE
-        ORDER BY timestamp DESC
-    `
-	rows, err := r.DB.Query(query, kitID, minutesAgo)
+          AND ` + column + ` >= NOW() - INTERVAL ? MINUTE` + condition + orderBy + " LIMIT ?"
+	args := append(append([]interface{}{kitID, minutesAgo}, keysetArgs...), page.Limit+1)
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
 	if err != nil {
-		log.Printf("Error querying garden data by kit %d and time (%d min): %v", kitID, minutesAgo, err)
-		return nil, fmt.Errorf("database query error: %w", err)
+		logging.FromContext(ctx).Error("Error querying garden data by kit and time", "kit_id", kitID, "minutes", minutesAgo, "error", err)
+		return nil, false, fmt.Errorf("database query error: %w", err)
 	}
 	defer rows.Close()
 
@@ -95,24 +158,352 @@ func (r *GardenDataRepositoryMysql) GetRecordsByKitIDAndTime(kitID int64, minute
 			&record.PhLevel,
 			&record.Time,
 			&record.Timestamp, // Scan the DB timestamp
+			&record.EventTime,
+			&record.ClockSkewSeconds,
 		); err != nil {
-			log.Printf("Error scanning garden data row: %v", err)
+			logging.FromContext(ctx).Error("Error scanning garden data row", "error", err)
 			// Return potentially partial results or fail entirely? Failing is safer.
-			return nil, fmt.Errorf("database scan error: %w", err)
+			return nil, false, fmt.Errorf("database scan error: %w", err)
 		}
 		records = append(records, record)
 	}
 
 	// Check for errors encountered during iteration
 	if err = rows.Err(); err != nil {
-		log.Printf("Error after iterating garden data rows: %v", err)
-		return nil, fmt.Errorf("database row iteration error: %w", err)
+		logging.FromContext(ctx).Error("Error after iterating garden data rows", "error", err)
+		return nil, false, fmt.Errorf("database row iteration error: %w", err)
 	}
 
 	// Return empty slice if no records were found (not an error)
 	if len(records) == 0 {
-		return []entities.GardenData{}, nil
+		return []entities.GardenData{}, false, nil
+	}
+
+	records, hasMore := pagination.TrimPage(records, page.Limit)
+	return records, hasMore, nil
+}
+
+// GetSamplesByKitIDAndTime implements ports.IGardenData
+func (r *GardenDataRepositoryMysql) GetSamplesByKitIDAndTime(ctx context.Context, kitID int64, minutesAgo int, metrics []string, page pagination.CursorParams) ([]entities.MetricSample, bool, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	column := orderColumn(page.Sort.Field)
+	query := `
+        SELECT sample_id, data_id, kit_id, metric_name, value, time, timestamp, event_time
+        FROM metric_samples
+        WHERE kit_id = ?
+          AND ` + column + ` >= NOW() - INTERVAL ? MINUTE
+    `
+	args := []interface{}{kitID, minutesAgo}
+	if len(metrics) > 0 {
+		query += " AND metric_name IN (?" + strings.Repeat(", ?", len(metrics)-1) + ")"
+		for _, metric := range metrics {
+			args = append(args, metric)
+		}
 	}
+	condition, keysetArgs, orderBy := pagination.KeysetClause(column, "sample_id", page)
+	query += condition + orderBy + " LIMIT ?"
+	args = append(append(args, keysetArgs...), page.Limit+1)
 
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error querying metric samples by kit and time", "kit_id", kitID, "minutes", minutesAgo, "error", err)
+		return nil, false, fmt.Errorf("database query error: %w", err)
+	}
+	defer rows.Close()
+
+	samples := []entities.MetricSample{}
+	for rows.Next() {
+		var sample entities.MetricSample
+		if err := rows.Scan(
+			&sample.SampleID,
+			&sample.DataID,
+			&sample.KitID,
+			&sample.Metric,
+			&sample.Value,
+			&sample.Time,
+			&sample.Timestamp,
+			&sample.EventTime,
+		); err != nil {
+			logging.FromContext(ctx).Error("Error scanning metric sample row", "error", err)
+			return nil, false, fmt.Errorf("database scan error: %w", err)
+		}
+		samples = append(samples, sample)
+	}
+
+	if err = rows.Err(); err != nil {
+		logging.FromContext(ctx).Error("Error after iterating metric sample rows", "error", err)
+		return nil, false, fmt.Errorf("database row iteration error: %w", err)
+	}
+
+	samples, hasMore := pagination.TrimPage(samples, page.Limit)
+	return samples, hasMore, nil
+}
+
+// GetRecordsPage implements ports.IGardenData
+func (r *GardenDataRepositoryMysql) GetRecordsPage(ctx context.Context, kitID int64, from, to time.Time, afterID int64, limit int) ([]entities.GardenData, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpBatch)
+	defer cancel()
+
+	query := `
+        SELECT data_id, kit_id, temperature, ground_humidity, environment_humidity, ph_level, time, timestamp,
+               event_time, clock_skew_seconds
+        FROM garden_data
+        WHERE kit_id = ?
+          AND timestamp >= ?
+          AND timestamp < ?
+          AND data_id > ?
+        ORDER BY data_id
+        LIMIT ?
+    `
+	rows, err := r.DB.QueryContext(ctx, query, kitID, from, to, afterID, limit)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error querying garden data page for kit", "kit_id", kitID, "after_id", afterID, "error", err)
+		return nil, fmt.Errorf("database query error: %w", err)
+	}
+	defer rows.Close()
+
+	records := make([]entities.GardenData, 0, limit)
+	for rows.Next() {
+		var record entities.GardenData
+		if err := rows.Scan(
+			&record.DataID,
+			&record.KitID,
+			&record.Temperature,
+			&record.GroundHumidity,
+			&record.EnvironmentHumidity,
+			&record.PhLevel,
+			&record.Time,
+			&record.Timestamp,
+			&record.EventTime,
+			&record.ClockSkewSeconds,
+		); err != nil {
+			logging.FromContext(ctx).Error("Error scanning garden data row", "error", err)
+			return nil, fmt.Errorf("database scan error: %w", err)
+		}
+		records = append(records, record)
+	}
+
+	if err = rows.Err(); err != nil {
+		logging.FromContext(ctx).Error("Error after iterating garden data page rows", "error", err)
+		return nil, fmt.Errorf("database row iteration error: %w", err)
+	}
+
+	if err := r.attachSamples(ctx, records); err != nil {
+		return nil, err
+	}
 	return records, nil
 }
+
+// attachSamples loads the samples of a page of records with one query.
+func (r *GardenDataRepositoryMysql) attachSamples(ctx context.Context, records []entities.GardenData) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	byID := make(map[int64]*entities.GardenData, len(records))
+	args := make([]interface{}, len(records))
+	for i := range records {
+		byID[records[i].DataID] = &records[i]
+		args[i] = records[i].DataID
+	}
+	query := "SELECT sample_id, data_id, metric_name, value FROM metric_samples WHERE data_id IN (?" + strings.Repeat(", ?", len(records)-1) + ") ORDER BY sample_id"
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error querying samples of garden data page", "error", err)
+		return fmt.Errorf("database query error: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sample entities.MetricSample
+		if err := rows.Scan(&sample.SampleID, &sample.DataID, &sample.Metric, &sample.Value); err != nil {
+			logging.FromContext(ctx).Error("Error scanning metric sample row", "error", err)
+			return fmt.Errorf("database scan error: %w", err)
+		}
+		record := byID[sample.DataID]
+		sample.KitID = record.KitID
+		sample.Time = record.Time
+		sample.Timestamp = record.Timestamp
+		sample.EventTime = record.EventTime
+		record.Samples = append(record.Samples, sample)
+	}
+	if err = rows.Err(); err != nil {
+		logging.FromContext(ctx).Error("Error after iterating metric sample rows", "error", err)
+		return fmt.Errorf("database row iteration error: %w", err)
+	}
+	return nil
+}
+
+// GetExistingTimes implements ports.IGardenData
+func (r *GardenDataRepositoryMysql) GetExistingTimes(ctx context.Context, kitID int64, times []int64) (map[int64]bool, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	existing := make(map[int64]bool)
+	if len(times) == 0 {
+		return existing, nil
+	}
+
+	query := "SELECT DISTINCT time FROM garden_data WHERE kit_id = ? AND time IN (?" + strings.Repeat(", ?", len(times)-1) + ")"
+	args := make([]interface{}, 0, len(times)+1)
+	args = append(args, kitID)
+	for _, t := range times {
+		args = append(args, t)
+	}
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error querying existing garden data times for kit", "kit_id", kitID, "error", err)
+		return nil, fmt.Errorf("database query error: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var t int64
+		if err := rows.Scan(&t); err != nil {
+			return nil, fmt.Errorf("database scan error: %w", err)
+		}
+		existing[t] = true
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("database row iteration error: %w", err)
+	}
+	return existing, nil
+}
+
+// GetMetricAggregates implements ports.IGardenData
+func (r *GardenDataRepositoryMysql) GetMetricAggregates(ctx context.Context, kitID int64, from, to time.Time) ([]entities.MetricAggregate, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpBatch)
+	defer cancel()
+
+	query := `
+        SELECT metric_name, COUNT(*), MIN(value), MAX(value), AVG(value), STDDEV_POP(value)
+        FROM metric_samples
+        WHERE kit_id = ? AND timestamp >= ? AND timestamp < ?
+        GROUP BY metric_name
+        ORDER BY metric_name
+    `
+	rows, err := r.DB.QueryContext(ctx, query, kitID, from, to)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error aggregating metric samples for kit", "kit_id", kitID, "error", err)
+		return nil, fmt.Errorf("database query error: %w", err)
+	}
+	defer rows.Close()
+
+	aggregates := []entities.MetricAggregate{}
+	for rows.Next() {
+		var aggregate entities.MetricAggregate
+		if err := rows.Scan(
+			&aggregate.Metric,
+			&aggregate.SampleCount,
+			&aggregate.MinValue,
+			&aggregate.MaxValue,
+			&aggregate.AvgValue,
+			&aggregate.StdDev,
+		); err != nil {
+			logging.FromContext(ctx).Error("Error scanning metric aggregate row", "error", err)
+			return nil, fmt.Errorf("database scan error: %w", err)
+		}
+		aggregates = append(aggregates, aggregate)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("database row iteration error: %w", err)
+	}
+	return aggregates, nil
+}
+
+// GetThresholdCompliance implements ports.IGardenData
+func (r *GardenDataRepositoryMysql) GetThresholdCompliance(ctx context.Context, kitID int64, metric string, min, max *float64, from, to time.Time) (entities.ThresholdCompliance, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpBatch)
+	defer cancel()
+
+	condition := "TRUE"
+	var bounds []interface{}
+	if min != nil {
+		condition += " AND value >= ?"
+		bounds = append(bounds, *min)
+	}
+	if max != nil {
+		condition += " AND value <= ?"
+		bounds = append(bounds, *max)
+	}
+
+	query := `
+        SELECT COALESCE(SUM(CASE WHEN ` + condition + ` THEN 1 ELSE 0 END), 0),
+               COUNT(DISTINCT CASE WHEN NOT (` + condition + `) THEN DATE_FORMAT(timestamp, '%Y-%m-%d %H') END)
+        FROM metric_samples
+        WHERE kit_id = ? AND metric_name = ? AND timestamp >= ? AND timestamp < ?
+    `
+	args := append(append(append([]interface{}{}, bounds...), bounds...), kitID, metric, from, to)
+
+	var compliance entities.ThresholdCompliance
+	if err := r.DB.QueryRowContext(ctx, query, args...).Scan(&compliance.WithinCount, &compliance.HoursOutOfRange); err != nil {
+		logging.FromContext(ctx).Error("Error computing threshold compliance for kit", "metric", metric, "kit_id", kitID, "error", err)
+		return entities.ThresholdCompliance{}, fmt.Errorf("database query error: %w", err)
+	}
+	return compliance, nil
+}
+
+// GetRecordTimes implements ports.IGardenData
+func (r *GardenDataRepositoryMysql) GetRecordTimes(ctx context.Context, kitID int64, from, to time.Time) ([]time.Time, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpBatch)
+	defer cancel()
+
+	query := "SELECT timestamp FROM garden_data WHERE kit_id = ? AND timestamp >= ? AND timestamp < ? ORDER BY timestamp"
+	rows, err := r.DB.QueryContext(ctx, query, kitID, from, to)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error querying record times for kit", "kit_id", kitID, "error", err)
+		return nil, fmt.Errorf("database query error: %w", err)
+	}
+	defer rows.Close()
+
+	times := []time.Time{}
+	for rows.Next() {
+		var t time.Time
+		if err := rows.Scan(&t); err != nil {
+			return nil, fmt.Errorf("database scan error: %w", err)
+		}
+		times = append(times, t)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("database row iteration error: %w", err)
+	}
+	return times, nil
+}
+
+// GetLastRecordTimes implements ports.IGardenData
+func (r *GardenDataRepositoryMysql) GetLastRecordTimes(ctx context.Context, afterKitID int64, limit int) (map[int64]time.Time, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpBatch)
+	defer cancel()
+
+	// One index lookup per kit rather than an aggregate over every record
+	query := `
+        SELECT k.kit_id, (SELECT MAX(d.timestamp) FROM garden_data d WHERE d.kit_id = k.kit_id)
+        FROM kits k
+        WHERE k.kit_id > ?
+        ORDER BY k.kit_id
+        LIMIT ?
+    `
+	rows, err := r.DB.QueryContext(ctx, query, afterKitID, limit)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error querying last record times", "error", err)
+		return nil, fmt.Errorf("database query error: %w", err)
+	}
+	defer rows.Close()
+
+	last := make(map[int64]time.Time)
+	for rows.Next() {
+		var kitID int64
+		var t sql.NullTime
+		if err := rows.Scan(&kitID, &t); err != nil {
+			return nil, fmt.Errorf("database scan error: %w", err)
+		}
+		last[kitID] = t.Time
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("database row iteration error: %w", err)
+	}
+	return last, nil
+}
@@ -0,0 +1,478 @@
+package adapters
+
+import (
+	database "api-order/src/Database"
+	"api-order/src/gardendata/domain/entities"
+	kit "api-order/src/kit/domain/ports"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/logging"
+	"api-order/src/shared/pagination"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// sqliteWindowStart is the SQLite equivalent of NOW() - INTERVAL ? MINUTE: it is
+// evaluated on the database clock and takes a "-N minutes" modifier.
+const sqliteWindowStart = "(strftime('%Y-%m-%d %H:%M:%f', 'now', ?) || '000')"
+
+func minutesModifier(minutes int) string {
+	return fmt.Sprintf("-%d minutes", minutes)
+}
+
+type GardenDataRepositorySqlite struct {
+	DB       *sql.DB
+	Timeouts database.Timeouts
+}
+
+// NewGardenDataRepositorySqlite wraps the shared connection pool.
+func NewGardenDataRepositorySqlite(db *sql.DB, timeouts database.Timeouts) *GardenDataRepositorySqlite {
+	return &GardenDataRepositorySqlite{DB: db, Timeouts: timeouts}
+}
+
+// Create implements ports.IGardenData
+// The garden_data row and its metric samples are written in a single transaction.
+func (r *GardenDataRepositorySqlite) Create(ctx context.Context, data entities.GardenData) (entities.GardenData, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpWrite)
+	defer cancel()
+
+	query := `
+        INSERT INTO garden_data
+        (kit_id, temperature, ground_humidity, environment_humidity, ph_level, time, event_time, clock_skew_seconds, timestamp)
+        VALUES (?, ?, ?, ?, ?, ?, ?, ?, COALESCE(?, ` + database.SQLiteNow + `))
+    `
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error starting garden data transaction", "error", err)
+		return entities.GardenData{}, fmt.Errorf("database transaction error: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, query, database.SQLiteArgs(
+		data.KitID,
+		data.Temperature,
+		data.GroundHumidity,
+		data.EnvironmentHumidity,
+		data.PhLevel,
+		data.Time,
+		data.EventTime,
+		data.ClockSkewSeconds,
+		storedTimestamp(data.Timestamp),
+	)...)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error executing garden data insert for kit", "kit_id", data.KitID, "error", err)
+		// kit_id is the only foreign key of garden_data
+		if err = database.TranslateError(err); errors.Is(err, domainerrors.ErrMissingReference) {
+			return entities.GardenData{}, kit.ErrKitNotFound.Wrap(err)
+		}
+		return entities.GardenData{}, fmt.Errorf("database execution error: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		logging.FromContext(ctx).Error("Error getting last insert ID for garden data", "error", err)
+		return entities.GardenData{}, fmt.Errorf("failed to retrieve last insert ID: %w", err)
+	}
+	data.DataID = id
+
+	if len(data.Samples) > 0 {
+		placeholders := make([]string, len(data.Samples))
+		args := make([]interface{}, 0, len(data.Samples)*7)
+		for i := range data.Samples {
+			data.Samples[i].DataID = id
+			data.Samples[i].KitID = data.KitID
+			data.Samples[i].Time = data.Time
+			data.Samples[i].EventTime = data.EventTime
+			placeholders[i] = "(?, ?, ?, ?, ?, ?, COALESCE(?, " + database.SQLiteNow + "))"
+			args = append(args, id, data.KitID, data.Samples[i].Metric, data.Samples[i].Value, data.Time, data.EventTime, storedTimestamp(data.Timestamp))
+		}
+
+		sampleQuery := "INSERT INTO metric_samples (data_id, kit_id, metric_name, value, time, event_time, timestamp) VALUES " + strings.Join(placeholders, ", ")
+		if _, err := tx.ExecContext(ctx, sampleQuery, database.SQLiteArgs(args...)...); err != nil {
+			logging.FromContext(ctx).Error("Error inserting metric samples for kit", "kit_id", data.KitID, "error", err)
+			return entities.GardenData{}, fmt.Errorf("database execution error: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		logging.FromContext(ctx).Error("Error committing garden data for kit", "kit_id", data.KitID, "error", err)
+		return entities.GardenData{}, fmt.Errorf("database commit error: %w", err)
+	}
+	return data, nil
+}
+
+const sqliteRecordColumns = `data_id, kit_id, temperature, ground_humidity, environment_humidity, ph_level, time, timestamp,
+               event_time, clock_skew_seconds`
+
+func scanSqliteRecord(rows *sql.Rows) (entities.GardenData, error) {
+	var record entities.GardenData
+	err := rows.Scan(
+		&record.DataID,
+		&record.KitID,
+		&record.Temperature,
+		&record.GroundHumidity,
+		&record.EnvironmentHumidity,
+		&record.PhLevel,
+		&record.Time,
+		database.SQLiteTime(&record.Timestamp),
+		database.SQLiteTime(&record.EventTime),
+		&record.ClockSkewSeconds,
+	)
+	return record, err
+}
+
+// GetRecordsByKitIDAndTime implements ports.IGardenData
+func (r *GardenDataRepositorySqlite) GetRecordsByKitIDAndTime(ctx context.Context, kitID int64, minutesAgo int, page pagination.CursorParams) ([]entities.GardenData, bool, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	column := orderColumn(page.Sort.Field)
+	condition, keysetArgs, orderBy := pagination.KeysetClause(column, "data_id", page)
+	query := `
+        SELECT ` + sqliteRecordColumns + `
+        FROM garden_data
+        WHERE kit_id = ?
+          AND ` + column + ` >= ` + sqliteWindowStart + condition + orderBy + " LIMIT ?"
+	args := append(append([]interface{}{kitID, minutesModifier(minutesAgo)}, keysetArgs...), page.Limit+1)
+
+	rows, err := r.DB.QueryContext(ctx, query, database.SQLiteArgs(args...)...)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error querying garden data by kit and time", "kit_id", kitID, "minutes", minutesAgo, "error", err)
+		return nil, false, fmt.Errorf("database query error: %w", err)
+	}
+	defer rows.Close()
+
+	records := []entities.GardenData{}
+	for rows.Next() {
+		record, err := scanSqliteRecord(rows)
+		if err != nil {
+			logging.FromContext(ctx).Error("Error scanning garden data row", "error", err)
+			return nil, false, fmt.Errorf("database scan error: %w", err)
+		}
+		records = append(records, record)
+	}
+	if err = rows.Err(); err != nil {
+		logging.FromContext(ctx).Error("Error after iterating garden data rows", "error", err)
+		return nil, false, fmt.Errorf("database row iteration error: %w", err)
+	}
+
+	records, hasMore := pagination.TrimPage(records, page.Limit)
+	return records, hasMore, nil
+}
+
+// GetSamplesByKitIDAndTime implements ports.IGardenData
+func (r *GardenDataRepositorySqlite) GetSamplesByKitIDAndTime(ctx context.Context, kitID int64, minutesAgo int, metrics []string, page pagination.CursorParams) ([]entities.MetricSample, bool, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	column := orderColumn(page.Sort.Field)
+	query := `
+        SELECT sample_id, data_id, kit_id, metric_name, value, time, timestamp, event_time
+        FROM metric_samples
+        WHERE kit_id = ?
+          AND ` + column + ` >= ` + sqliteWindowStart
+	args := []interface{}{kitID, minutesModifier(minutesAgo)}
+	if len(metrics) > 0 {
+		query += " AND metric_name IN (?" + strings.Repeat(", ?", len(metrics)-1) + ")"
+		for _, metric := range metrics {
+			args = append(args, metric)
+		}
+	}
+	condition, keysetArgs, orderBy := pagination.KeysetClause(column, "sample_id", page)
+	query += condition + orderBy + " LIMIT ?"
+	args = append(append(args, keysetArgs...), page.Limit+1)
+
+	rows, err := r.DB.QueryContext(ctx, query, database.SQLiteArgs(args...)...)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error querying metric samples by kit and time", "kit_id", kitID, "minutes", minutesAgo, "error", err)
+		return nil, false, fmt.Errorf("database query error: %w", err)
+	}
+	defer rows.Close()
+
+	samples := []entities.MetricSample{}
+	for rows.Next() {
+		var sample entities.MetricSample
+		if err := rows.Scan(
+			&sample.SampleID,
+			&sample.DataID,
+			&sample.KitID,
+			&sample.Metric,
+			&sample.Value,
+			&sample.Time,
+			database.SQLiteTime(&sample.Timestamp),
+			database.SQLiteTime(&sample.EventTime),
+		); err != nil {
+			logging.FromContext(ctx).Error("Error scanning metric sample row", "error", err)
+			return nil, false, fmt.Errorf("database scan error: %w", err)
+		}
+		samples = append(samples, sample)
+	}
+	if err = rows.Err(); err != nil {
+		logging.FromContext(ctx).Error("Error after iterating metric sample rows", "error", err)
+		return nil, false, fmt.Errorf("database row iteration error: %w", err)
+	}
+
+	samples, hasMore := pagination.TrimPage(samples, page.Limit)
+	return samples, hasMore, nil
+}
+
+// GetRecordsPage implements ports.IGardenData
+func (r *GardenDataRepositorySqlite) GetRecordsPage(ctx context.Context, kitID int64, from, to time.Time, afterID int64, limit int) ([]entities.GardenData, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpBatch)
+	defer cancel()
+
+	query := `
+        SELECT ` + sqliteRecordColumns + `
+        FROM garden_data
+        WHERE kit_id = ?
+          AND timestamp >= ?
+          AND timestamp < ?
+          AND data_id > ?
+        ORDER BY data_id
+        LIMIT ?
+    `
+	rows, err := r.DB.QueryContext(ctx, query, database.SQLiteArgs(kitID, from, to, afterID, limit)...)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error querying garden data page for kit", "kit_id", kitID, "after_id", afterID, "error", err)
+		return nil, fmt.Errorf("database query error: %w", err)
+	}
+	defer rows.Close()
+
+	records := make([]entities.GardenData, 0, limit)
+	for rows.Next() {
+		record, err := scanSqliteRecord(rows)
+		if err != nil {
+			logging.FromContext(ctx).Error("Error scanning garden data row", "error", err)
+			return nil, fmt.Errorf("database scan error: %w", err)
+		}
+		records = append(records, record)
+	}
+	if err = rows.Err(); err != nil {
+		logging.FromContext(ctx).Error("Error after iterating garden data page rows", "error", err)
+		return nil, fmt.Errorf("database row iteration error: %w", err)
+	}
+	if err := r.attachSamples(ctx, records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// attachSamples loads the samples of a page of records with one query.
+func (r *GardenDataRepositorySqlite) attachSamples(ctx context.Context, records []entities.GardenData) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	byID := make(map[int64]*entities.GardenData, len(records))
+	args := make([]interface{}, len(records))
+	for i := range records {
+		byID[records[i].DataID] = &records[i]
+		args[i] = records[i].DataID
+	}
+	query := "SELECT sample_id, data_id, metric_name, value FROM metric_samples WHERE data_id IN (?" + strings.Repeat(", ?", len(records)-1) + ") ORDER BY sample_id"
+	rows, err := r.DB.QueryContext(ctx, query, database.SQLiteArgs(args...)...)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error querying samples of garden data page", "error", err)
+		return fmt.Errorf("database query error: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sample entities.MetricSample
+		if err := rows.Scan(&sample.SampleID, &sample.DataID, &sample.Metric, &sample.Value); err != nil {
+			logging.FromContext(ctx).Error("Error scanning metric sample row", "error", err)
+			return fmt.Errorf("database scan error: %w", err)
+		}
+		record := byID[sample.DataID]
+		sample.KitID = record.KitID
+		sample.Time = record.Time
+		sample.Timestamp = record.Timestamp
+		sample.EventTime = record.EventTime
+		record.Samples = append(record.Samples, sample)
+	}
+	if err = rows.Err(); err != nil {
+		logging.FromContext(ctx).Error("Error after iterating metric sample rows", "error", err)
+		return fmt.Errorf("database row iteration error: %w", err)
+	}
+	return nil
+}
+
+// GetExistingTimes implements ports.IGardenData
+func (r *GardenDataRepositorySqlite) GetExistingTimes(ctx context.Context, kitID int64, times []int64) (map[int64]bool, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	existing := make(map[int64]bool)
+	if len(times) == 0 {
+		return existing, nil
+	}
+
+	query := "SELECT DISTINCT time FROM garden_data WHERE kit_id = ? AND time IN (?" + strings.Repeat(", ?", len(times)-1) + ")"
+	args := make([]interface{}, 0, len(times)+1)
+	args = append(args, kitID)
+	for _, t := range times {
+		args = append(args, t)
+	}
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error querying existing garden data times for kit", "kit_id", kitID, "error", err)
+		return nil, fmt.Errorf("database query error: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var t int64
+		if err := rows.Scan(&t); err != nil {
+			return nil, fmt.Errorf("database scan error: %w", err)
+		}
+		existing[t] = true
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("database row iteration error: %w", err)
+	}
+	return existing, nil
+}
+
+// GetMetricAggregates implements ports.IGardenData
+// SQLite has no STDDEV_POP, so the deviation is derived from the mean of the squares.
+func (r *GardenDataRepositorySqlite) GetMetricAggregates(ctx context.Context, kitID int64, from, to time.Time) ([]entities.MetricAggregate, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpBatch)
+	defer cancel()
+
+	query := `
+        SELECT metric_name, COUNT(*), MIN(value), MAX(value), AVG(value), AVG(value * value)
+        FROM metric_samples
+        WHERE kit_id = ? AND timestamp >= ? AND timestamp < ?
+        GROUP BY metric_name
+        ORDER BY metric_name
+    `
+	rows, err := r.DB.QueryContext(ctx, query, database.SQLiteArgs(kitID, from, to)...)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error aggregating metric samples for kit", "kit_id", kitID, "error", err)
+		return nil, fmt.Errorf("database query error: %w", err)
+	}
+	defer rows.Close()
+
+	aggregates := []entities.MetricAggregate{}
+	for rows.Next() {
+		var aggregate entities.MetricAggregate
+		var meanOfSquares float64
+		if err := rows.Scan(
+			&aggregate.Metric,
+			&aggregate.SampleCount,
+			&aggregate.MinValue,
+			&aggregate.MaxValue,
+			&aggregate.AvgValue,
+			&meanOfSquares,
+		); err != nil {
+			logging.FromContext(ctx).Error("Error scanning metric aggregate row", "error", err)
+			return nil, fmt.Errorf("database scan error: %w", err)
+		}
+		// Rounding can leave a tiny negative variance for constant series
+		aggregate.StdDev = math.Sqrt(math.Max(0, meanOfSquares-aggregate.AvgValue*aggregate.AvgValue))
+		aggregates = append(aggregates, aggregate)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("database row iteration error: %w", err)
+	}
+	return aggregates, nil
+}
+
+// GetThresholdCompliance implements ports.IGardenData
+func (r *GardenDataRepositorySqlite) GetThresholdCompliance(ctx context.Context, kitID int64, metric string, min, max *float64, from, to time.Time) (entities.ThresholdCompliance, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpBatch)
+	defer cancel()
+
+	condition := "1"
+	var bounds []interface{}
+	if min != nil {
+		condition += " AND value >= ?"
+		bounds = append(bounds, *min)
+	}
+	if max != nil {
+		condition += " AND value <= ?"
+		bounds = append(bounds, *max)
+	}
+
+	query := `
+        SELECT COALESCE(SUM(CASE WHEN ` + condition + ` THEN 1 ELSE 0 END), 0),
+               COUNT(DISTINCT CASE WHEN NOT (` + condition + `) THEN strftime('%Y-%m-%d %H', timestamp) END)
+        FROM metric_samples
+        WHERE kit_id = ? AND metric_name = ? AND timestamp >= ? AND timestamp < ?
+    `
+	args := append(append(append([]interface{}{}, bounds...), bounds...), kitID, metric, from, to)
+
+	var compliance entities.ThresholdCompliance
+	if err := r.DB.QueryRowContext(ctx, query, database.SQLiteArgs(args...)...).Scan(&compliance.WithinCount, &compliance.HoursOutOfRange); err != nil {
+		logging.FromContext(ctx).Error("Error computing threshold compliance for kit", "metric", metric, "kit_id", kitID, "error", err)
+		return entities.ThresholdCompliance{}, fmt.Errorf("database query error: %w", err)
+	}
+	return compliance, nil
+}
+
+// GetRecordTimes implements ports.IGardenData
+func (r *GardenDataRepositorySqlite) GetRecordTimes(ctx context.Context, kitID int64, from, to time.Time) ([]time.Time, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpBatch)
+	defer cancel()
+
+	query := "SELECT timestamp FROM garden_data WHERE kit_id = ? AND timestamp >= ? AND timestamp < ? ORDER BY timestamp"
+	rows, err := r.DB.QueryContext(ctx, query, database.SQLiteArgs(kitID, from, to)...)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error querying record times for kit", "kit_id", kitID, "error", err)
+		return nil, fmt.Errorf("database query error: %w", err)
+	}
+	defer rows.Close()
+
+	times := []time.Time{}
+	for rows.Next() {
+		var t time.Time
+		if err := rows.Scan(database.SQLiteTime(&t)); err != nil {
+			return nil, fmt.Errorf("database scan error: %w", err)
+		}
+		times = append(times, t)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("database row iteration error: %w", err)
+	}
+	return times, nil
+}
+
+// GetLastRecordTimes implements ports.IGardenData
+func (r *GardenDataRepositorySqlite) GetLastRecordTimes(ctx context.Context, afterKitID int64, limit int) (map[int64]time.Time, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpBatch)
+	defer cancel()
+
+	// One index lookup per kit rather than an aggregate over every record
+	query := `
+        SELECT k.kit_id, (SELECT MAX(d.timestamp) FROM garden_data d WHERE d.kit_id = k.kit_id)
+        FROM kits k
+        WHERE k.kit_id > ?
+        ORDER BY k.kit_id
+        LIMIT ?
+    `
+	rows, err := r.DB.QueryContext(ctx, query, afterKitID, limit)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error querying last record times", "error", err)
+		return nil, fmt.Errorf("database query error: %w", err)
+	}
+	defer rows.Close()
+
+	last := make(map[int64]time.Time)
+	for rows.Next() {
+		var kitID int64
+		var t time.Time
+		if err := rows.Scan(&kitID, database.SQLiteTime(&t)); err != nil {
+			return nil, fmt.Errorf("database scan error: %w", err)
+		}
+		last[kitID] = t
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("database row iteration error: %w", err)
+	}
+	return last, nil
+}
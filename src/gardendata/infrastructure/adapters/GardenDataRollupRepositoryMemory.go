@@ -0,0 +1,220 @@
+package adapters
+
+import (
+	"api-order/src/gardendata/domain/entities"
+	metric "api-order/src/metric/domain/entities"
+	"context"
+	"math"
+	"sort"
+	"sync"
+	"time"
+)
+
+type rollupKey struct {
+	kitID       int64
+	metric      string
+	granularity string
+	bucketStart time.Time
+}
+
+// GardenDataRollupRepositoryMemory keeps rollups in process memory and prunes
+// the raw readings of a GardenDataRepositoryMemory. It backs the HTTP test suite.
+// mu is always taken before the lock of data.
+type GardenDataRollupRepositoryMemory struct {
+	mu       sync.RWMutex
+	data     *GardenDataRepositoryMemory
+	rollups  map[rollupKey]entities.MetricRollup
+	rolledUp map[int64]bool
+}
+
+func NewGardenDataRollupRepositoryMemory(data *GardenDataRepositoryMemory) *GardenDataRollupRepositoryMemory {
+	return &GardenDataRollupRepositoryMemory{
+		data:     data,
+		rollups:  make(map[rollupKey]entities.MetricRollup),
+		rolledUp: make(map[int64]bool),
+	}
+}
+
+// RollupPending implements ports.IGardenDataRollup
+func (r *GardenDataRollupRepositoryMemory) RollupPending(ctx context.Context, before time.Time) (int64, int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.data.mu.RLock()
+	defer r.data.mu.RUnlock()
+
+	claimed := make(map[int64]bool)
+	for _, record := range r.data.records {
+		if !r.rolledUp[record.DataID] && record.Timestamp.Before(before) {
+			claimed[record.DataID] = true
+		}
+	}
+
+	// Buckets start from the stored rollup so late readings are added to it
+	buckets := make(map[rollupKey]*entities.MetricRollup)
+	addValue := func(key rollupKey, value float64) {
+		if _, ok := buckets[key]; !ok {
+			if stored, ok := r.rollups[key]; ok {
+				buckets[key] = &stored
+			}
+		}
+		addToBucket(buckets, key, 1, value, value, value)
+	}
+	add := func(kitID int64, name string, value float64, timestamp time.Time) {
+		day := time.Date(timestamp.Year(), timestamp.Month(), timestamp.Day(), 0, 0, 0, 0, timestamp.Location())
+		addValue(rollupKey{kitID, name, entities.GranularityHour, timestamp.Truncate(time.Hour)}, value)
+		addValue(rollupKey{kitID, name, entities.GranularityDay, day}, value)
+	}
+	sampled := make(map[int64]bool)
+	for _, sample := range r.data.samples {
+		sampled[sample.DataID] = true
+		if claimed[sample.DataID] {
+			add(sample.KitID, sample.Metric, sample.Value, sample.Timestamp)
+		}
+	}
+	// Legacy readings without samples only have the fixed columns
+	for _, record := range r.data.records {
+		if !claimed[record.DataID] || sampled[record.DataID] {
+			continue
+		}
+		for name, value := range map[string]float64{
+			metric.MetricTemperature:         record.Temperature,
+			metric.MetricGroundHumidity:      record.GroundHumidity,
+			metric.MetricEnvironmentHumidity: record.EnvironmentHumidity,
+			metric.MetricPhLevel:             record.PhLevel,
+		} {
+			add(record.KitID, name, value, record.Timestamp)
+		}
+	}
+
+	var hourly, daily int64
+	for key, bucket := range buckets {
+		r.rollups[key] = *bucket
+		if key.granularity == entities.GranularityHour {
+			hourly++
+		} else {
+			daily++
+		}
+	}
+	for id := range claimed {
+		r.rolledUp[id] = true
+	}
+	return hourly, daily, nil
+}
+
+// addToBucket merges count values with the given min, max and average into the bucket of key.
+func addToBucket(buckets map[rollupKey]*entities.MetricRollup, key rollupKey, count int64, min, max, avg float64) {
+	bucket, ok := buckets[key]
+	if !ok {
+		buckets[key] = &entities.MetricRollup{
+			KitID:       key.kitID,
+			Metric:      key.metric,
+			Granularity: key.granularity,
+			BucketStart: key.bucketStart,
+			SampleCount: count,
+			MinValue:    min,
+			MaxValue:    max,
+			AvgValue:    avg,
+		}
+		return
+	}
+	total := bucket.SampleCount + count
+	bucket.AvgValue = (bucket.AvgValue*float64(bucket.SampleCount) + avg*float64(count)) / float64(total)
+	bucket.SampleCount = total
+	bucket.MinValue = math.Min(bucket.MinValue, min)
+	bucket.MaxValue = math.Max(bucket.MaxValue, max)
+}
+
+// GetEarliestPendingTime implements ports.IGardenDataRollup
+func (r *GardenDataRollupRepositoryMemory) GetEarliestPendingTime(ctx context.Context) (time.Time, bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	r.data.mu.RLock()
+	defer r.data.mu.RUnlock()
+
+	var earliest time.Time
+	found := false
+	for _, record := range r.data.records {
+		if r.rolledUp[record.DataID] {
+			continue
+		}
+		if !found || record.Timestamp.Before(earliest) {
+			earliest, found = record.Timestamp, true
+		}
+	}
+	return earliest, found, nil
+}
+
+// PruneRawBefore implements ports.IGardenDataRollup
+func (r *GardenDataRollupRepositoryMemory) PruneRawBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.data.mu.Lock()
+	defer r.data.mu.Unlock()
+
+	var pruned int64
+	samples := r.data.samples[:0]
+	for _, sample := range r.data.samples {
+		if sample.Timestamp.Before(cutoff) && r.rolledUp[sample.DataID] {
+			pruned++
+			continue
+		}
+		samples = append(samples, sample)
+	}
+	r.data.samples = samples
+
+	records := r.data.records[:0]
+	for _, record := range r.data.records {
+		if record.Timestamp.Before(cutoff) && r.rolledUp[record.DataID] {
+			delete(r.rolledUp, record.DataID)
+			pruned++
+			continue
+		}
+		records = append(records, record)
+	}
+	r.data.records = records
+	return pruned, nil
+}
+
+// PruneRollupsBefore implements ports.IGardenDataRollup
+func (r *GardenDataRollupRepositoryMemory) PruneRollupsBefore(ctx context.Context, granularity string, cutoff time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var pruned int64
+	for key := range r.rollups {
+		if key.granularity == granularity && key.bucketStart.Before(cutoff) {
+			delete(r.rollups, key)
+			pruned++
+		}
+	}
+	return pruned, nil
+}
+
+// GetRollups implements ports.IGardenDataRollup
+func (r *GardenDataRollupRepositoryMemory) GetRollups(ctx context.Context, kitID int64, granularity string, from, to time.Time, metrics []string) ([]entities.MetricRollup, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	wanted := make(map[string]bool, len(metrics))
+	for _, metric := range metrics {
+		wanted[metric] = true
+	}
+
+	rollups := []entities.MetricRollup{}
+	for key, rollup := range r.rollups {
+		if key.kitID != kitID || key.granularity != granularity || !inRange(key.bucketStart, from, to) {
+			continue
+		}
+		if len(wanted) > 0 && !wanted[key.metric] {
+			continue
+		}
+		rollups = append(rollups, rollup)
+	}
+	sort.Slice(rollups, func(i, j int) bool {
+		if !rollups[i].BucketStart.Equal(rollups[j].BucketStart) {
+			return rollups[i].BucketStart.After(rollups[j].BucketStart)
+		}
+		return rollups[i].Metric < rollups[j].Metric
+	})
+	return rollups, nil
+}
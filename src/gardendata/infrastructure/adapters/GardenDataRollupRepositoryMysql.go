@@ -0,0 +1,236 @@
+package adapters
+
+import (
+	database "api-order/src/Database"
+	"api-order/src/gardendata/domain/entities"
+	"api-order/src/shared/logging"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// pruneBatchSize bounds the rows deleted per statement so pruning never holds long locks.
+const pruneBatchSize = 5000
+
+// garden_data.rollup_state tracks each reading through the compaction: it is
+// stored pending, claimed while its samples are merged into the rollups and
+// rolled up afterwards. Only rolled up readings are ever pruned.
+const (
+	claimPendingQuery = "UPDATE garden_data SET rollup_state = 1 WHERE rollup_state = 0 AND timestamp < ?"
+	markRolledUpQuery = "UPDATE garden_data SET rollup_state = 2 WHERE rollup_state = 1"
+	pendingTimeQuery  = "SELECT MIN(timestamp) FROM garden_data WHERE rollup_state = 0"
+	rolledUpCondition = "rollup_state = 2"
+	rolledUpSamples   = "EXISTS (SELECT 1 FROM garden_data d WHERE d.data_id = metric_samples.data_id AND d.rollup_state = 2)"
+)
+
+// claimedSamplesQuery selects the samples of the claimed readings as (kit_id,
+// metric_name, value, timestamp) rows. Readings stored before metric samples
+// existed only have the four fixed columns, so those are read as samples of
+// the built-in metrics.
+const claimedSamplesQuery = `
+            SELECT s.kit_id, s.metric_name, s.value, s.timestamp
+            FROM metric_samples s
+            JOIN garden_data d ON d.data_id = s.data_id
+            WHERE d.rollup_state = 1
+            UNION ALL
+            SELECT d.kit_id, m.metric_name,
+                   CASE m.metric_name
+                       WHEN 'temperature' THEN d.temperature
+                       WHEN 'ground_humidity' THEN d.ground_humidity
+                       WHEN 'environment_humidity' THEN d.environment_humidity
+                       ELSE d.ph_level
+                   END,
+                   d.timestamp
+            FROM garden_data d
+            CROSS JOIN (
+                SELECT 'temperature' AS metric_name UNION ALL SELECT 'ground_humidity'
+                UNION ALL SELECT 'environment_humidity' UNION ALL SELECT 'ph_level'
+            ) m
+            WHERE d.rollup_state = 1
+              AND NOT EXISTS (SELECT 1 FROM metric_samples s WHERE s.data_id = d.data_id)`
+
+type GardenDataRollupRepositoryMysql struct {
+	DB       *sql.DB
+	Timeouts database.Timeouts
+}
+
+// NewGardenDataRollupRepositoryMysql wraps the shared connection pool.
+func NewGardenDataRollupRepositoryMysql(db *sql.DB, timeouts database.Timeouts) *GardenDataRollupRepositoryMysql {
+	return &GardenDataRollupRepositoryMysql{DB: db, Timeouts: timeouts}
+}
+
+// RollupPending implements ports.IGardenDataRollup
+func (r *GardenDataRollupRepositoryMysql) RollupPending(ctx context.Context, before time.Time) (int64, int64, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpBatch)
+	defer cancel()
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error starting rollup transaction", "error", err)
+		return 0, 0, fmt.Errorf("database transaction error: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, claimPendingQuery, before); err != nil {
+		logging.FromContext(ctx).Error("Error claiming pending readings", "before", before, "error", err)
+		return 0, 0, fmt.Errorf("database execution error: %w", err)
+	}
+	hourly, err := r.mergeClaimed(ctx, tx, entities.GranularityHour, "DATE_FORMAT(timestamp, '%Y-%m-%d %H:00:00')")
+	if err != nil {
+		return 0, 0, err
+	}
+	daily, err := r.mergeClaimed(ctx, tx, entities.GranularityDay, "DATE(timestamp)")
+	if err != nil {
+		return 0, 0, err
+	}
+	if _, err := tx.ExecContext(ctx, markRolledUpQuery); err != nil {
+		logging.FromContext(ctx).Error("Error marking readings as rolled up", "error", err)
+		return 0, 0, fmt.Errorf("database execution error: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		logging.FromContext(ctx).Error("Error committing rollup transaction", "error", err)
+		return 0, 0, fmt.Errorf("database commit error: %w", err)
+	}
+	return hourly, daily, nil
+}
+
+// mergeClaimed adds the claimed samples to the buckets of a granularity.
+// avg_value is assigned first because MySQL assigns left to right, so the
+// later assignments already see the merged row.
+func (r *GardenDataRollupRepositoryMysql) mergeClaimed(ctx context.Context, tx *sql.Tx, granularity, bucket string) (int64, error) {
+	query := `
+        INSERT INTO metric_rollups
+        (kit_id, metric_name, granularity, bucket_start, sample_count, min_value, max_value, avg_value)
+        SELECT kit_id, metric_name, '` + granularity + `', ` + bucket + ` AS bucket,
+               COUNT(*), MIN(value), MAX(value), AVG(value)
+        FROM (` + claimedSamplesQuery + `
+        ) raw
+        GROUP BY kit_id, metric_name, bucket
+        ON DUPLICATE KEY UPDATE
+            avg_value = (avg_value * sample_count + VALUES(avg_value) * VALUES(sample_count)) / (sample_count + VALUES(sample_count)),
+            sample_count = sample_count + VALUES(sample_count),
+            min_value = LEAST(min_value, VALUES(min_value)),
+            max_value = GREATEST(max_value, VALUES(max_value))
+    `
+	result, err := tx.ExecContext(ctx, query)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error rolling up claimed readings", "granularity", granularity, "error", err)
+		return 0, fmt.Errorf("database execution error: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// GetEarliestPendingTime implements ports.IGardenDataRollup
+func (r *GardenDataRollupRepositoryMysql) GetEarliestPendingTime(ctx context.Context) (time.Time, bool, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	var earliest sql.NullTime
+	err := r.DB.QueryRowContext(ctx, pendingTimeQuery).Scan(&earliest)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("database query error: %w", err)
+	}
+	return earliest.Time, earliest.Valid, nil
+}
+
+// PruneRawBefore implements ports.IGardenDataRollup
+// Samples go first so a failure never leaves samples pointing at deleted records.
+func (r *GardenDataRollupRepositoryMysql) PruneRawBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpBatch)
+	defer cancel()
+
+	samples, err := r.deleteInBatches(ctx, "DELETE FROM metric_samples WHERE timestamp < ? AND "+rolledUpSamples+" LIMIT ?", cutoff)
+	if err != nil {
+		return samples, err
+	}
+	records, err := r.deleteInBatches(ctx, "DELETE FROM garden_data WHERE timestamp < ? AND "+rolledUpCondition+" LIMIT ?", cutoff)
+	return samples + records, err
+}
+
+// PruneRollupsBefore implements ports.IGardenDataRollup
+func (r *GardenDataRollupRepositoryMysql) PruneRollupsBefore(ctx context.Context, granularity string, cutoff time.Time) (int64, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpBatch)
+	defer cancel()
+
+	var total int64
+	for {
+		result, err := r.DB.ExecContext(ctx, "DELETE FROM metric_rollups WHERE granularity = ? AND bucket_start < ? LIMIT ?", granularity, cutoff, pruneBatchSize)
+		if err != nil {
+			logging.FromContext(ctx).Error("Error pruning rollups", "granularity", granularity, "cutoff", cutoff, "error", err)
+			return total, fmt.Errorf("database execution error: %w", err)
+		}
+		affected, _ := result.RowsAffected()
+		total += affected
+		if affected < pruneBatchSize {
+			return total, nil
+		}
+	}
+}
+
+func (r *GardenDataRollupRepositoryMysql) deleteInBatches(ctx context.Context, query string, cutoff time.Time) (int64, error) {
+	var total int64
+	for {
+		result, err := r.DB.ExecContext(ctx, query, cutoff, pruneBatchSize)
+		if err != nil {
+			logging.FromContext(ctx).Error("Error pruning raw data", "cutoff", cutoff, "error", err)
+			return total, fmt.Errorf("database execution error: %w", err)
+		}
+		affected, _ := result.RowsAffected()
+		total += affected
+		if affected < pruneBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// GetRollups implements ports.IGardenDataRollup
+func (r *GardenDataRollupRepositoryMysql) GetRollups(ctx context.Context, kitID int64, granularity string, from, to time.Time, metrics []string) ([]entities.MetricRollup, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	query := `
+        SELECT kit_id, metric_name, granularity, bucket_start, sample_count, min_value, max_value, avg_value
+        FROM metric_rollups
+        WHERE kit_id = ? AND granularity = ? AND bucket_start >= ? AND bucket_start < ?
+    `
+	args := []interface{}{kitID, granularity, from, to}
+	if len(metrics) > 0 {
+		query += " AND metric_name IN (?" + strings.Repeat(", ?", len(metrics)-1) + ")"
+		for _, metric := range metrics {
+			args = append(args, metric)
+		}
+	}
+	query += " ORDER BY bucket_start DESC, metric_name"
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error querying rollups for kit", "granularity", granularity, "kit_id", kitID, "error", err)
+		return nil, fmt.Errorf("database query error: %w", err)
+	}
+	defer rows.Close()
+
+	rollups := []entities.MetricRollup{}
+	for rows.Next() {
+		var rollup entities.MetricRollup
+		if err := rows.Scan(
+			&rollup.KitID,
+			&rollup.Metric,
+			&rollup.Granularity,
+			&rollup.BucketStart,
+			&rollup.SampleCount,
+			&rollup.MinValue,
+			&rollup.MaxValue,
+			&rollup.AvgValue,
+		); err != nil {
+			logging.FromContext(ctx).Error("Error scanning rollup row", "error", err)
+			return nil, fmt.Errorf("database scan error: %w", err)
+		}
+		rollups = append(rollups, rollup)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("database row iteration error: %w", err)
+	}
+	return rollups, nil
+}
@@ -0,0 +1,192 @@
+package adapters
+
+import (
+	database "api-order/src/Database"
+	"api-order/src/gardendata/domain/entities"
+	"api-order/src/shared/logging"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type GardenDataRollupRepositoryPostgres struct {
+	DB        *sql.DB
+	Timescale bool
+	Timeouts  database.Timeouts
+}
+
+// NewGardenDataRollupRepositoryPostgres wraps the shared connection pool.
+// timescale selects time_bucket over date_trunc for the buckets.
+func NewGardenDataRollupRepositoryPostgres(db *sql.DB, timescale bool, timeouts database.Timeouts) *GardenDataRollupRepositoryPostgres {
+	return &GardenDataRollupRepositoryPostgres{DB: db, Timescale: timescale, Timeouts: timeouts}
+}
+
+// RollupPending implements ports.IGardenDataRollup
+func (r *GardenDataRollupRepositoryPostgres) RollupPending(ctx context.Context, before time.Time) (int64, int64, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpBatch)
+	defer cancel()
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error starting rollup transaction", "error", err)
+		return 0, 0, fmt.Errorf("database transaction error: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, database.Rebind(claimPendingQuery), before); err != nil {
+		logging.FromContext(ctx).Error("Error claiming pending readings", "before", before, "error", err)
+		return 0, 0, fmt.Errorf("database execution error: %w", err)
+	}
+	hourly, err := r.mergeClaimed(ctx, tx, entities.GranularityHour, postgresBucket(r.Timescale, "hour", "timestamp"))
+	if err != nil {
+		return 0, 0, err
+	}
+	daily, err := r.mergeClaimed(ctx, tx, entities.GranularityDay, postgresBucket(r.Timescale, "day", "timestamp"))
+	if err != nil {
+		return 0, 0, err
+	}
+	if _, err := tx.ExecContext(ctx, markRolledUpQuery); err != nil {
+		logging.FromContext(ctx).Error("Error marking readings as rolled up", "error", err)
+		return 0, 0, fmt.Errorf("database execution error: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		logging.FromContext(ctx).Error("Error committing rollup transaction", "error", err)
+		return 0, 0, fmt.Errorf("database commit error: %w", err)
+	}
+	return hourly, daily, nil
+}
+
+// mergeClaimed adds the claimed samples to the buckets of a granularity.
+func (r *GardenDataRollupRepositoryPostgres) mergeClaimed(ctx context.Context, tx *sql.Tx, granularity, bucket string) (int64, error) {
+	query := `
+        INSERT INTO metric_rollups
+        (kit_id, metric_name, granularity, bucket_start, sample_count, min_value, max_value, avg_value)
+        SELECT kit_id, metric_name, '` + granularity + `', ` + bucket + ` AS bucket,
+               COUNT(*), MIN(value), MAX(value), AVG(value)
+        FROM (` + claimedSamplesQuery + `
+        ) raw
+        GROUP BY kit_id, metric_name, bucket
+        ON CONFLICT (kit_id, metric_name, granularity, bucket_start) DO UPDATE SET
+            sample_count = metric_rollups.sample_count + excluded.sample_count,
+            min_value = LEAST(metric_rollups.min_value, excluded.min_value),
+            max_value = GREATEST(metric_rollups.max_value, excluded.max_value),
+            avg_value = (metric_rollups.avg_value * metric_rollups.sample_count + excluded.avg_value * excluded.sample_count)
+                / (metric_rollups.sample_count + excluded.sample_count)
+    `
+	result, err := tx.ExecContext(ctx, query)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error rolling up claimed readings", "granularity", granularity, "error", err)
+		return 0, fmt.Errorf("database execution error: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// GetEarliestPendingTime implements ports.IGardenDataRollup
+func (r *GardenDataRollupRepositoryPostgres) GetEarliestPendingTime(ctx context.Context) (time.Time, bool, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	var earliest sql.NullTime
+	err := r.DB.QueryRowContext(ctx, pendingTimeQuery).Scan(&earliest)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("database query error: %w", err)
+	}
+	return earliest.Time, earliest.Valid, nil
+}
+
+// PruneRawBefore implements ports.IGardenDataRollup
+// Samples go first so a failure never leaves samples pointing at deleted records.
+func (r *GardenDataRollupRepositoryPostgres) PruneRawBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpBatch)
+	defer cancel()
+
+	samples, err := r.deleteInBatches(ctx, "metric_samples", "sample_id", "timestamp < ? AND "+rolledUpSamples, cutoff)
+	if err != nil {
+		return samples, err
+	}
+	records, err := r.deleteInBatches(ctx, "garden_data", "data_id", "timestamp < ? AND "+rolledUpCondition, cutoff)
+	return samples + records, err
+}
+
+// PruneRollupsBefore implements ports.IGardenDataRollup
+func (r *GardenDataRollupRepositoryPostgres) PruneRollupsBefore(ctx context.Context, granularity string, cutoff time.Time) (int64, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpBatch)
+	defer cancel()
+
+	// metric_rollups is never a hypertable, so its physical row IDs are unique
+	return r.deleteInBatches(ctx, "metric_rollups", "ctid", "granularity = ? AND bucket_start < ?", granularity, cutoff)
+}
+
+// deleteInBatches emulates DELETE ... LIMIT, which PostgreSQL does not have,
+// by deleting the keys of one batch at a time. The raw tables are keyed by
+// their IDs rather than ctid, which is only unique within a hypertable chunk.
+func (r *GardenDataRollupRepositoryPostgres) deleteInBatches(ctx context.Context, table, key, condition string, args ...interface{}) (int64, error) {
+	query := database.Rebind("DELETE FROM " + table + " WHERE " + key + " IN (SELECT " + key + " FROM " + table + " WHERE " + condition + " LIMIT ?)")
+	args = append(args, pruneBatchSize)
+
+	var total int64
+	for {
+		result, err := r.DB.ExecContext(ctx, query, args...)
+		if err != nil {
+			logging.FromContext(ctx).Error("Error pruning", "table", table, "error", err)
+			return total, fmt.Errorf("database execution error: %w", err)
+		}
+		affected, _ := result.RowsAffected()
+		total += affected
+		if affected < pruneBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// GetRollups implements ports.IGardenDataRollup
+func (r *GardenDataRollupRepositoryPostgres) GetRollups(ctx context.Context, kitID int64, granularity string, from, to time.Time, metrics []string) ([]entities.MetricRollup, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	query := `
+        SELECT kit_id, metric_name, granularity, bucket_start, sample_count, min_value, max_value, avg_value
+        FROM metric_rollups
+        WHERE kit_id = ? AND granularity = ? AND bucket_start >= ? AND bucket_start < ?
+    `
+	args := []interface{}{kitID, granularity, from, to}
+	if len(metrics) > 0 {
+		query += " AND metric_name IN (?" + strings.Repeat(", ?", len(metrics)-1) + ")"
+		for _, metric := range metrics {
+			args = append(args, metric)
+		}
+	}
+	query += " ORDER BY bucket_start DESC, metric_name"
+
+	rows, err := r.DB.QueryContext(ctx, database.Rebind(query), args...)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error querying rollups for kit", "granularity", granularity, "kit_id", kitID, "error", err)
+		return nil, fmt.Errorf("database query error: %w", err)
+	}
+	defer rows.Close()
+
+	rollups := []entities.MetricRollup{}
+	for rows.Next() {
+		var rollup entities.MetricRollup
+		if err := rows.Scan(
+			&rollup.KitID,
+			&rollup.Metric,
+			&rollup.Granularity,
+			&rollup.BucketStart,
+			&rollup.SampleCount,
+			&rollup.MinValue,
+			&rollup.MaxValue,
+			&rollup.AvgValue,
+		); err != nil {
+			logging.FromContext(ctx).Error("Error scanning rollup row", "error", err)
+			return nil, fmt.Errorf("database scan error: %w", err)
+		}
+		rollups = append(rollups, rollup)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("database row iteration error: %w", err)
+	}
+	return rollups, nil
+}
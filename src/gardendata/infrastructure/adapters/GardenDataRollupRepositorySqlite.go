@@ -0,0 +1,190 @@
+package adapters
+
+import (
+	database "api-order/src/Database"
+	"api-order/src/gardendata/domain/entities"
+	"api-order/src/shared/logging"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type GardenDataRollupRepositorySqlite struct {
+	DB       *sql.DB
+	Timeouts database.Timeouts
+}
+
+// NewGardenDataRollupRepositorySqlite wraps the shared connection pool.
+func NewGardenDataRollupRepositorySqlite(db *sql.DB, timeouts database.Timeouts) *GardenDataRollupRepositorySqlite {
+	return &GardenDataRollupRepositorySqlite{DB: db, Timeouts: timeouts}
+}
+
+// RollupPending implements ports.IGardenDataRollup
+func (r *GardenDataRollupRepositorySqlite) RollupPending(ctx context.Context, before time.Time) (int64, int64, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpBatch)
+	defer cancel()
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error starting rollup transaction", "error", err)
+		return 0, 0, fmt.Errorf("database transaction error: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, claimPendingQuery, database.SQLiteArgs(before)...); err != nil {
+		logging.FromContext(ctx).Error("Error claiming pending readings", "before", before, "error", err)
+		return 0, 0, fmt.Errorf("database execution error: %w", err)
+	}
+	hourly, err := r.mergeClaimed(ctx, tx, entities.GranularityHour, "strftime('%Y-%m-%d %H:00:00.000000', timestamp)")
+	if err != nil {
+		return 0, 0, err
+	}
+	daily, err := r.mergeClaimed(ctx, tx, entities.GranularityDay, "strftime('%Y-%m-%d 00:00:00.000000', timestamp)")
+	if err != nil {
+		return 0, 0, err
+	}
+	if _, err := tx.ExecContext(ctx, markRolledUpQuery); err != nil {
+		logging.FromContext(ctx).Error("Error marking readings as rolled up", "error", err)
+		return 0, 0, fmt.Errorf("database execution error: %w", err)
+	}
+	if err := tx.Commit(); err != nil {
+		logging.FromContext(ctx).Error("Error committing rollup transaction", "error", err)
+		return 0, 0, fmt.Errorf("database commit error: %w", err)
+	}
+	return hourly, daily, nil
+}
+
+// mergeClaimed adds the claimed samples to the buckets of a granularity.
+func (r *GardenDataRollupRepositorySqlite) mergeClaimed(ctx context.Context, tx *sql.Tx, granularity, bucket string) (int64, error) {
+	// The WHERE clause keeps SQLite from reading ON CONFLICT as a join constraint.
+	query := `
+        INSERT INTO metric_rollups
+        (kit_id, metric_name, granularity, bucket_start, sample_count, min_value, max_value, avg_value)
+        SELECT kit_id, metric_name, '` + granularity + `', ` + bucket + ` AS bucket,
+               COUNT(*), MIN(value), MAX(value), AVG(value)
+        FROM (` + claimedSamplesQuery + `
+        ) raw
+        WHERE true
+        GROUP BY kit_id, metric_name, bucket
+        ON CONFLICT (kit_id, metric_name, granularity, bucket_start) DO UPDATE SET
+            sample_count = metric_rollups.sample_count + excluded.sample_count,
+            min_value = MIN(metric_rollups.min_value, excluded.min_value),
+            max_value = MAX(metric_rollups.max_value, excluded.max_value),
+            avg_value = (metric_rollups.avg_value * metric_rollups.sample_count + excluded.avg_value * excluded.sample_count)
+                / (metric_rollups.sample_count + excluded.sample_count)
+    `
+	result, err := tx.ExecContext(ctx, query)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error rolling up claimed readings", "granularity", granularity, "error", err)
+		return 0, fmt.Errorf("database execution error: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// GetEarliestPendingTime implements ports.IGardenDataRollup
+func (r *GardenDataRollupRepositorySqlite) GetEarliestPendingTime(ctx context.Context) (time.Time, bool, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	var earliest time.Time
+	err := r.DB.QueryRowContext(ctx, pendingTimeQuery).Scan(database.SQLiteTime(&earliest))
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("database query error: %w", err)
+	}
+	return earliest, !earliest.IsZero(), nil
+}
+
+// PruneRawBefore implements ports.IGardenDataRollup
+// Samples go first so a failure never leaves samples pointing at deleted records.
+func (r *GardenDataRollupRepositorySqlite) PruneRawBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpBatch)
+	defer cancel()
+
+	samples, err := r.deleteInBatches(ctx, "metric_samples", "timestamp < ? AND "+rolledUpSamples, cutoff)
+	if err != nil {
+		return samples, err
+	}
+	records, err := r.deleteInBatches(ctx, "garden_data", "timestamp < ? AND "+rolledUpCondition, cutoff)
+	return samples + records, err
+}
+
+// PruneRollupsBefore implements ports.IGardenDataRollup
+func (r *GardenDataRollupRepositorySqlite) PruneRollupsBefore(ctx context.Context, granularity string, cutoff time.Time) (int64, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpBatch)
+	defer cancel()
+
+	return r.deleteInBatches(ctx, "metric_rollups", "granularity = ? AND bucket_start < ?", granularity, cutoff)
+}
+
+// deleteInBatches emulates DELETE ... LIMIT, which SQLite only supports when
+// compiled with SQLITE_ENABLE_UPDATE_DELETE_LIMIT.
+func (r *GardenDataRollupRepositorySqlite) deleteInBatches(ctx context.Context, table, condition string, args ...interface{}) (int64, error) {
+	query := "DELETE FROM " + table + " WHERE rowid IN (SELECT rowid FROM " + table + " WHERE " + condition + " LIMIT ?)"
+	args = database.SQLiteArgs(append(args, pruneBatchSize)...)
+
+	var total int64
+	for {
+		result, err := r.DB.ExecContext(ctx, query, args...)
+		if err != nil {
+			logging.FromContext(ctx).Error("Error pruning", "table", table, "error", err)
+			return total, fmt.Errorf("database execution error: %w", err)
+		}
+		affected, _ := result.RowsAffected()
+		total += affected
+		if affected < pruneBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// GetRollups implements ports.IGardenDataRollup
+func (r *GardenDataRollupRepositorySqlite) GetRollups(ctx context.Context, kitID int64, granularity string, from, to time.Time, metrics []string) ([]entities.MetricRollup, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	query := `
+        SELECT kit_id, metric_name, granularity, bucket_start, sample_count, min_value, max_value, avg_value
+        FROM metric_rollups
+        WHERE kit_id = ? AND granularity = ? AND bucket_start >= ? AND bucket_start < ?
+    `
+	args := []interface{}{kitID, granularity, from, to}
+	if len(metrics) > 0 {
+		query += " AND metric_name IN (?" + strings.Repeat(", ?", len(metrics)-1) + ")"
+		for _, metric := range metrics {
+			args = append(args, metric)
+		}
+	}
+	query += " ORDER BY bucket_start DESC, metric_name"
+
+	rows, err := r.DB.QueryContext(ctx, query, database.SQLiteArgs(args...)...)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error querying rollups for kit", "granularity", granularity, "kit_id", kitID, "error", err)
+		return nil, fmt.Errorf("database query error: %w", err)
+	}
+	defer rows.Close()
+
+	rollups := []entities.MetricRollup{}
+	for rows.Next() {
+		var rollup entities.MetricRollup
+		if err := rows.Scan(
+			&rollup.KitID,
+			&rollup.Metric,
+			&rollup.Granularity,
+			database.SQLiteTime(&rollup.BucketStart),
+			&rollup.SampleCount,
+			&rollup.MinValue,
+			&rollup.MaxValue,
+			&rollup.AvgValue,
+		); err != nil {
+			logging.FromContext(ctx).Error("Error scanning rollup row", "error", err)
+			return nil, fmt.Errorf("database scan error: %w", err)
+		}
+		rollups = append(rollups, rollup)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("database row iteration error: %w", err)
+	}
+	return rollups, nil
+}
@@ -0,0 +1,60 @@
+package adapters
+
+import (
+	"api-order/src/gardendata/domain/entities"
+	"api-order/src/gardendata/domain/ports"
+	"sync"
+	"time"
+)
+
+// ImportJobStoreMemory keeps import jobs in process memory.
+// Jobs are lost on restart, which is acceptable for progress polling.
+// Finished jobs, line errors included, are dropped once retention has passed,
+// so the store does not grow with every import; a zero retention keeps them.
+type ImportJobStoreMemory struct {
+	mu        sync.RWMutex
+	jobs      map[string]entities.ImportJob
+	retention time.Duration
+}
+
+func NewImportJobStoreMemory(retention time.Duration) *ImportJobStoreMemory {
+	return &ImportJobStoreMemory{jobs: make(map[string]entities.ImportJob), retention: retention}
+}
+
+// Save implements ports.IImportJobs
+func (s *ImportJobStoreMemory) Save(job entities.ImportJob) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Copy the errors slice so callers can keep mutating their job value.
+	job.Errors = append([]entities.ImportLineError(nil), job.Errors...)
+	s.jobs[job.ID] = job
+	if job.FinishedAt != nil {
+		s.evictExpired(time.Now())
+	}
+	return nil
+}
+
+// evictExpired drops the jobs that finished more than retention ago.
+func (s *ImportJobStoreMemory) evictExpired(now time.Time) {
+	if s.retention <= 0 {
+		return
+	}
+	for id, job := range s.jobs {
+		if job.FinishedAt != nil && now.Sub(*job.FinishedAt) > s.retention {
+			delete(s.jobs, id)
+		}
+	}
+}
+
+// Get implements ports.IImportJobs
+func (s *ImportJobStoreMemory) Get(id string) (entities.ImportJob, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	job, ok := s.jobs[id]
+	if !ok || s.retention > 0 && job.FinishedAt != nil && time.Since(*job.FinishedAt) > s.retention {
+		return entities.ImportJob{}, ports.ErrImportJobNotFound
+	}
+	return job, nil
+}
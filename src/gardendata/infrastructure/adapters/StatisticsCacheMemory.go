@@ -0,0 +1,60 @@
+package adapters
+
+import (
+	"api-order/src/gardendata/domain/entities"
+	"sync"
+	"time"
+)
+
+type statisticsCacheEntry struct {
+	stats     entities.KitStatistics
+	expiresAt time.Time
+}
+
+// StatisticsCacheMemory keeps kit statistics in process memory. Entries also
+// expire after ttl, so alerts and the moving end of the period are picked up
+// even when a kit stops sending readings.
+type StatisticsCacheMemory struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[int64]map[string]statisticsCacheEntry
+}
+
+func NewStatisticsCacheMemory(ttl time.Duration) *StatisticsCacheMemory {
+	return &StatisticsCacheMemory{ttl: ttl, entries: make(map[int64]map[string]statisticsCacheEntry)}
+}
+
+// Get implements ports.IStatisticsCache
+func (c *StatisticsCacheMemory) Get(kitID int64, key string) (entities.KitStatistics, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[kitID][key]
+	if !ok {
+		return entities.KitStatistics{}, false
+	}
+	if time.Now().After(entry.expiresAt) {
+		delete(c.entries[kitID], key)
+		return entities.KitStatistics{}, false
+	}
+	return entry.stats, true
+}
+
+// Set implements ports.IStatisticsCache
+func (c *StatisticsCacheMemory) Set(kitID int64, key string, stats entities.KitStatistics) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entries[kitID] == nil {
+		c.entries[kitID] = make(map[string]statisticsCacheEntry)
+	}
+	c.entries[kitID][key] = statisticsCacheEntry{stats: stats, expiresAt: time.Now().Add(c.ttl)}
+}
+
+// Invalidate implements ports.IStatisticsCache
+func (c *StatisticsCacheMemory) Invalidate(kitID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, kitID)
+}
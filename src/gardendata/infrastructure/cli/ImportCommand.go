@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"api-order/src/gardendata/application"
+	"api-order/src/gardendata/domain/entities"
+	"api-order/src/gardendata/domain/ports"
+	"api-order/src/gardendata/infrastructure/adapters"
+	"api-order/src/gardendata/infrastructure/importer"
+	metricPorts "api-order/src/metric/domain/ports"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// RunImportCommand implements `api import`, which loads a CSV of historical
+// readings into a kit synchronously and prints progress to stdout:
+//
+//	api import -kit 3 -file sdcard.csv -map time=ts,temperature=temp_c
+//
+// The repositories come from the caller so the import writes to whichever
+// storage backend is configured.
+func RunImportCommand(gardenDataRepository ports.IGardenData, metricRepository metricPorts.IMetric, args []string, stdout io.Writer) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	kitID := flags.Int64("kit", 0, "ID of the kit the readings belong to")
+	path := flags.String("file", "", "path of the CSV file to import")
+	mappingFlag := flags.String("map", "", "column mapping as field=column pairs (defaults to the header names)")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *kitID <= 0 || *path == "" {
+		flags.Usage()
+		return errors.New("-kit and -file are required")
+	}
+
+	mapping, err := importer.ParseMapping(*mappingFlag)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(*path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	rows, err := importer.ParseCSV(file, mapping)
+	if err != nil {
+		return err
+	}
+
+	register := application.NewRegisterGardenDataUseCase(gardenDataRepository, metricRepository, nil, nil, nil)
+	useCase := application.NewImportGardenDataUseCase(gardenDataRepository, nil, adapters.NewImportJobStoreMemory(0), nil, register)
+
+	job, err := useCase.RunSync(context.Background(), *kitID, rows, func(job entities.ImportJob) {
+		fmt.Fprintf(stdout, "\r%d/%d rows processed (%d inserted, %d duplicates, %d failed)",
+			job.ProcessedRows, job.TotalRows, job.InsertedRows, job.DuplicateRows, job.FailedRows)
+	})
+	fmt.Fprintln(stdout)
+	if err != nil {
+		return err
+	}
+
+	for _, lineError := range job.Errors {
+		fmt.Fprintf(stdout, "line %d: %s\n", lineError.Line, lineError.Message)
+	}
+	if job.FailedRows > len(job.Errors) {
+		fmt.Fprintf(stdout, "... %d more line errors not shown\n", job.FailedRows-len(job.Errors))
+	}
+	if job.Status == entities.ImportStatusFailed {
+		return errors.New(job.Failure)
+	}
+	return nil
+}
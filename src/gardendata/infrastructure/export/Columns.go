@@ -0,0 +1,106 @@
+package export
+
+import (
+	"api-order/src/gardendata/domain/entities"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Column is one exportable field of a garden data record.
+type Column struct {
+	Name string
+	// Numeric marks values that spreadsheet formats should store as numbers.
+	Numeric bool
+	value   func(record *entities.GardenData, loc *time.Location) interface{}
+}
+
+// Value returns the column value for a record, rendering times in loc, or nil
+// when the record has no value for the column.
+func (c Column) Value(record *entities.GardenData, loc *time.Location) interface{} {
+	return c.value(record, loc)
+}
+
+var allColumns = []Column{
+	{Name: "data_id", Numeric: true, value: func(r *entities.GardenData, _ *time.Location) interface{} { return r.DataID }},
+	{Name: "kit_id", Numeric: true, value: func(r *entities.GardenData, _ *time.Location) interface{} { return r.KitID }},
+	{Name: "temperature", Numeric: true, value: func(r *entities.GardenData, _ *time.Location) interface{} { return r.Temperature }},
+	{Name: "ground_humidity", Numeric: true, value: func(r *entities.GardenData, _ *time.Location) interface{} { return r.GroundHumidity }},
+	{Name: "environment_humidity", Numeric: true, value: func(r *entities.GardenData, _ *time.Location) interface{} { return r.EnvironmentHumidity }},
+	{Name: "ph_level", Numeric: true, value: func(r *entities.GardenData, _ *time.Location) interface{} { return r.PhLevel }},
+	{Name: "time", Numeric: true, value: func(r *entities.GardenData, _ *time.Location) interface{} { return r.Time }},
+	{Name: "device_time", value: func(r *entities.GardenData, loc *time.Location) interface{} {
+		return time.Unix(r.Time, 0).In(loc).Format(time.RFC3339)
+	}},
+	{Name: "timestamp", value: func(r *entities.GardenData, loc *time.Location) interface{} {
+		return r.Timestamp.In(loc).Format(time.RFC3339)
+	}},
+	{Name: "event_time", value: func(r *entities.GardenData, loc *time.Location) interface{} {
+		return r.EventTime.In(loc).Format(time.RFC3339)
+	}},
+	{Name: "clock_skew_seconds", Numeric: true, value: func(r *entities.GardenData, _ *time.Location) interface{} { return r.ClockSkewSeconds }},
+}
+
+// metricColumn exports the sample of a registry metric, or nothing for
+// records stored without it.
+func metricColumn(name string) Column {
+	return Column{Name: name, Numeric: true, value: func(r *entities.GardenData, _ *time.Location) interface{} {
+		for _, sample := range r.Samples {
+			if sample.Metric == name {
+				return sample.Value
+			}
+		}
+		return nil
+	}}
+}
+
+// availableColumns returns the fixed columns followed by one column per
+// metric that is not already a fixed column.
+func availableColumns(metrics []string) []Column {
+	columns := append([]Column{}, allColumns...)
+	for _, name := range metrics {
+		fixed := false
+		for _, column := range allColumns {
+			fixed = fixed || column.Name == name
+		}
+		if !fixed {
+			columns = append(columns, metricColumn(name))
+		}
+	}
+	return columns
+}
+
+// ColumnNames lists every column that can be requested for a kit declaring
+// metrics, in default order.
+func ColumnNames(metrics []string) []string {
+	columns := availableColumns(metrics)
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.Name
+	}
+	return names
+}
+
+// SelectColumns resolves a list of column names among the fixed columns and
+// the metrics a kit declares. An empty list selects every column.
+func SelectColumns(names []string, metrics []string) ([]Column, error) {
+	columns := availableColumns(metrics)
+	if len(names) == 0 {
+		return columns, nil
+	}
+
+	byName := make(map[string]Column, len(columns))
+	for _, column := range columns {
+		byName[column.Name] = column
+	}
+
+	selected := make([]Column, 0, len(names))
+	for _, name := range names {
+		column, ok := byName[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q (allowed: %s)", name, strings.Join(ColumnNames(metrics), ", "))
+		}
+		selected = append(selected, column)
+	}
+	return selected, nil
+}
@@ -0,0 +1,53 @@
+package export
+
+import (
+	"api-order/src/gardendata/domain/entities"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"time"
+)
+
+type csvWriter struct {
+	writer  *csv.Writer
+	columns []Column
+	loc     *time.Location
+	row     []string
+}
+
+func newCSVWriter(w io.Writer, columns []Column, loc *time.Location) (*csvWriter, error) {
+	writer := csv.NewWriter(w)
+
+	header := make([]string, len(columns))
+	for i, column := range columns {
+		header[i] = column.Name
+	}
+	if err := writer.Write(header); err != nil {
+		return nil, err
+	}
+
+	return &csvWriter{writer: writer, columns: columns, loc: loc, row: make([]string, len(columns))}, nil
+}
+
+func (c *csvWriter) ContentType() string { return "text/csv; charset=utf-8" }
+
+func (c *csvWriter) Extension() string { return FormatCSV }
+
+func (c *csvWriter) WriteRecord(record *entities.GardenData) error {
+	for i, column := range c.columns {
+		c.row[i] = ""
+		if value := column.Value(record, c.loc); value != nil {
+			c.row[i] = fmt.Sprint(value)
+		}
+	}
+	return c.writer.Write(c.row)
+}
+
+func (c *csvWriter) Flush() error {
+	c.writer.Flush()
+	return c.writer.Error()
+}
+
+func (c *csvWriter) Close() error {
+	return c.Flush()
+}
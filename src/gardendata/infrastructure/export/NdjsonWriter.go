@@ -0,0 +1,71 @@
+package export
+
+import (
+	"api-order/src/gardendata/domain/entities"
+	"bufio"
+	"encoding/json"
+	"io"
+	"time"
+)
+
+type ndjsonWriter struct {
+	buffer  *bufio.Writer
+	encoder *json.Encoder
+	columns []Column
+	loc     *time.Location
+}
+
+func newNDJSONWriter(w io.Writer, columns []Column, loc *time.Location) *ndjsonWriter {
+	buffer := bufio.NewWriter(w)
+	return &ndjsonWriter{buffer: buffer, encoder: json.NewEncoder(buffer), columns: columns, loc: loc}
+}
+
+func (n *ndjsonWriter) ContentType() string { return "application/x-ndjson" }
+
+func (n *ndjsonWriter) Extension() string { return FormatNDJSON }
+
+// WriteRecord writes one JSON object per line. Keys follow the column order.
+func (n *ndjsonWriter) WriteRecord(record *entities.GardenData) error {
+	line := make(orderedObject, len(n.columns))
+	for i, column := range n.columns {
+		line[i] = field{Key: column.Name, Value: column.Value(record, n.loc)}
+	}
+	return n.encoder.Encode(line)
+}
+
+func (n *ndjsonWriter) Flush() error {
+	return n.buffer.Flush()
+}
+
+func (n *ndjsonWriter) Close() error {
+	return n.Flush()
+}
+
+type field struct {
+	Key   string
+	Value interface{}
+}
+
+// orderedObject marshals as a JSON object preserving the key order.
+type orderedObject []field
+
+func (o orderedObject) MarshalJSON() ([]byte, error) {
+	out := []byte{'{'}
+	for i, f := range o {
+		if i > 0 {
+			out = append(out, ',')
+		}
+		key, err := json.Marshal(f.Key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(f.Value)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, key...)
+		out = append(out, ':')
+		out = append(out, value...)
+	}
+	return append(out, '}'), nil
+}
@@ -0,0 +1,40 @@
+package export
+
+import (
+	"api-order/src/gardendata/domain/entities"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Supported export formats
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+	FormatXLSX   = "xlsx"
+)
+
+// RecordWriter streams garden data records in a given file format.
+// WriteRecord may be called any number of times; Close must be called once at the end.
+type RecordWriter interface {
+	ContentType() string
+	Extension() string
+	WriteRecord(record *entities.GardenData) error
+	Flush() error
+	Close() error
+}
+
+// NewWriter creates a RecordWriter for format writing the selected columns to w,
+// with every time value rendered in loc.
+func NewWriter(format string, w io.Writer, columns []Column, loc *time.Location) (RecordWriter, error) {
+	switch format {
+	case FormatCSV, "":
+		return newCSVWriter(w, columns, loc)
+	case FormatNDJSON:
+		return newNDJSONWriter(w, columns, loc), nil
+	case FormatXLSX:
+		return newXLSXWriter(w, columns, loc)
+	default:
+		return nil, fmt.Errorf("unsupported export format %q (allowed: csv, ndjson, xlsx)", format)
+	}
+}
@@ -1,53 +1,151 @@
 package http
 
 import (
-	// Standard library imports if needed (e.g., "log")
-	"log"
-
+	alert "api-order/src/alert/domain/ports"
+	"api-order/src/config"
 	"api-order/src/gardendata/application" // Corrected paths
 	"api-order/src/gardendata/domain/ports"
 	"api-order/src/gardendata/infrastructure/adapters"
 	"api-order/src/gardendata/infrastructure/http/controllers"
+	"api-order/src/gardendata/infrastructure/jobs"
+	kit "api-order/src/kit/domain/ports"
+	metric "api-order/src/metric/domain/ports"
+	"api-order/src/shared/lifecycle"
+	user "api-order/src/user/domain/ports"
+	"time"
 )
 
-// Variables holding instances (consider central DI instead of package vars)
-var (
-	gardenDataRepository ports.IGardenData
+// importJobRetention is how long finished import jobs can still be polled.
+const importJobRetention = 24 * time.Hour
+
+// Repositories are the storage ports the GardenData feature depends on.
+type Repositories struct {
+	GardenData      ports.IGardenData
+	Rollups         ports.IGardenDataRollup
+	AnomalySettings ports.IAnomalySettings
+	Metrics         metric.IMetric
+	Alerts          alert.IAlert
+	Kits            kit.IKit
+	Users           user.IUser
+	// Statistics is shared with the metric module, which drops the statistics
+	// of a kit when its thresholds change
+	Statistics ports.IStatisticsCache
+}
+
+// Dependencies holds the GardenData use cases and background jobs. It is built
+// by the application container and handed to GardenDataRoutes.
+type Dependencies struct {
+	config config.GardenDataConfig
 	// Use cases
-	registerGardenDataUseCase   *application.RegisterGardenDataUseCase
-	getMinutesGardenDataUseCase *application.GetMinutesGardenDataUseCase
-)
+	registerGardenDataUseCase      *application.RegisterGardenDataUseCase
+	getMinutesGardenDataUseCase    *application.GetMinutesGardenDataUseCase
+	getMinutesMetricSamplesUseCase *application.GetMinutesMetricSamplesUseCase
+	exportGardenDataUseCase        *application.ExportGardenDataUseCase
+	importGardenDataUseCase        *application.ImportGardenDataUseCase
+	getImportJobUseCase            *application.GetImportJobUseCase
+	compactGardenDataUseCase       *application.CompactGardenDataUseCase
+	getKitStatisticsUseCase        *application.GetKitStatisticsUseCase
+	getAnomalySettingsUseCase      *application.GetAnomalySettingsUseCase
+	updateAnomalySettingsUseCase   *application.UpdateAnomalySettingsUseCase
+	alertDataGapsUseCase           *application.AlertDataGapsUseCase
+	getCompletenessReportUseCase   *application.GetCompletenessReportUseCase
+	// Background jobs
+	compactionScheduler *jobs.CompactionScheduler
+	gapAlertScheduler   *jobs.GapAlertScheduler
+	importRunner        *jobs.ImportRunner
+	runnerRegistered    bool
+}
 
-// Initialize dependencies for the GardenData feature
-// WARNING: init() functions in multiple packages can have unpredictable order
-// and make dependencies harder to manage. Prefer explicit initialization.
-func init() {
-	var err error
-	gardenDataRepository, err = adapters.NewGardenDataRepositoryMysql()
-	if err != nil {
-		// Use log.Fatalf to stop execution if critical dependencies fail
-		log.Fatalf("Error initializing GardenData repository: %v", err)
+// NewDependencies wires the GardenData use cases on top of the given repositories.
+func NewDependencies(repos Repositories, cfg config.GardenDataConfig) *Dependencies {
+	statisticsCache := repos.Statistics
+	if statisticsCache == nil {
+		statisticsCache = adapters.NewStatisticsCacheMemory(cfg.StatisticsCacheTTL)
 	}
+	d := &Dependencies{config: cfg}
 
 	// Initialize Use Cases
-	registerGardenDataUseCase = application.NewRegisterGardenDataUseCase(gardenDataRepository)
-	getMinutesGardenDataUseCase = application.NewGetMinutesGardenDataUseCase(gardenDataRepository)
+	alertLocales := application.NewAlertLocales(repos.Kits, repos.Users)
+	anomalyDetector := application.NewDetectAnomaliesUseCase(repos.AnomalySettings, adapters.NewAnomalyStateMemory(), repos.Metrics, repos.Alerts, alertLocales)
+	clockDrift := application.NewClockDriftTracker(repos.Kits, cfg.ClockSkewTolerance)
+	d.registerGardenDataUseCase = application.NewRegisterGardenDataUseCase(repos.GardenData, repos.Metrics, statisticsCache, anomalyDetector, clockDrift)
+	d.getMinutesGardenDataUseCase = application.NewGetMinutesGardenDataUseCase(repos.GardenData, repos.Rollups, cfg.Retention)
+	d.getMinutesMetricSamplesUseCase = application.NewGetMinutesMetricSamplesUseCase(repos.GardenData, repos.Rollups, cfg.Retention)
+	d.exportGardenDataUseCase = application.NewExportGardenDataUseCase(repos.GardenData, repos.Metrics, repos.Kits)
+
+	importJobs := adapters.NewImportJobStoreMemory(importJobRetention)
+	d.importRunner = jobs.NewImportRunner()
+	// Historical rows must not feed the live anomaly detector.
+	importRegisterUseCase := application.NewRegisterGardenDataUseCase(repos.GardenData, repos.Metrics, statisticsCache, nil, nil)
+	d.importGardenDataUseCase = application.NewImportGardenDataUseCase(repos.GardenData, repos.Kits, importJobs, d.importRunner, importRegisterUseCase)
+	d.getImportJobUseCase = application.NewGetImportJobUseCase(importJobs)
+	d.compactGardenDataUseCase = application.NewCompactGardenDataUseCase(repos.Rollups, cfg.Retention)
+	d.getKitStatisticsUseCase = application.NewGetKitStatisticsUseCase(repos.GardenData, repos.Metrics, repos.Alerts, repos.Kits, statisticsCache)
+	d.getAnomalySettingsUseCase = application.NewGetAnomalySettingsUseCase(repos.AnomalySettings, repos.Kits)
+	d.updateAnomalySettingsUseCase = application.NewUpdateAnomalySettingsUseCase(repos.AnomalySettings, repos.Kits)
+	d.getCompletenessReportUseCase = application.NewGetCompletenessReportUseCase(repos.GardenData, repos.Kits)
+	d.alertDataGapsUseCase = application.NewAlertDataGapsUseCase(repos.GardenData, repos.Alerts, alertLocales, statisticsCache, cfg.GapAlertAfter)
+	return d
+}
+
+// RegisterWorkers hands the background jobs to the lifecycle manager, which
+// starts them with the server and stops them on shutdown. The import runner
+// is stopped last, so imports get the most time to finish. The retention job
+// only runs when a raw retention is configured, the data gap watcher when
+// GAP_ALERT_AFTER is.
+func (d *Dependencies) RegisterWorkers(manager *lifecycle.Manager) {
+	if !d.runnerRegistered {
+		d.runnerRegistered = true
+		manager.Register("garden data imports", d.importRunner)
+	}
+	if d.config.Retention.Enabled() && d.compactionScheduler == nil {
+		d.compactionScheduler = jobs.NewCompactionScheduler(d.compactGardenDataUseCase, d.config.CompactionInterval)
+		manager.Register("garden data compaction", d.compactionScheduler)
+	}
+	if d.config.GapAlertAfter > 0 && d.gapAlertScheduler == nil {
+		d.gapAlertScheduler = jobs.NewGapAlertScheduler(d.alertDataGapsUseCase, d.config.GapCheckInterval)
+		manager.Register("data gap alerts", d.gapAlertScheduler)
+	}
 }
 
 // Setup functions for GardenData controllers
 
-func SetUpRegisterGardenDataController() *controllers.RegisterGardenDataController {
-	// Ensure use case is initialized
-	if registerGardenDataUseCase == nil {
-		log.Fatal("RegisterGardenDataUseCase not initialized") // Or handle error appropriately
-	}
-	return controllers.NewRegisterGardenDataController(registerGardenDataUseCase)
+func (d *Dependencies) SetUpRegisterGardenDataController() *controllers.RegisterGardenDataController {
+	return controllers.NewRegisterGardenDataController(d.registerGardenDataUseCase)
 }
 
-func SetUpGetMinutesGardenDataController() *controllers.GetMinutesGardenDataController {
-	// Ensure use case is initialized
-	if getMinutesGardenDataUseCase == nil {
-		log.Fatal("GetMinutesGardenDataUseCase not initialized") // Or handle error appropriately
-	}
-	return controllers.NewGetMinutesGardenDataController(getMinutesGardenDataUseCase)
+func (d *Dependencies) SetUpGetMinutesGardenDataController() *controllers.GetMinutesGardenDataController {
+	return controllers.NewGetMinutesGardenDataController(d.getMinutesGardenDataUseCase)
+}
+
+func (d *Dependencies) SetUpGetMinutesMetricSamplesController() *controllers.GetMinutesMetricSamplesController {
+	return controllers.NewGetMinutesMetricSamplesController(d.getMinutesMetricSamplesUseCase)
+}
+
+func (d *Dependencies) SetUpExportGardenDataController() *controllers.ExportGardenDataController {
+	return controllers.NewExportGardenDataController(d.exportGardenDataUseCase)
+}
+
+func (d *Dependencies) SetUpImportGardenDataController() *controllers.ImportGardenDataController {
+	return controllers.NewImportGardenDataController(d.importGardenDataUseCase)
+}
+
+func (d *Dependencies) SetUpGetImportJobController() *controllers.GetImportJobController {
+	return controllers.NewGetImportJobController(d.getImportJobUseCase)
+}
+
+func (d *Dependencies) SetUpGetKitStatisticsController() *controllers.GetKitStatisticsController {
+	return controllers.NewGetKitStatisticsController(d.getKitStatisticsUseCase)
+}
+
+func (d *Dependencies) SetUpGetAnomalySettingsController() *controllers.GetAnomalySettingsController {
+	return controllers.NewGetAnomalySettingsController(d.getAnomalySettingsUseCase)
+}
+
+func (d *Dependencies) SetUpUpdateAnomalySettingsController() *controllers.UpdateAnomalySettingsController {
+	return controllers.NewUpdateAnomalySettingsController(d.getAnomalySettingsUseCase, d.updateAnomalySettingsUseCase)
+}
+
+func (d *Dependencies) SetUpGetCompletenessReportController() *controllers.GetCompletenessReportController {
+	return controllers.NewGetCompletenessReportController(d.getCompletenessReportUseCase)
 }
@@ -0,0 +1,177 @@
+package controllers
+
+import (
+	"api-order/src/gardendata/application"
+	"api-order/src/gardendata/domain/entities"
+	"api-order/src/gardendata/infrastructure/export"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/logging"
+	"api-order/src/shared/middlewares"
+	"api-order/src/shared/preferences"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExportGardenDataController struct {
+	ExportUseCase *application.ExportGardenDataUseCase
+}
+
+func NewExportGardenDataController(useCase *application.ExportGardenDataUseCase) *ExportGardenDataController {
+	return &ExportGardenDataController{ExportUseCase: useCase}
+}
+
+// @Summary      Export Garden Data
+// @Description  Streams the sensor history of a kit for a time range as CSV, NDJSON or XLSX. Rows are read with a cursor, so large ranges are never loaded in memory at once. Temperatures are in the user's preferred unit. "from" and "to" accept RFC3339, YYYY-MM-DD (in the requested timezone) or unix seconds; the default range is the last 24 hours.
+// @Tags         GardenData
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        Authorization header string true "Bearer Token"
+// @Param        kit_id   path   int     true   "Kit ID" Format(int64)
+// @Param        from     query  string  false  "Start of the range (inclusive)"
+// @Param        to       query  string  false  "End of the range (exclusive)"
+// @Param        format   query  string  false  "Output format" Enums(csv, ndjson, xlsx) default(csv)
+// @Param        columns  query  string  false  "Comma separated columns: data_id, kit_id, temperature, ground_humidity, environment_humidity, ph_level, time, device_time, timestamp, event_time, clock_skew_seconds, and any other metric the kit declares"
+// @Param        tz       query  string  false  "IANA timezone used to render timestamps and parse dates; defaults to the user's timezone"
+// @Success      200  {file}    file  "Exported data"
+// @Failure      400  {object}  responses.Response "Invalid Kit ID, range, format, columns or timezone"
+// @Failure      401  {object}  responses.Response "Unauthorized - Invalid or missing token"
+// @Failure      403  {object}  responses.Response "Kit belongs to another user"
+// @Failure      404  {object}  responses.Response "Kit not found"
+// @Failure      500  {object}  responses.Response "Internal server error while exporting data"
+// @Failure      504  {object}  responses.Response "Operation timed out"
+// @Router       /v1/garden/data/kit/{kit_id}/export [get]
+// @Security     BearerAuth
+func (ctr *ExportGardenDataController) Run(ctx *gin.Context) {
+	kitID, err := strconv.ParseInt(ctx.Param("kit_id"), 10, 64)
+	if err != nil || kitID <= 0 {
+		ctx.Error(domainerrors.InvalidParameter("kit_id", "must be a positive integer"))
+		return
+	}
+
+	claimsData, exists := ctx.Get("datUser")
+	customClaims, ok := claimsData.(*middlewares.CustomClaims)
+	if !exists || !ok {
+		ctx.Error(middlewares.ErrMissingToken)
+		return
+	}
+
+	prefs := preferences.FromContext(ctx.Request.Context())
+	loc, err := time.LoadLocation(ctx.DefaultQuery("tz", prefs.Timezone))
+	if err != nil {
+		ctx.Error(domainerrors.InvalidParameter("tz", err.Error()))
+		return
+	}
+
+	to := time.Now()
+	if value := ctx.Query("to"); value != "" {
+		if to, err = parseExportTime(value, loc); err != nil {
+			ctx.Error(domainerrors.InvalidParameter("to", err.Error()))
+			return
+		}
+	}
+	from := to.Add(-24 * time.Hour)
+	if value := ctx.Query("from"); value != "" {
+		if from, err = parseExportTime(value, loc); err != nil {
+			ctx.Error(domainerrors.InvalidParameter("from", err.Error()))
+			return
+		}
+	}
+
+	metrics, err := ctr.ExportUseCase.KitMetrics(ctx.Request.Context(), kitID, customClaims.ClientID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	var columnNames []string
+	if value := ctx.Query("columns"); value != "" {
+		columnNames = strings.Split(value, ",")
+	}
+	columns, err := export.SelectColumns(columnNames, metrics)
+	if err != nil {
+		ctx.Error(domainerrors.InvalidParameter("columns", err.Error()))
+		return
+	}
+
+	format := strings.ToLower(ctx.DefaultQuery("format", export.FormatCSV))
+	if format != export.FormatCSV && format != export.FormatNDJSON && format != export.FormatXLSX {
+		ctx.Error(domainerrors.InvalidParameter("format", "must be one of csv, ndjson, xlsx"))
+		return
+	}
+
+	// The writer is created on the first batch so errors before any row is
+	// read can still be answered with a JSON error instead of a truncated file.
+	var writer export.RecordWriter
+	startStream := func() error {
+		if writer != nil {
+			return nil
+		}
+		var err error
+		writer, err = export.NewWriter(format, ctx.Writer, columns, loc)
+		if err != nil {
+			return err
+		}
+		filename := fmt.Sprintf("kit-%d-%s-%s.%s", kitID, from.In(loc).Format("20060102T150405"), to.In(loc).Format("20060102T150405"), writer.Extension())
+		ctx.Header("Content-Type", writer.ContentType())
+		ctx.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		ctx.Status(http.StatusOK)
+		return nil
+	}
+
+	err = ctr.ExportUseCase.Run(ctx.Request.Context(), kitID, customClaims.ClientID, from, to, func(batch []entities.GardenData) error {
+		if err := startStream(); err != nil {
+			return err
+		}
+		for i := range batch {
+			record := batch[i].RenderTemperatures(prefs)
+			if err := writer.WriteRecord(&record); err != nil {
+				return err
+			}
+		}
+		if err := writer.Flush(); err != nil {
+			return err
+		}
+		ctx.Writer.Flush()
+		return nil
+	})
+
+	if err != nil {
+		if writer != nil {
+			// Headers are already sent; the client gets a truncated file.
+			logging.FromContext(ctx.Request.Context()).Error("Error streaming export for kit", "kit_id", kitID, "error", err)
+			ctx.Abort()
+			return
+		}
+		ctx.Error(err)
+		return
+	}
+
+	// Empty ranges still produce a file with just the header.
+	if err := startStream(); err != nil {
+		logging.FromContext(ctx.Request.Context()).Error("Error starting export for kit", "kit_id", kitID, "error", err)
+		ctx.Abort()
+		return
+	}
+	if err := writer.Close(); err != nil {
+		logging.FromContext(ctx.Request.Context()).Error("Error finishing export for kit", "kit_id", kitID, "error", err)
+	}
+}
+
+// parseExportTime accepts RFC3339, a plain date in loc, or unix seconds.
+func parseExportTime(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, loc); err == nil {
+		return t, nil
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+	return time.Time{}, fmt.Errorf("cannot parse %q as RFC3339, YYYY-MM-DD or unix seconds", value)
+}
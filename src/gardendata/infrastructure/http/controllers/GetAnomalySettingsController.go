@@ -0,0 +1,64 @@
+package controllers
+
+import (
+	"api-order/src/gardendata/application"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/middlewares"
+	"api-order/src/shared/responses"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GetAnomalySettingsController struct {
+	GetUseCase *application.GetAnomalySettingsUseCase
+}
+
+func NewGetAnomalySettingsController(useCase *application.GetAnomalySettingsUseCase) *GetAnomalySettingsController {
+	return &GetAnomalySettingsController{GetUseCase: useCase}
+}
+
+// @Summary      Get Anomaly Detector Settings
+// @Description  Returns the anomaly detector tuning of a kit, or the defaults if it was never changed.
+// @Tags         GardenData
+// @Produce      json
+// @Param        Authorization header string true "Bearer Token"
+// @Param        kit_id  path  int  true  "Kit ID" Format(int64)
+// @Success      200  {object}  responses.Response{data=entities.AnomalySettings} "Settings retrieved successfully"
+// @Failure      400  {object}  responses.Response "Invalid Kit ID"
+// @Failure      401  {object}  responses.Response "Unauthorized - Invalid or missing token"
+// @Failure      403  {object}  responses.Response "Kit belongs to another user"
+// @Failure      404  {object}  responses.Response "Kit not found"
+// @Failure      500  {object}  responses.Response "Internal server error"
+// @Failure      504  {object}  responses.Response "Operation timed out"
+// @Router       /v1/garden/data/kit/{kit_id}/anomaly-settings [get]
+// @Security     BearerAuth
+func (ctr *GetAnomalySettingsController) Run(ctx *gin.Context) {
+	kitID, err := strconv.ParseInt(ctx.Param("kit_id"), 10, 64)
+	if err != nil || kitID <= 0 {
+		ctx.Error(domainerrors.InvalidParameter("kit_id", "must be a positive integer"))
+		return
+	}
+
+	claimsData, exists := ctx.Get("datUser")
+	customClaims, ok := claimsData.(*middlewares.CustomClaims)
+	if !exists || !ok {
+		ctx.Error(middlewares.ErrMissingToken)
+		return
+	}
+
+	settings, err := ctr.GetUseCase.Run(ctx.Request.Context(), kitID, customClaims.ClientID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, responses.Response{
+		Success: true,
+		Message: i18n.T(ctx.Request.Context(), "garden_data.anomaly_settings_retrieved"),
+		Data:    settings,
+		Error:   nil,
+	})
+}
@@ -0,0 +1,90 @@
+package controllers
+
+import (
+	"api-order/src/gardendata/application"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/middlewares"
+	"api-order/src/shared/preferences"
+	"api-order/src/shared/responses"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GetCompletenessReportController struct {
+	ReportUseCase *application.GetCompletenessReportUseCase
+}
+
+func NewGetCompletenessReportController(useCase *application.GetCompletenessReportUseCase) *GetCompletenessReportController {
+	return &GetCompletenessReportController{ReportUseCase: useCase}
+}
+
+// @Summary      Get Data Completeness Report
+// @Description  Compares the readings of a kit with its expected sampling interval: lists the missing intervals and the uptime percentage of every day. "from" and "to" accept RFC3339, YYYY-MM-DD (in the requested timezone) or unix seconds; the default range is the last 7 days and the maximum is 92 days.
+// @Tags         GardenData
+// @Produce      json
+// @Param        Authorization header string true "Bearer Token"
+// @Param        kit_id  path   int     true   "Kit ID" Format(int64)
+// @Param        from    query  string  false  "Start of the range (inclusive)"
+// @Param        to      query  string  false  "End of the range (exclusive)"
+// @Param        tz      query  string  false  "IANA timezone used to split days and parse dates; defaults to the user's timezone"
+// @Success      200  {object}  responses.Response{data=entities.CompletenessReport} "Report computed successfully"
+// @Failure      400  {object}  responses.Response "Invalid Kit ID, range or timezone"
+// @Failure      401  {object}  responses.Response "Unauthorized - Invalid or missing token"
+// @Failure      403  {object}  responses.Response "Kit belongs to another user"
+// @Failure      404  {object}  responses.Response "Kit not found"
+// @Failure      500  {object}  responses.Response "Internal server error while computing the report"
+// @Failure      504  {object}  responses.Response "Operation timed out"
+// @Router       /v1/garden/data/kit/{kit_id}/completeness [get]
+// @Security     BearerAuth
+func (ctr *GetCompletenessReportController) Run(ctx *gin.Context) {
+	kitID, err := strconv.ParseInt(ctx.Param("kit_id"), 10, 64)
+	if err != nil || kitID <= 0 {
+		ctx.Error(domainerrors.InvalidParameter("kit_id", "must be a positive integer"))
+		return
+	}
+
+	claimsData, exists := ctx.Get("datUser")
+	customClaims, ok := claimsData.(*middlewares.CustomClaims)
+	if !exists || !ok {
+		ctx.Error(middlewares.ErrMissingToken)
+		return
+	}
+
+	loc, err := time.LoadLocation(ctx.DefaultQuery("tz", preferences.FromContext(ctx.Request.Context()).Timezone))
+	if err != nil {
+		ctx.Error(domainerrors.InvalidParameter("tz", err.Error()))
+		return
+	}
+
+	to := time.Now()
+	if value := ctx.Query("to"); value != "" {
+		if to, err = parseExportTime(value, loc); err != nil {
+			ctx.Error(domainerrors.InvalidParameter("to", err.Error()))
+			return
+		}
+	}
+	from := to.AddDate(0, 0, -7)
+	if value := ctx.Query("from"); value != "" {
+		if from, err = parseExportTime(value, loc); err != nil {
+			ctx.Error(domainerrors.InvalidParameter("from", err.Error()))
+			return
+		}
+	}
+
+	report, err := ctr.ReportUseCase.Run(ctx.Request.Context(), kitID, customClaims.ClientID, from, to, loc)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, responses.Response{
+		Success: true,
+		Message: i18n.T(ctx.Request.Context(), "garden_data.completeness_retrieved"),
+		Data:    report,
+		Error:   nil,
+	})
+}
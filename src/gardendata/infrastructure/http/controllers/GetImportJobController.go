@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"api-order/src/gardendata/application"
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/middlewares"
+	"api-order/src/shared/responses"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GetImportJobController struct {
+	GetUseCase *application.GetImportJobUseCase
+}
+
+func NewGetImportJobController(useCase *application.GetImportJobUseCase) *GetImportJobController {
+	return &GetImportJobController{GetUseCase: useCase}
+}
+
+// @Summary      Get Import Job Progress
+// @Description  Returns the progress counters and per-line errors of a bulk import job started by the authenticated user.
+// @Tags         GardenData
+// @Produce      json
+// @Param        Authorization header string true "Bearer Token"
+// @Param        job_id  path  string  true  "Import job ID"
+// @Success      200  {object}  responses.Response{data=entities.ImportJob} "Import job retrieved successfully"
+// @Failure      401  {object}  responses.Response "Unauthorized - Invalid or missing token"
+// @Failure      404  {object}  responses.Response "Import job not found"
+// @Router       /v1/garden/data/import/jobs/{job_id} [get]
+// @Security     BearerAuth
+func (ctr *GetImportJobController) Run(ctx *gin.Context) {
+	claimsData, exists := ctx.Get("datUser")
+	customClaims, ok := claimsData.(*middlewares.CustomClaims)
+	if !exists || !ok {
+		ctx.Error(middlewares.ErrMissingToken)
+		return
+	}
+
+	job, err := ctr.GetUseCase.Run(ctx.Request.Context(), customClaims.ClientID, ctx.Param("job_id"))
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, responses.Response{
+		Success: true,
+		Message: i18n.T(ctx.Request.Context(), "garden_data.import_job_retrieved"),
+		Data:    job,
+		Error:   nil,
+	})
+}
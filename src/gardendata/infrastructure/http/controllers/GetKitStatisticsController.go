@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"api-order/src/gardendata/application"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/middlewares"
+	"api-order/src/shared/preferences"
+	"api-order/src/shared/responses"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GetKitStatisticsController struct {
+	StatisticsUseCase *application.GetKitStatisticsUseCase
+}
+
+func NewGetKitStatisticsController(useCase *application.GetKitStatisticsUseCase) *GetKitStatisticsController {
+	return &GetKitStatisticsController{StatisticsUseCase: useCase}
+}
+
+// @Summary      Get Kit Statistics
+// @Description  Summarises the readings of a kit for the current day, week (from Monday) or month: per metric min, max, average and standard deviation, the percentage of samples within the kit's thresholds, the hours with readings out of range, and the number of alerts raised. Results are cached until the kit sends new readings. Temperatures follow the user's preferred unit.
+// @Tags         GardenData
+// @Produce      json
+// @Param        Authorization header string true "Bearer Token"
+// @Param        kit_id  path   int     true   "Kit ID" Format(int64)
+// @Param        period  query  string  false  "Statistics period" Enums(day, week, month) default(day)
+// @Param        tz      query  string  false  "IANA timezone used to align the period; defaults to the user's timezone"
+// @Success      200  {object}  responses.Response{data=entities.KitStatistics} "Statistics computed successfully"
+// @Failure      400  {object}  responses.Response "Invalid Kit ID, period or timezone"
+// @Failure      401  {object}  responses.Response "Unauthorized - Invalid or missing token"
+// @Failure      403  {object}  responses.Response "Kit belongs to another user"
+// @Failure      404  {object}  responses.Response "Kit not found"
+// @Failure      500  {object}  responses.Response "Internal server error while computing statistics"
+// @Failure      504  {object}  responses.Response "Operation timed out"
+// @Router       /v1/garden/data/kit/{kit_id}/statistics [get]
+// @Security     BearerAuth
+func (ctr *GetKitStatisticsController) Run(ctx *gin.Context) {
+	kitID, err := strconv.ParseInt(ctx.Param("kit_id"), 10, 64)
+	if err != nil || kitID <= 0 {
+		ctx.Error(domainerrors.InvalidParameter("kit_id", "must be a positive integer"))
+		return
+	}
+
+	claimsData, exists := ctx.Get("datUser")
+	customClaims, ok := claimsData.(*middlewares.CustomClaims)
+	if !exists || !ok {
+		ctx.Error(middlewares.ErrMissingToken)
+		return
+	}
+
+	prefs := preferences.FromContext(ctx.Request.Context())
+	prefs.Timezone = ctx.DefaultQuery("tz", prefs.Timezone)
+	loc, err := time.LoadLocation(prefs.Timezone)
+	if err != nil {
+		ctx.Error(domainerrors.InvalidParameter("tz", err.Error()))
+		return
+	}
+
+	stats, err := ctr.StatisticsUseCase.Run(ctx.Request.Context(), kitID, customClaims.ClientID, ctx.DefaultQuery("period", "day"), loc)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, responses.Response{
+		Success: true,
+		Message: i18n.T(ctx.Request.Context(), "garden_data.statistics_retrieved"),
+		Data:    stats.Render(prefs),
+		Error:   nil,
+	})
+}
@@ -3,10 +3,11 @@ package controllers
 import (
 	"api-order/src/gardendata/application" // Corrected path
 	"api-order/src/gardendata/domain/entities"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/pagination"
+	"api-order/src/shared/preferences"
 	"api-order/src/shared/responses"
-	"database/sql"
-	"errors"
-	"fmt"
 	"net/http"
 	"strconv"
 
@@ -22,18 +23,22 @@ func NewGetMinutesGardenDataController(useCase *application.GetMinutesGardenData
 }
 
 // @Summary      Get Recent Garden Data
-// @Description  Retrieves garden sensor data records for a specific kit recorded within the last N minutes.
+// @Description  Retrieves garden sensor data records for a specific kit recorded within the last N minutes. GET /v1/garden/data/kit/{kit_id}/minutes uses the default dashboard range of the user instead. Temperatures and times follow the user's preferences.
 // @Tags         GardenData
 // @Produce      json
 // @Param        Authorization header string true "Bearer Token or API Key"
 // @Param        kit_id   path      int  true  "Kit ID" Format(int64)
 // @Param        minutes  path      int  true  "Number of past minutes to fetch data for"
+// @Param        limit    query     int     false  "Page size (max 5000); rollup records are appended to the last page" default(500)
+// @Param        cursor   query     string  false  "Opaque next_cursor of the previous page"
+// @Param        sort     query     string  false  "Column used for the window and descending order" Enums(-timestamp, -event_time) default(-timestamp)
 // @Success      200      {object}  responses.Response{data=[]entities.GardenDataResponse} "Data retrieved successfully"
-// @Failure      400      {object}  responses.Response "Invalid Kit ID or Minutes parameter"
+// @Failure      400      {object}  responses.Response "Invalid Kit ID, Minutes or pagination parameters"
 // @Failure      401      {object}  responses.Response "Unauthorized - Invalid or missing token/key"
 // @Failure      403      {object}  responses.Response "Forbidden - User does not have access to this Kit ID"
 // @Failure      404      {object}  responses.Response "Kit ID not found (if validation added)"
 // @Failure      500      {object}  responses.Response "Internal server error while retrieving data"
+// @Failure      504      {object}  responses.Response "Operation timed out"
 // @Router       /v1/garden/data/kit/{kit_id}/minutes/{minutes} [get]
 // @Security     BearerAuth // Or appropriate scheme
 func (ctr *GetMinutesGardenDataController) Run(ctx *gin.Context) {
@@ -41,19 +46,14 @@ func (ctr *GetMinutesGardenDataController) Run(ctx *gin.Context) {
 	kitIDParam := ctx.Param("kit_id")
 	kitID, err := strconv.ParseInt(kitIDParam, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, responses.Response{
-			Success: false, Message: "ID de kit inválido.", Error: err.Error(), Data: nil,
-		})
+		ctx.Error(domainerrors.InvalidParameter("kit_id", err.Error()))
 		return
 	}
 
-	// Parse Minutes from path
-	minutesParam := ctx.Param("minutes")
-	minutes, err := strconv.Atoi(minutesParam)
-	if err != nil || minutes <= 0 { // Also check if minutes is positive
-		ctx.JSON(http.StatusBadRequest, responses.Response{
-			Success: false, Message: "Parámetro de minutos inválido (debe ser un entero positivo).", Error: err.Error(), Data: nil,
-		})
+	// Parse Minutes from path, or use the dashboard range of the user
+	prefs := preferences.FromContext(ctx.Request.Context())
+	minutes, ok := recentMinutes(ctx, prefs)
+	if !ok {
 		return
 	}
 
@@ -64,39 +64,52 @@ func (ctr *GetMinutesGardenDataController) Run(ctx *gin.Context) {
 	// hasAccess := checkUserKitAccess(userIDFromToken, kitID) // Implement this logic
 	// if !hasAccess { ctx.JSON(http.StatusForbidden, ...); return }
 
+	params, err := pagination.ParseCursor(ctx.Request.URL.Query(), application.GardenDataPageSpec)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
 	// Execute the use case
-	records, err := ctr.GetUseCase.Run(kitID, minutes)
+	records, page, err := ctr.GetUseCase.Run(ctx.Request.Context(), kitID, minutes, params)
 
 	// Handle errors from use case
 	if err != nil {
-		// Log the internal error
-		// log.Printf("Error getting garden data for kit %d (%d min): %v", kitID, minutes, err)
-
-		// Check for specific errors like "not found" if the repository/use case signals it
-		if errors.Is(err, sql.ErrNoRows) { // Or a custom "NotFound" error
-			ctx.JSON(http.StatusNotFound, responses.Response{
-				Success: false, Message: "No se encontraron datos para el kit especificado o el kit no existe.", Error: "Not found", Data: nil,
-			})
-		} else {
-			// Generic internal server error
-			ctx.JSON(http.StatusInternalServerError, responses.Response{
-				Success: false, Message: "Error al obtener los datos del jardín.", Error: "Internal server error", Data: nil,
-			})
-		}
+		ctx.Error(err)
 		return
 	}
 
 	// Convert records to response format (if different, though here it's the same)
 	responseRecords := make([]entities.GardenDataResponse, len(records))
 	for i, rec := range records {
-		responseRecords[i] = rec.ToResponse()
+		responseRecords[i] = rec.ToResponse().Render(prefs)
 	}
 
 	// Return success response (even if the list is empty)
 	ctx.JSON(http.StatusOK, responses.Response{
-		Success: true,
-		Message: fmt.Sprintf("Datos de los últimos %d minutos obtenidos correctamente.", minutes),
-		Data:    responseRecords, // Send the slice of response objects
-		Error:   nil,
+		Success:    true,
+		Message:    i18n.T(ctx.Request.Context(), "garden_data.retrieved", minutes),
+		Data:       responseRecords, // Send the slice of response objects
+		Error:      nil,
+		Pagination: page,
 	})
 }
+
+// recentMinutes returns the minutes path parameter, or the dashboard range of
+// the user on the routes without it. An invalid value is reported with
+// ctx.Error.
+func recentMinutes(ctx *gin.Context, prefs preferences.Preferences) (int, bool) {
+	minutesParam := ctx.Param("minutes")
+	if minutesParam == "" {
+		if prefs.DashboardRangeMinutes > 0 {
+			return prefs.DashboardRangeMinutes, true
+		}
+		return preferences.DefaultDashboardRangeMinutes, true
+	}
+	minutes, err := strconv.Atoi(minutesParam)
+	if err != nil || minutes <= 0 { // Also check if minutes is positive
+		ctx.Error(domainerrors.InvalidParameter("minutes", "must be a positive integer"))
+		return 0, false
+	}
+	return minutes, true
+}
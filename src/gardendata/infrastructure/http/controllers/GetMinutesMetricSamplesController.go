@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"api-order/src/gardendata/application"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/pagination"
+	"api-order/src/shared/preferences"
+	"api-order/src/shared/responses"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GetMinutesMetricSamplesController struct {
+	GetUseCase *application.GetMinutesMetricSamplesUseCase
+}
+
+func NewGetMinutesMetricSamplesController(useCase *application.GetMinutesMetricSamplesUseCase) *GetMinutesMetricSamplesController {
+	return &GetMinutesMetricSamplesController{GetUseCase: useCase}
+}
+
+// @Summary      Get Recent Metric Samples
+// @Description  Retrieves typed metric samples for a kit recorded within the last N minutes. Filter with one or more "metric" query parameters. GET /v1/garden/data/kit/{kit_id}/samples/minutes uses the default dashboard range of the user instead. Temperatures and times follow the user's preferences.
+// @Tags         GardenData
+// @Produce      json
+// @Param        Authorization header string true "Bearer Token or API Key"
+// @Param        kit_id   path      int     true   "Kit ID" Format(int64)
+// @Param        minutes  path      int     true   "Number of past minutes to fetch data for"
+// @Param        metric   query     []string false "Metric names to include (repeat or comma separate)" collectionFormat(multi)
+// @Param        limit    query     int     false  "Page size (max 10000); rollup samples are appended to the last page" default(1000)
+// @Param        cursor   query     string  false  "Opaque next_cursor of the previous page"
+// @Param        sort     query     string  false  "Column used for the window and descending order" Enums(-timestamp, -event_time) default(-timestamp)
+// @Success      200      {object}  responses.Response{data=[]entities.MetricSample} "Samples retrieved successfully"
+// @Failure      400      {object}  responses.Response "Invalid Kit ID, Minutes or pagination parameters"
+// @Failure      401      {object}  responses.Response "Unauthorized - Invalid or missing token/key"
+// @Failure      500      {object}  responses.Response "Internal server error while retrieving data"
+// @Failure      504      {object}  responses.Response "Operation timed out"
+// @Router       /v1/garden/data/kit/{kit_id}/samples/minutes/{minutes} [get]
+// @Security     BearerAuth
+func (ctr *GetMinutesMetricSamplesController) Run(ctx *gin.Context) {
+	kitID, err := strconv.ParseInt(ctx.Param("kit_id"), 10, 64)
+	if err != nil || kitID <= 0 {
+		ctx.Error(domainerrors.InvalidParameter("kit_id", "must be a positive integer"))
+		return
+	}
+
+	prefs := preferences.FromContext(ctx.Request.Context())
+	minutes, ok := recentMinutes(ctx, prefs)
+	if !ok {
+		return
+	}
+
+	var metrics []string
+	for _, value := range ctx.QueryArray("metric") {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				metrics = append(metrics, name)
+			}
+		}
+	}
+
+	params, err := pagination.ParseCursor(ctx.Request.URL.Query(), application.MetricSamplesPageSpec)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	samples, page, err := ctr.GetUseCase.Run(ctx.Request.Context(), kitID, minutes, metrics, params)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	for i := range samples {
+		samples[i] = samples[i].Render(prefs)
+	}
+	ctx.JSON(http.StatusOK, responses.Response{
+		Success:    true,
+		Message:    i18n.T(ctx.Request.Context(), "garden_data.samples_retrieved", minutes),
+		Data:       samples,
+		Error:      nil,
+		Pagination: page,
+	})
+}
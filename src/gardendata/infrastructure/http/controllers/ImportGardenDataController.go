@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"api-order/src/gardendata/application"
+	"api-order/src/gardendata/infrastructure/importer"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/middlewares"
+	"api-order/src/shared/responses"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxImportFileSize limits the size of uploaded CSV files (50 MB).
+const maxImportFileSize = 50 << 20
+
+type ImportGardenDataController struct {
+	ImportUseCase *application.ImportGardenDataUseCase
+}
+
+func NewImportGardenDataController(useCase *application.ImportGardenDataUseCase) *ImportGardenDataController {
+	return &ImportGardenDataController{ImportUseCase: useCase}
+}
+
+// @Summary      Import Historical Garden Data
+// @Description  Uploads a CSV file of historical readings (e.g. recovered from a kit's SD card). The file is parsed immediately and imported in the background; poll the returned job for progress. Rows whose device time already exists for the kit are skipped as duplicates.
+// @Tags         GardenData
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        Authorization header string true "Bearer Token"
+// @Param        kit_id   path      int     true   "Kit ID" Format(int64)
+// @Param        file     formData  file    true   "CSV file with a header row"
+// @Param        mapping  formData  string  false  "Column mapping as field=column pairs, e.g. time=ts,temperature=temp_c. Defaults to using the header names."
+// @Success      202  {object}  responses.Response{data=entities.ImportJob} "Import job accepted"
+// @Failure      400  {object}  responses.Response "Invalid Kit ID, file or mapping"
+// @Failure      401  {object}  responses.Response "Unauthorized - Invalid or missing token"
+// @Failure      403  {object}  responses.Response "Kit belongs to another user"
+// @Failure      404  {object}  responses.Response "Kit not found"
+// @Failure      500  {object}  responses.Response "Internal server error while starting the import"
+// @Failure      504  {object}  responses.Response "Operation timed out"
+// @Router       /v1/garden/data/kit/{kit_id}/import [post]
+// @Security     BearerAuth
+func (ctr *ImportGardenDataController) Run(ctx *gin.Context) {
+	kitID, err := strconv.ParseInt(ctx.Param("kit_id"), 10, 64)
+	if err != nil || kitID <= 0 {
+		ctx.Error(domainerrors.InvalidParameter("kit_id", "must be a positive integer"))
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImportFileSize)
+	fileHeader, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.Error(domainerrors.InvalidParameter("file", err.Error()))
+		return
+	}
+
+	mapping, err := importer.ParseMapping(ctx.PostForm("mapping"))
+	if err != nil {
+		ctx.Error(domainerrors.InvalidParameter("mapping", err.Error()))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.Error(domainerrors.InvalidParameter("file", err.Error()))
+		return
+	}
+	defer file.Close()
+
+	rows, err := importer.ParseCSV(file, mapping)
+	if err != nil {
+		ctx.Error(domainerrors.InvalidParameter("file", err.Error()))
+		return
+	}
+
+	claimsData, exists := ctx.Get("datUser")
+	customClaims, ok := claimsData.(*middlewares.CustomClaims)
+	if !exists || !ok {
+		ctx.Error(middlewares.ErrMissingToken)
+		return
+	}
+
+	job, err := ctr.ImportUseCase.Start(ctx.Request.Context(), kitID, customClaims.ClientID, rows)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, responses.Response{
+		Success: true,
+		Message: i18n.T(ctx.Request.Context(), "garden_data.import_started"),
+		Data:    job,
+		Error:   nil,
+	})
+}
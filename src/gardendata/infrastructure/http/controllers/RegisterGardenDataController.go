@@ -3,100 +3,81 @@ package controllers
 import (
 	"api-order/src/gardendata/application"                 // Corrected path
 	"api-order/src/gardendata/infrastructure/http/request" // Corrected path
+	kit "api-order/src/kit/domain/ports"
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/logging"
+	"api-order/src/shared/monitoring"
 	"api-order/src/shared/responses"
-	"fmt"
+	"api-order/src/shared/validation"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
 )
 
 type RegisterGardenDataController struct {
 	RegisterUseCase *application.RegisterGardenDataUseCase
-	Validator       *validator.Validate
 }
 
 func NewRegisterGardenDataController(useCase *application.RegisterGardenDataUseCase) *RegisterGardenDataController {
 	return &RegisterGardenDataController{
 		RegisterUseCase: useCase,
-		Validator:       validator.New(),
 	}
 }
 
 // @Summary      Register Garden Sensor Data
-// @Description  Receives and stores a new set of sensor readings for a specific kit.
+// @Description  Receives and stores a new set of sensor readings for a specific kit. Besides the four fixed fields, any registered metric can be sent in "metrics".
 // @Tags         GardenData
 // @Accept       json
 // @Produce      json
 // @Param        data body request.RegisterGardenDataRequest true "Sensor Data Payload"
 // @Success      201  {object}  responses.Response{data=entities.GardenDataResponse} "Data registered successfully"
-// @Failure      400  {object}  responses.Response "Invalid request body or validation failed"
+// @Failure      400  {object}  responses.Response "Invalid request body, validation failed, or unknown/out of range metric"
 // @Failure      401  {object}  responses.Response "Unauthorized - Invalid or missing token/key"
-// @Failure      404  {object}  responses.Response "Kit ID not found (if validation added)"
+// @Failure      404  {object}  responses.Response "Kit ID not found"
 // @Failure      500  {object}  responses.Response "Internal server error during registration"
+// @Failure      504  {object}  responses.Response "Operation timed out"
 // @Router       /v1/garden/data/ [post]
 func (ctr *RegisterGardenDataController) Run(ctx *gin.Context) {
 	var req request.RegisterGardenDataRequest
 
-	// Bind JSON body
-	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, responses.Response{
-			Success: false,
-			Message: "Error procesando la solicitud. Verifique el formato JSON.",
-			Data:    nil,
-			Error:   err.Error(),
-		})
+	// Bind and validate the JSON body
+	if err := validation.BindJSON(ctx, &req); err != nil {
+		logging.FromContext(ctx.Request.Context()).Warn("Validation error for RegisterGardenDataRequest", "error", err)
+		monitoring.IngestionRejections.WithLabelValues(monitoring.ReasonInvalidRequest).Inc()
+		ctx.Error(err)
 		return
 	}
 
-	// Validate struct fields based on tags
-	if err := ctr.Validator.Struct(req); err != nil {
-		// Log validation errors if needed for debugging
-		fmt.Printf("Validation error for RegisterGardenDataRequest: %v\n", err)
-		ctx.JSON(http.StatusBadRequest, responses.Response{
-			Success: false,
-			Message: "Datos inválidos proporcionados.",
-			Data:    nil,
-			Error:   err.Error(), // Consider formatting validation errors
-		})
-		return
-	}
-
-	// Optional: Validate KitID existence against the `kits` table or user's associated kits
-	// This would likely require injecting a KitRepository or UserService here or in the use case.
-	// For now, we assume the kit_id is valid if the DB insert doesn't fail on foreign key.
-
-	// Execute the use case
-	createdRecord, err := ctr.RegisterUseCase.Run(
-		req.KitID,
-		req.Temperature,
-		req.GroundHumidity,
-		req.EnvironmentHumidity,
-		req.PhLevel,
-		req.Time,
-	)
+	// Every log line of the ingestion carries the kit
+	ctx.Request = ctx.Request.WithContext(logging.With(ctx.Request.Context(), "kit_id", req.KitID))
 
+	// Execute the use case. A kit_id without a kit fails on the foreign key
+	// and comes back as kit_not_found.
+	createdRecord, err := ctr.RegisterUseCase.Run(ctx.Request.Context(), req.KitID, req.Readings(), req.Time)
 	if err != nil {
-		// Log the error for internal monitoring
-		// log.Printf("Error registering garden data via controller: %v", err)
-
-		// Check for specific errors if the use case or repo provides them
-		// Example: if errors.Is(err, someSpecificError) { ... }
-
-		// Return a generic server error to the client
-		ctx.JSON(http.StatusInternalServerError, responses.Response{
-			Success: false,
-			Message: "Error al registrar los datos del jardín.",
-			Data:    nil,
-			Error:   "Internal server error", // Avoid exposing internal error details like err.Error() directly
-		})
+		switch {
+		case responses.IsTimeout(err):
+			monitoring.IngestionRejections.WithLabelValues(monitoring.ReasonTimeout).Inc()
+		// Readings that do not match the metric registry are client errors
+		case errors.Is(err, application.ErrNoReadings),
+			errors.Is(err, application.ErrUnknownMetric),
+			errors.Is(err, application.ErrMetricOutOfRange),
+			errors.Is(err, application.ErrUndeclaredSensor):
+			monitoring.IngestionRejections.WithLabelValues(monitoring.ReasonInvalidReadings).Inc()
+		case errors.Is(err, kit.ErrKitNotFound):
+			monitoring.IngestionRejections.WithLabelValues(monitoring.ReasonInvalidRequest).Inc()
+		default:
+			monitoring.IngestionRejections.WithLabelValues(monitoring.ReasonInternalError).Inc()
+		}
+		ctx.Error(err)
 		return
 	}
 
 	// Return success response
 	ctx.JSON(http.StatusCreated, responses.Response{
 		Success: true,
-		Message: "Datos del jardín registrados correctamente.",
+		Message: i18n.T(ctx.Request.Context(), "garden_data.registered"),
 		Data:    createdRecord.ToResponse(), // Use the response converter
 		Error:   nil,
 	})
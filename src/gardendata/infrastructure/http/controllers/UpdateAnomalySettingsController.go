@@ -0,0 +1,96 @@
+package controllers
+
+import (
+	"api-order/src/gardendata/application"
+	"api-order/src/gardendata/infrastructure/http/request"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/middlewares"
+	"api-order/src/shared/responses"
+	"api-order/src/shared/validation"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UpdateAnomalySettingsController struct {
+	GetUseCase    *application.GetAnomalySettingsUseCase
+	UpdateUseCase *application.UpdateAnomalySettingsUseCase
+}
+
+func NewUpdateAnomalySettingsController(getUseCase *application.GetAnomalySettingsUseCase, updateUseCase *application.UpdateAnomalySettingsUseCase) *UpdateAnomalySettingsController {
+	return &UpdateAnomalySettingsController{
+		GetUseCase:    getUseCase,
+		UpdateUseCase: updateUseCase,
+	}
+}
+
+// @Summary      Update Anomaly Detector Settings
+// @Description  Tunes the sensitivity of the anomaly detector for a kit. Lower z_score_threshold and spike_fraction values, or a shorter flatline_minutes, raise more alerts; 0 disables a check. Omitted fields keep their current value.
+// @Tags         GardenData
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer Token"
+// @Param        kit_id    path  int  true  "Kit ID" Format(int64)
+// @Param        settings  body  request.UpdateAnomalySettingsRequest true "Detector settings"
+// @Success      200  {object}  responses.Response{data=entities.AnomalySettings} "Settings updated successfully"
+// @Failure      400  {object}  responses.Response "Invalid Kit ID or settings"
+// @Failure      401  {object}  responses.Response "Unauthorized - Invalid or missing token"
+// @Failure      403  {object}  responses.Response "Kit belongs to another user"
+// @Failure      404  {object}  responses.Response "Kit not found"
+// @Failure      500  {object}  responses.Response "Internal server error"
+// @Failure      504  {object}  responses.Response "Operation timed out"
+// @Router       /v1/garden/data/kit/{kit_id}/anomaly-settings [put]
+// @Security     BearerAuth
+func (ctr *UpdateAnomalySettingsController) Run(ctx *gin.Context) {
+	kitID, err := strconv.ParseInt(ctx.Param("kit_id"), 10, 64)
+	if err != nil || kitID <= 0 {
+		ctx.Error(domainerrors.InvalidParameter("kit_id", "must be a positive integer"))
+		return
+	}
+
+	claimsData, exists := ctx.Get("datUser")
+	customClaims, ok := claimsData.(*middlewares.CustomClaims)
+	if !exists || !ok {
+		ctx.Error(middlewares.ErrMissingToken)
+		return
+	}
+
+	var req request.UpdateAnomalySettingsRequest
+	if err := validation.BindJSON(ctx, &req); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	settings, err := ctr.GetUseCase.Run(ctx.Request.Context(), kitID, customClaims.ClientID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+	if req.Enabled != nil {
+		settings.Enabled = *req.Enabled
+	}
+	if req.ZScoreThreshold != nil {
+		settings.ZScoreThreshold = *req.ZScoreThreshold
+	}
+	if req.SpikeFraction != nil {
+		settings.SpikeFraction = *req.SpikeFraction
+	}
+	if req.FlatlineMinutes != nil {
+		settings.FlatlineMinutes = *req.FlatlineMinutes
+	}
+
+	updated, err := ctr.UpdateUseCase.Run(ctx.Request.Context(), customClaims.ClientID, settings)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, responses.Response{
+		Success: true,
+		Message: i18n.T(ctx.Request.Context(), "garden_data.anomaly_settings_updated"),
+		Data:    updated,
+		Error:   nil,
+	})
+}
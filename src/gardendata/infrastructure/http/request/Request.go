@@ -1,14 +1,47 @@
 package request
 
 // RegisterGardenDataRequest defines the expected JSON body for registering new data.
+// The four fixed fields are kept for existing firmware; any other registered
+// metric goes in Metrics, e.g. {"metrics": {"co2": 412, "light": 830}}.
 type RegisterGardenDataRequest struct {
-	KitID               int64   `json:"kit_id" validate:"required,gt=0"` // Ensure KitID is positive
-	Temperature         float64 `json:"temperature"`                     // Add validation tags if needed (e.g., min/max)
-	GroundHumidity      float64 `json:"ground_humidity"`
-	EnvironmentHumidity float64 `json:"environment_humidity"` // Corrected spelling
-	PhLevel             float64 `json:"ph_level"`
-	Time                int64   `json:"time" validate:"required"` // Require the device timestamp
+	KitID               int64              `json:"kit_id" validate:"required,gt=0"` // Ensure KitID is positive
+	Temperature         *float64           `json:"temperature"`                     // Add validation tags if needed (e.g., min/max)
+	GroundHumidity      *float64           `json:"ground_humidity"`
+	EnvironmentHumidity *float64           `json:"environment_humidity"` // Corrected spelling
+	PhLevel             *float64           `json:"ph_level"`
+	Metrics             map[string]float64 `json:"metrics"`
+	Time                int64              `json:"time" validate:"required"` // Require the device timestamp
+}
+
+// Readings merges the fixed fields and the metrics map into a single
+// metric name -> value map. Entries of the metrics map take precedence.
+func (r *RegisterGardenDataRequest) Readings() map[string]float64 {
+	readings := make(map[string]float64, len(r.Metrics)+4)
+	fixed := map[string]*float64{
+		"temperature":          r.Temperature,
+		"ground_humidity":      r.GroundHumidity,
+		"environment_humidity": r.EnvironmentHumidity,
+		"ph_level":             r.PhLevel,
+	}
+	for name, value := range fixed {
+		if value != nil {
+			readings[name] = *value
+		}
+	}
+	for name, value := range r.Metrics {
+		readings[name] = value
+	}
+	return readings
 }
 
 // Note: No specific request struct is typically needed for the GET request,
 // as parameters are usually passed via URL path or query strings.
+
+// UpdateAnomalySettingsRequest tunes the anomaly detector of a kit. Omitted
+// fields keep their current value; 0 disables a check.
+type UpdateAnomalySettingsRequest struct {
+	Enabled         *bool    `json:"enabled"`
+	ZScoreThreshold *float64 `json:"z_score_threshold" validate:"omitempty,gte=0"`
+	SpikeFraction   *float64 `json:"spike_fraction" validate:"omitempty,gte=0,lte=1"`
+	FlatlineMinutes *int     `json:"flatline_minutes" validate:"omitempty,gte=0"`
+}
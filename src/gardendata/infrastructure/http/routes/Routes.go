@@ -7,16 +7,34 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func GardenDataRoutes(router *gin.RouterGroup) {
+func GardenDataRoutes(router *gin.RouterGroup, deps *http.Dependencies) {
 	// Instantiate controllers using the setup functions
-	registerController := http.SetUpRegisterGardenDataController()
-	getController := http.SetUpGetMinutesGardenDataController()
+	registerController := deps.SetUpRegisterGardenDataController()
+	getController := deps.SetUpGetMinutesGardenDataController()
+	getSamplesController := deps.SetUpGetMinutesMetricSamplesController()
+	exportController := deps.SetUpExportGardenDataController()
+	importController := deps.SetUpImportGardenDataController()
+	importJobController := deps.SetUpGetImportJobController()
+	statisticsController := deps.SetUpGetKitStatisticsController()
+	getAnomalySettingsController := deps.SetUpGetAnomalySettingsController()
+	updateAnomalySettingsController := deps.SetUpUpdateAnomalySettingsController()
+	completenessController := deps.SetUpGetCompletenessReportController()
 
 	// Apply authentication middleware if needed for these routes
 	// Data ingestion (POST) might use API keys, GET might use user tokens
 	// Applying standard user auth middleware here for consistency example:
 
 	// Define routes
-	router.POST("/", registerController.Run)                                                        // Register new data
-	router.GET("/kit/:kit_id/minutes/:minutes", middlewares.JWTAuthMiddleware(), getController.Run) // Get recent data
+	router.POST("/", registerController.Run)                                                                       // Register new data
+	router.GET("/kit/:kit_id/minutes/:minutes", middlewares.JWTAuthMiddleware(), getController.Run)                // Get recent data
+	router.GET("/kit/:kit_id/minutes", middlewares.JWTAuthMiddleware(), getController.Run)                         // Over the user's dashboard range
+	router.GET("/kit/:kit_id/samples/minutes/:minutes", middlewares.JWTAuthMiddleware(), getSamplesController.Run) // Get recent metric samples
+	router.GET("/kit/:kit_id/samples/minutes", middlewares.JWTAuthMiddleware(), getSamplesController.Run)
+	router.GET("/kit/:kit_id/export", middlewares.JWTAuthMiddleware(), exportController.Run)                       // Stream history as CSV/NDJSON/XLSX
+	router.POST("/kit/:kit_id/import", middlewares.JWTAuthMiddleware(), importController.Run)                      // Bulk import historical CSV
+	router.GET("/import/jobs/:job_id", middlewares.JWTAuthMiddleware(), importJobController.Run)                   // Poll import progress
+	router.GET("/kit/:kit_id/statistics", middlewares.JWTAuthMiddleware(), statisticsController.Run)               // Day/week/month summary
+	router.GET("/kit/:kit_id/anomaly-settings", middlewares.JWTAuthMiddleware(), getAnomalySettingsController.Run) // Anomaly detector tuning
+	router.PUT("/kit/:kit_id/anomaly-settings", middlewares.JWTAuthMiddleware(), updateAnomalySettingsController.Run)
+	router.GET("/kit/:kit_id/completeness", middlewares.JWTAuthMiddleware(), completenessController.Run) // Gaps and uptime per day
 }
@@ -0,0 +1,137 @@
+package importer
+
+import (
+	"api-order/src/gardendata/domain/entities"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeField is the mapping key of the column holding the device timestamp.
+const TimeField = "time"
+
+// ColumnMapping maps a target field (TimeField or a metric name) to a CSV header.
+type ColumnMapping map[string]string
+
+// ParseMapping reads a mapping written as "field=column,field=column".
+func ParseMapping(value string) (ColumnMapping, error) {
+	mapping := ColumnMapping{}
+	if strings.TrimSpace(value) == "" {
+		return mapping, nil
+	}
+	for _, pair := range strings.Split(value, ",") {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+			return nil, fmt.Errorf("invalid mapping entry %q, expected field=column", pair)
+		}
+		mapping[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	return mapping, nil
+}
+
+// ParseCSV reads a CSV file with a header row into import rows.
+//
+// Without an explicit mapping every header is used as the target field, so a file
+// with "time,temperature,co2" columns imports as-is. With a mapping only the mapped
+// columns are read. Lines that cannot be parsed are returned with ParseError set so
+// the import can report them; only a malformed header aborts parsing.
+func ParseCSV(r io.Reader, mapping ColumnMapping) ([]entities.ImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("csv file is empty")
+		}
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.TrimSpace(strings.TrimPrefix(name, "\ufeff"))] = i
+	}
+
+	if len(mapping) == 0 {
+		mapping = ColumnMapping{}
+		for name := range index {
+			mapping[name] = name
+		}
+	}
+
+	if _, ok := mapping[TimeField]; !ok {
+		return nil, fmt.Errorf("mapping must include the %q field", TimeField)
+	}
+	fields := make(map[string]int, len(mapping))
+	for field, column := range mapping {
+		i, ok := index[column]
+		if !ok {
+			return nil, fmt.Errorf("column %q mapped to %q not found in header", column, field)
+		}
+		fields[field] = i
+	}
+	timeIndex := fields[TimeField]
+	delete(fields, TimeField)
+
+	var rows []entities.ImportRow
+	line := 1
+	for {
+		record, err := reader.Read()
+		line++
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		row := entities.ImportRow{Line: line, Readings: make(map[string]float64, len(fields))}
+		if err != nil {
+			row.ParseError = err.Error()
+			rows = append(rows, row)
+			continue
+		}
+
+		row.Time, row.ParseError = parseTimeCell(cell(record, timeIndex))
+		if row.ParseError == "" {
+			for field, i := range fields {
+				value := cell(record, i)
+				if value == "" {
+					continue // Missing readings are allowed, the sensor may not have reported
+				}
+				number, err := strconv.ParseFloat(value, 64)
+				if err != nil {
+					row.ParseError = fmt.Sprintf("column %q: %q is not a number", header[i], value)
+					break
+				}
+				row.Readings[field] = number
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func cell(record []string, i int) string {
+	if i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// parseTimeCell accepts unix seconds or RFC3339 timestamps.
+func parseTimeCell(value string) (int64, string) {
+	if value == "" {
+		return 0, "missing time value"
+	}
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return seconds, ""
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t.Unix(), ""
+	}
+	return 0, fmt.Sprintf("time %q is neither unix seconds nor RFC3339", value)
+}
@@ -0,0 +1,91 @@
+package jobs
+
+import (
+	"api-order/src/gardendata/application"
+	"api-order/src/shared/logging"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// CompactionScheduler runs the retention compaction periodically in the background.
+type CompactionScheduler struct {
+	useCase  *application.CompactGardenDataUseCase
+	interval time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+	once     sync.Once
+	// cancel aborts the run in progress when Stop runs out of time.
+	cancel context.CancelFunc
+}
+
+func NewCompactionScheduler(useCase *application.CompactGardenDataUseCase, interval time.Duration) *CompactionScheduler {
+	return &CompactionScheduler{
+		useCase:  useCase,
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start runs a compaction right away and then once per interval until Stop is called.
+func (s *CompactionScheduler) Start(ctx context.Context) error {
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	s.cancel = cancel
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			s.runOnce(runCtx)
+			select {
+			case <-ticker.C:
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+	logging.FromContext(ctx).Info("Garden data compaction scheduled", "interval", s.interval)
+	return nil
+}
+
+// Stop asks the scheduler to exit and waits for the current run to finish. If
+// ctx is done first, the run is cancelled.
+func (s *CompactionScheduler) Stop(ctx context.Context) error {
+	s.once.Do(func() { close(s.stop) })
+	defer s.cancel()
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		<-s.done
+		return ctx.Err()
+	}
+}
+
+func (s *CompactionScheduler) runOnce(ctx context.Context) {
+	started := time.Now()
+	result, err := s.useCase.Run(ctx, started)
+	if err != nil {
+		logging.FromContext(ctx).Error("Garden data compaction failed", "error", err)
+		return
+	}
+	logging.FromContext(ctx).Info("Garden data compaction done",
+		"duration", time.Since(started).Round(time.Millisecond),
+		"hourly_buckets", result.HourlyBuckets, "daily_buckets", result.DailyBuckets,
+		"pruned_raw", result.PrunedRaw, "pruned_hourly", result.PrunedHourly, "pruned_daily", result.PrunedDaily)
+}
+
+// Health fails once the compaction loop has exited.
+func (s *CompactionScheduler) Health(ctx context.Context) error {
+	select {
+	case <-s.done:
+		return errors.New("scheduler exited")
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,82 @@
+package jobs
+
+import (
+	"api-order/src/gardendata/application"
+	"api-order/src/shared/logging"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// GapAlertScheduler periodically looks for kits that stopped sending readings.
+type GapAlertScheduler struct {
+	useCase  *application.AlertDataGapsUseCase
+	interval time.Duration
+	stop     chan struct{}
+	done     chan struct{}
+	once     sync.Once
+	// cancel aborts the run in progress when Stop runs out of time.
+	cancel context.CancelFunc
+}
+
+func NewGapAlertScheduler(useCase *application.AlertDataGapsUseCase, interval time.Duration) *GapAlertScheduler {
+	return &GapAlertScheduler{
+		useCase:  useCase,
+		interval: interval,
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+}
+
+// Start checks once per interval until Stop is called.
+func (s *GapAlertScheduler) Start(ctx context.Context) error {
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	s.cancel = cancel
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case now := <-ticker.C:
+				if raised, err := s.useCase.Run(runCtx, now); err != nil {
+					logging.FromContext(runCtx).Error("Data gap check failed", "error", err)
+				} else if raised > 0 {
+					logging.FromContext(runCtx).Info("Data gap check raised alerts", "alerts", raised)
+				}
+			case <-s.stop:
+				return
+			}
+		}
+	}()
+	logging.FromContext(ctx).Info("Data gap alerts scheduled", "silence", s.useCase.Threshold, "interval", s.interval)
+	return nil
+}
+
+// Stop asks the scheduler to exit and waits for the current check to finish. If
+// ctx is done first, the check is cancelled.
+func (s *GapAlertScheduler) Stop(ctx context.Context) error {
+	s.once.Do(func() { close(s.stop) })
+	defer s.cancel()
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		<-s.done
+		return ctx.Err()
+	}
+}
+
+// Health fails once the check loop has exited.
+func (s *GapAlertScheduler) Health(ctx context.Context) error {
+	select {
+	case <-s.done:
+		return errors.New("scheduler exited")
+	default:
+		return nil
+	}
+}
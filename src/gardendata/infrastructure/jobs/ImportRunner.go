@@ -0,0 +1,77 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrImportRunnerStopped is returned for imports started during shutdown.
+var ErrImportRunnerStopped = errors.New("import runner stopped")
+
+// errShuttingDown is the cause of the cancellation of interrupted imports.
+var errShuttingDown = errors.New("server shutting down")
+
+// ImportRunner runs the CSV imports started through the API. It accepts jobs
+// as soon as it is created, so handlers served before Start work too, and on
+// Stop waits for the running jobs before cancelling them.
+type ImportRunner struct {
+	mu      sync.Mutex
+	stopped bool
+	running sync.WaitGroup
+	// shutdown is cancelled when Stop runs out of time, which cancels every job.
+	shutdown context.Context
+	cancel   context.CancelCauseFunc
+}
+
+func NewImportRunner() *ImportRunner {
+	shutdown, cancel := context.WithCancelCause(context.Background())
+	return &ImportRunner{shutdown: shutdown, cancel: cancel}
+}
+
+// Go runs job in a new goroutine until it returns or the runner cancels it.
+func (r *ImportRunner) Go(ctx context.Context, job func(ctx context.Context)) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stopped {
+		return ErrImportRunnerStopped
+	}
+
+	jobCtx, cancel := context.WithCancelCause(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(r.shutdown, func() { cancel(context.Cause(r.shutdown)) })
+	r.running.Add(1)
+	go func() {
+		defer r.running.Done()
+		defer cancel(nil)
+		defer stop()
+		job(jobCtx)
+	}()
+	return nil
+}
+
+// Start does nothing: the runner accepts jobs from the moment it is created.
+func (r *ImportRunner) Start(ctx context.Context) error {
+	return nil
+}
+
+// Stop refuses new jobs and waits for the running ones. If ctx is done first,
+// they are cancelled and record their interruption before Stop returns.
+func (r *ImportRunner) Stop(ctx context.Context) error {
+	r.mu.Lock()
+	r.stopped = true
+	r.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		r.running.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		r.cancel(errShuttingDown)
+		<-done
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,62 @@
+package jobs_test
+
+import (
+	"api-order/src/gardendata/infrastructure/jobs"
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestImportRunnerWaitsForJobsOnStop(t *testing.T) {
+	runner := jobs.NewImportRunner()
+	finished := make(chan struct{})
+	err := runner.Go(context.Background(), func(ctx context.Context) {
+		time.Sleep(50 * time.Millisecond)
+		close(finished)
+	})
+	if err != nil {
+		t.Fatalf("go: %v", err)
+	}
+
+	if err := runner.Stop(context.Background()); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+	select {
+	case <-finished:
+	default:
+		t.Fatal("stop returned before the job finished")
+	}
+	if err := runner.Go(context.Background(), func(context.Context) {}); !errors.Is(err, jobs.ErrImportRunnerStopped) {
+		t.Fatalf("got %v, want ErrImportRunnerStopped", err)
+	}
+}
+
+func TestImportRunnerCancelsJobsWhenStopTimesOut(t *testing.T) {
+	runner := jobs.NewImportRunner()
+	// The request that started the job is over, which must not cancel it.
+	requestCtx, cancelRequest := context.WithCancel(context.Background())
+	cause := make(chan error, 1)
+	err := runner.Go(requestCtx, func(ctx context.Context) {
+		<-ctx.Done()
+		cause <- context.Cause(ctx)
+	})
+	if err != nil {
+		t.Fatalf("go: %v", err)
+	}
+	cancelRequest()
+
+	stopCtx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := runner.Stop(stopCtx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want the stop deadline", err)
+	}
+	select {
+	case err := <-cause:
+		if err == nil || err.Error() != "server shutting down" {
+			t.Fatalf("job cancelled with %v", err)
+		}
+	default:
+		t.Fatal("stop returned before the job exited")
+	}
+}
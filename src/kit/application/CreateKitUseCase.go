@@ -3,6 +3,8 @@ package application
 import (
 	"api-order/src/kit/domain/entities"
 	"api-order/src/kit/domain/ports"
+	"api-order/src/shared/tracing"
+	"context"
 )
 
 type CreateKitUseCase struct {
@@ -14,15 +16,24 @@ func NewCreateKitUseCase(kitRepository ports.IKit) *CreateKitUseCase {
 }
 
 // Run now takes userID from the controller (which gets it from JWT)
-func (uc *CreateKitUseCase) Run(name, description string, userID int64) (entities.Kit, error) {
+// A samplingInterval of 0 uses entities.DefaultSamplingIntervalSeconds.
+func (uc *CreateKitUseCase) Run(ctx context.Context, name, description string, userID int64, samplingInterval int) (entities.Kit, error) {
+	ctx, span := tracing.Start(ctx, "CreateKitUseCase.Run")
+	defer span.End()
+
+	if samplingInterval == 0 {
+		samplingInterval = entities.DefaultSamplingIntervalSeconds
+	}
+
 	kit := entities.Kit{
-		UserID:      userID,
-		Name:        name,
-		Description: description,
+		UserID:                  userID,
+		Name:                    name,
+		Description:             description,
+		SamplingIntervalSeconds: samplingInterval,
 		// CreatedAt is handled by the database DEFAULT
 	}
 
-	createdKit, err := uc.KitRepository.Create(kit)
+	createdKit, err := uc.KitRepository.Create(ctx, kit)
 	if err != nil {
 		// Specific error handling (like unique constraints) might be needed here
 		// depending on repository implementation feedback, but keep it simple for now.
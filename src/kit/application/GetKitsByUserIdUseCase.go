@@ -3,8 +3,19 @@ package application
 import (
 	"api-order/src/kit/domain/entities"
 	"api-order/src/kit/domain/ports"
+	"api-order/src/shared/pagination"
+	"api-order/src/shared/tracing"
+	"context"
 )
 
+// KitsPageSpec describes the paging, sorting and filtering accepted by the kits list.
+var KitsPageSpec = pagination.Spec{
+	DefaultLimit: 50,
+	MaxLimit:     200,
+	Sorts:        []string{"name", "-name", "created_at", "-created_at"},
+	Filters:      []string{"name"},
+}
+
 type GetKitsUseCase struct {
 	KitRepository ports.IKit
 }
@@ -14,17 +25,20 @@ func NewGetKitsUseCase(kitRepository ports.IKit) *GetKitsUseCase {
 }
 
 // Run takes the userID to fetch kits for
-func (uc *GetKitsUseCase) Run(userID int64) ([]entities.Kit, error) {
-	kits, err := uc.KitRepository.GetByUserID(userID)
+func (uc *GetKitsUseCase) Run(ctx context.Context, userID int64, params pagination.OffsetParams) ([]entities.Kit, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "GetKitsUseCase.Run")
+	defer span.End()
+
+	kits, total, err := uc.KitRepository.GetByUserID(ctx, userID, params)
 	if err != nil {
 		// Handle specific errors if needed, e.g., distinguishing "not found" from other DB errors
-		return nil, err
+		return nil, nil, err
 	}
 
 	// It's okay to return an empty slice if no kits are found
 	if kits == nil {
-		return []entities.Kit{}, nil
+		kits = []entities.Kit{}
 	}
 
-	return kits, nil
+	return kits, pagination.OffsetPage(params, total), nil
 }
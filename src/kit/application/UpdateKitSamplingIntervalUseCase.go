@@ -0,0 +1,42 @@
+package application
+
+import (
+	"api-order/src/kit/domain/entities"
+	"api-order/src/kit/domain/ports"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/tracing"
+	"context"
+	"fmt"
+)
+
+var ErrKitNotOwned = ports.ErrKitNotOwned
+var ErrInvalidSamplingInterval = domainerrors.Validation("invalid_sampling_interval", fmt.Sprintf("sampling interval must be between %d and %d seconds", entities.MinSamplingIntervalSeconds, entities.MaxSamplingIntervalSeconds))
+
+type UpdateKitSamplingIntervalUseCase struct {
+	KitRepository ports.IKit
+}
+
+func NewUpdateKitSamplingIntervalUseCase(kitRepository ports.IKit) *UpdateKitSamplingIntervalUseCase {
+	return &UpdateKitSamplingIntervalUseCase{KitRepository: kitRepository}
+}
+
+// Run changes the expected sampling interval of a kit owned by userID.
+func (uc *UpdateKitSamplingIntervalUseCase) Run(ctx context.Context, kitID, userID int64, seconds int) (entities.Kit, error) {
+	ctx, span := tracing.Start(ctx, "UpdateKitSamplingIntervalUseCase.Run")
+	defer span.End()
+
+	if seconds < entities.MinSamplingIntervalSeconds || seconds > entities.MaxSamplingIntervalSeconds {
+		return entities.Kit{}, ErrInvalidSamplingInterval
+	}
+
+	kit, err := ports.OwnedKit(ctx, uc.KitRepository, kitID, userID)
+	if err != nil {
+		return entities.Kit{}, err
+	}
+
+	if err := uc.KitRepository.UpdateSamplingInterval(ctx, kitID, seconds); err != nil {
+		return entities.Kit{}, err
+	}
+	kit.SamplingIntervalSeconds = seconds
+	return kit, nil
+}
@@ -2,10 +2,29 @@ package entities
 
 import "time"
 
+// Sampling interval bounds, in seconds. Kits that never configured it are
+// expected to report every DefaultSamplingIntervalSeconds.
+const (
+	DefaultSamplingIntervalSeconds = 300
+	MinSamplingIntervalSeconds     = 10
+	MaxSamplingIntervalSeconds     = 86400
+)
+
 type Kit struct {
-	ID          int64     `json:"id"`
-	UserID      int64     `json:"user_id"`
-	Name        string    `json:"name"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
+	ID                      int64     `json:"id"`
+	UserID                  int64     `json:"user_id"`
+	Name                    string    `json:"name"`
+	Description             string    `json:"description"`
+	SamplingIntervalSeconds int       `json:"sampling_interval_seconds"` // Expected time between readings
+	ClockDriftFlagged       bool      `json:"clock_drift_flagged"`       // Device clock is too far from server time
+	ClockSkewSeconds        int64     `json:"clock_skew_seconds"`        // Device minus server time, kept within 30s of the last reading
+	CreatedAt               time.Time `json:"created_at"`
+}
+
+// SamplingInterval returns the expected time between two readings of the kit.
+func (k *Kit) SamplingInterval() time.Duration {
+	if k.SamplingIntervalSeconds <= 0 {
+		return DefaultSamplingIntervalSeconds * time.Second
+	}
+	return time.Duration(k.SamplingIntervalSeconds) * time.Second
 }
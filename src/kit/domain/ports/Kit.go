@@ -1,9 +1,40 @@
 package ports
 
-import "api-order/src/kit/domain/entities"
+import (
+	"api-order/src/kit/domain/entities"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/pagination"
+	"context"
+)
+
+// ErrKitNotFound is returned when a kit id does not exist.
+var ErrKitNotFound = domainerrors.NotFound("kit_not_found", "kit not found")
+
+// ErrKitNotOwned is returned when a kit belongs to another user.
+var ErrKitNotOwned = domainerrors.Forbidden("kit_not_owned", "kit does not belong to the user")
 
 type IKit interface {
-	Create(kit entities.Kit) (entities.Kit, error)
-	GetByUserID(userID int64) ([]entities.Kit, error)
-	CheckKitNameExists(name string) (bool, error)
+	Create(ctx context.Context, kit entities.Kit) (entities.Kit, error)
+	// GetByUserID returns one page of the user's kits and the total number of kits matching the filters
+	GetByUserID(ctx context.Context, userID int64, page pagination.OffsetParams) ([]entities.Kit, int64, error)
+	CheckKitNameExists(ctx context.Context, name string) (bool, error)
+	// GetByID returns ErrKitNotFound when the kit does not exist
+	GetByID(ctx context.Context, kitID int64) (entities.Kit, error)
+	UpdateSamplingInterval(ctx context.Context, kitID int64, seconds int) error
+	// UpdateClockDrift stores the measured clock skew of the kit and whether it exceeds the tolerance
+	UpdateClockDrift(ctx context.Context, kitID int64, skewSeconds int64, flagged bool) error
+}
+
+// OwnedKit returns the kit when it belongs to userID. It fails with
+// ErrKitNotFound when the kit does not exist and ErrKitNotOwned when another
+// user owns it, so every module authorizes kit changes the same way.
+func OwnedKit(ctx context.Context, kits IKit, kitID, userID int64) (entities.Kit, error) {
+	kit, err := kits.GetByID(ctx, kitID)
+	if err != nil {
+		return entities.Kit{}, err
+	}
+	if kit.UserID != userID {
+		return entities.Kit{}, ErrKitNotOwned
+	}
+	return kit, nil
 }
@@ -0,0 +1,128 @@
+package adapters
+
+import (
+	"api-order/src/kit/domain/entities"
+	"api-order/src/kit/domain/ports"
+	"api-order/src/shared/pagination"
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// KitRepositoryMemory keeps kits in process memory. It backs the HTTP test
+// suite and mirrors the filtering and ordering of the MySQL repository.
+type KitRepositoryMemory struct {
+	mu     sync.RWMutex
+	nextID int64
+	kits   map[int64]entities.Kit
+}
+
+func NewKitRepositoryMemory() *KitRepositoryMemory {
+	return &KitRepositoryMemory{kits: make(map[int64]entities.Kit)}
+}
+
+// Create implements ports.IKit
+func (r *KitRepositoryMemory) Create(ctx context.Context, kit entities.Kit) (entities.Kit, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	kit.ID = r.nextID
+	kit.CreatedAt = time.Now()
+	r.kits[kit.ID] = kit
+	return kit, nil
+}
+
+// GetByUserID implements ports.IKit
+func (r *KitRepositoryMemory) GetByUserID(ctx context.Context, userID int64, page pagination.OffsetParams) ([]entities.Kit, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	name, filterByName := page.Filters["name"]
+	matches := []entities.Kit{}
+	for _, kit := range r.kits {
+		if kit.UserID != userID || (filterByName && !strings.Contains(strings.ToLower(kit.Name), strings.ToLower(name))) {
+			continue
+		}
+		matches = append(matches, kit)
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if page.Sort.Desc {
+			a, b = b, a
+		}
+		if page.Sort.Field == "created_at" && !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		if page.Sort.Field != "created_at" && a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	})
+
+	total := int64(len(matches))
+	if page.Offset >= len(matches) {
+		return []entities.Kit{}, total, nil
+	}
+	matches = matches[page.Offset:]
+	if len(matches) > page.Limit {
+		matches = matches[:page.Limit]
+	}
+	return matches, total, nil
+}
+
+// CheckKitNameExists implements ports.IKit
+func (r *KitRepositoryMemory) CheckKitNameExists(ctx context.Context, name string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, kit := range r.kits {
+		if kit.Name == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// GetByID implements ports.IKit
+func (r *KitRepositoryMemory) GetByID(ctx context.Context, kitID int64) (entities.Kit, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	kit, ok := r.kits[kitID]
+	if !ok {
+		return entities.Kit{}, ports.ErrKitNotFound
+	}
+	return kit, nil
+}
+
+// UpdateSamplingInterval implements ports.IKit
+func (r *KitRepositoryMemory) UpdateSamplingInterval(ctx context.Context, kitID int64, seconds int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kit, ok := r.kits[kitID]
+	if !ok {
+		return ports.ErrKitNotFound
+	}
+	kit.SamplingIntervalSeconds = seconds
+	r.kits[kitID] = kit
+	return nil
+}
+
+// UpdateClockDrift implements ports.IKit
+func (r *KitRepositoryMemory) UpdateClockDrift(ctx context.Context, kitID int64, skewSeconds int64, flagged bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Like the UPDATE statement, an unknown kit is not an error
+	if kit, ok := r.kits[kitID]; ok {
+		kit.ClockSkewSeconds = skewSeconds
+		kit.ClockDriftFlagged = flagged
+		r.kits[kitID] = kit
+	}
+	return nil
+}
@@ -3,44 +3,48 @@ package adapters
 import (
 	database "api-order/src/Database" // Assuming shared DB connection setup
 	"api-order/src/kit/domain/entities"
+	"api-order/src/kit/domain/ports"
+	"api-order/src/shared/logging"
+	"api-order/src/shared/pagination"
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
-	"log" // For logging errors
 )
 
 type KitRepositoryMysql struct {
-	DB *sql.DB
+	DB       *sql.DB
+	Timeouts database.Timeouts
 }
 
-// Reusing the database connection logic
-func NewKitRepositoryMysql() (*KitRepositoryMysql, error) {
-	db, err := database.Connect()
-	if err != nil {
-		return nil, err
-	}
-	return &KitRepositoryMysql{DB: db}, nil
+// NewKitRepositoryMysql wraps the shared connection pool.
+func NewKitRepositoryMysql(db *sql.DB, timeouts database.Timeouts) *KitRepositoryMysql {
+	return &KitRepositoryMysql{DB: db, Timeouts: timeouts}
 }
 
 // Create implements ports.IKit
-func (r *KitRepositoryMysql) Create(kit entities.Kit) (entities.Kit, error) {
-	query := "INSERT INTO kits (user_id, name, description) VALUES (?, ?, ?)"
-	stmt, err := r.DB.Prepare(query)
+func (r *KitRepositoryMysql) Create(ctx context.Context, kit entities.Kit) (entities.Kit, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpWrite)
+	defer cancel()
+
+	query := "INSERT INTO kits (user_id, name, description, sampling_interval_seconds) VALUES (?, ?, ?, ?)"
+	stmt, err := r.DB.PrepareContext(ctx, query)
 	if err != nil {
-		log.Printf("Error preparing kit insert statement: %v", err)
+		logging.FromContext(ctx).Error("Error preparing kit insert statement", "error", err)
 		return entities.Kit{}, err
 	}
 	defer stmt.Close()
 
-	result, err := stmt.Exec(kit.UserID, kit.Name, kit.Description)
+	result, err := stmt.ExecContext(ctx, kit.UserID, kit.Name, kit.Description, kit.SamplingIntervalSeconds)
 	if err != nil {
-		log.Printf("Error executing kit insert statement: %v", err)
-		// Consider checking for specific DB errors like foreign key violations if needed
-		return entities.Kit{}, err
+		logging.FromContext(ctx).Error("Error executing kit insert statement", "error", err)
+		// A missing user_id becomes domainerrors.ErrMissingReference
+		return entities.Kit{}, database.TranslateError(err)
 	}
 
 	id, err := result.LastInsertId()
 	if err != nil {
-		log.Printf("Error getting last insert ID for kit: %v", err)
+		logging.FromContext(ctx).Error("Error getting last insert ID for kit", "error", err)
 		return entities.Kit{}, err
 	}
 
@@ -55,14 +59,43 @@ func (r *KitRepositoryMysql) Create(kit entities.Kit) (entities.Kit, error) {
 	return kit, nil
 }
 
+// kitSortColumns maps the sort fields accepted by the kits list to columns
+var kitSortColumns = map[string]string{"name": "name", "created_at": "created_at"}
+
 // GetByUserID implements ports.IKit
-func (r *KitRepositoryMysql) GetByUserID(userID int64) ([]entities.Kit, error) {
+func (r *KitRepositoryMysql) GetByUserID(ctx context.Context, userID int64, page pagination.OffsetParams) ([]entities.Kit, int64, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	where := " WHERE user_id = ?"
+	args := []interface{}{userID}
+	if name, ok := page.Filters["name"]; ok {
+		where += " AND name LIKE ?"
+		args = append(args, "%"+name+"%")
+	}
+
+	var total int64
+	if err := r.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM kits"+where, args...).Scan(&total); err != nil {
+		logging.FromContext(ctx).Error("Error counting kits by user ID", "user_id", userID, "error", err)
+		return nil, 0, err
+	}
+
+	column, ok := kitSortColumns[page.Sort.Field]
+	if !ok {
+		column = "name"
+	}
+	direction := "ASC"
+	if page.Sort.Desc {
+		direction = "DESC"
+	}
+
 	// Select created_at as well, since it's part of the entity
-	query := "SELECT kit_id, user_id, name, description, created_at FROM kits WHERE user_id = ?"
-	rows, err := r.DB.Query(query, userID)
+	query := "SELECT kit_id, user_id, name, description, sampling_interval_seconds, clock_drift_flagged, clock_skew_seconds, created_at FROM kits" +
+		where + " ORDER BY " + column + " " + direction + ", kit_id " + direction + " LIMIT ? OFFSET ?"
+	rows, err := r.DB.QueryContext(ctx, query, append(args, page.Limit, page.Offset)...)
 	if err != nil {
-		log.Printf("Error querying kits by user ID %d: %v", userID, err)
-		return nil, err
+		logging.FromContext(ctx).Error("Error querying kits by user ID", "user_id", userID, "error", err)
+		return nil, 0, err
 	}
 	defer rows.Close()
 
@@ -70,31 +103,34 @@ func (r *KitRepositoryMysql) GetByUserID(userID int64) ([]entities.Kit, error) {
 	for rows.Next() {
 		var kit entities.Kit
 		// Ensure Scan order matches SELECT statement
-		if err := rows.Scan(&kit.ID, &kit.UserID, &kit.Name, &kit.Description, &kit.CreatedAt); err != nil {
-			log.Printf("Error scanning kit row: %v", err)
+		if err := rows.Scan(&kit.ID, &kit.UserID, &kit.Name, &kit.Description, &kit.SamplingIntervalSeconds, &kit.ClockDriftFlagged, &kit.ClockSkewSeconds, &kit.CreatedAt); err != nil {
+			logging.FromContext(ctx).Error("Error scanning kit row", "error", err)
 			// Decide if one bad row should fail the whole query or just be skipped
-			return nil, err // Fail fast for now
+			return nil, 0, err // Fail fast for now
 		}
 		kits = append(kits, kit)
 	}
 
 	if err = rows.Err(); err != nil {
-		log.Printf("Error after iterating kit rows: %v", err)
-		return nil, err
+		logging.FromContext(ctx).Error("Error after iterating kit rows", "error", err)
+		return nil, 0, err
 	}
 
 	// Return empty slice, not nil, if no kits found (standard Go practice)
 	if len(kits) == 0 {
-		return []entities.Kit{}, nil
+		return []entities.Kit{}, total, nil
 	}
 
-	return kits, nil
+	return kits, total, nil
 }
 
-func (r *KitRepositoryMysql) CheckKitNameExists(name string) (bool, error) {
+func (r *KitRepositoryMysql) CheckKitNameExists(ctx context.Context, name string) (bool, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
 	query := "SELECT EXISTS(SELECT 1 FROM kits WHERE name = ?)"
 	var exists bool
-	err := r.DB.QueryRow(query, name).Scan(&exists)
+	err := r.DB.QueryRowContext(ctx, query, name).Scan(&exists)
 
 	// sql.ErrNoRows is not returned by EXISTS, it always returns one row (0 or 1)
 	if err != nil {
@@ -103,3 +139,52 @@ func (r *KitRepositoryMysql) CheckKitNameExists(name string) (bool, error) {
 
 	return exists, nil
 }
+
+// GetByID implements ports.IKit
+func (r *KitRepositoryMysql) GetByID(ctx context.Context, kitID int64) (entities.Kit, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	query := "SELECT kit_id, user_id, name, description, sampling_interval_seconds, clock_drift_flagged, clock_skew_seconds, created_at FROM kits WHERE kit_id = ?"
+	var kit entities.Kit
+	err := r.DB.QueryRowContext(ctx, query, kitID).Scan(&kit.ID, &kit.UserID, &kit.Name, &kit.Description, &kit.SamplingIntervalSeconds, &kit.ClockDriftFlagged, &kit.ClockSkewSeconds, &kit.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entities.Kit{}, ports.ErrKitNotFound
+		}
+		return entities.Kit{}, fmt.Errorf("failed to get kit %d: %w", kitID, err)
+	}
+	return kit, nil
+}
+
+// UpdateSamplingInterval implements ports.IKit
+func (r *KitRepositoryMysql) UpdateSamplingInterval(ctx context.Context, kitID int64, seconds int) error {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpWrite)
+	defer cancel()
+
+	result, err := r.DB.ExecContext(ctx, "UPDATE kits SET sampling_interval_seconds = ? WHERE kit_id = ?", seconds, kitID)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error updating sampling interval of kit", "kit_id", kitID, "error", err)
+		return err
+	}
+	// MySQL reports 0 affected rows when the value does not change, so check existence separately
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		if _, err := r.GetByID(ctx, kitID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateClockDrift implements ports.IKit
+func (r *KitRepositoryMysql) UpdateClockDrift(ctx context.Context, kitID int64, skewSeconds int64, flagged bool) error {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpWrite)
+	defer cancel()
+
+	_, err := r.DB.ExecContext(ctx, "UPDATE kits SET clock_skew_seconds = ?, clock_drift_flagged = ? WHERE kit_id = ?", skewSeconds, flagged, kitID)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error updating clock drift of kit", "kit_id", kitID, "error", err)
+		return err
+	}
+	return nil
+}
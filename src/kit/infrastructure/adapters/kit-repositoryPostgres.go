@@ -0,0 +1,158 @@
+package adapters
+
+import (
+	database "api-order/src/Database"
+	"api-order/src/kit/domain/entities"
+	"api-order/src/kit/domain/ports"
+	"api-order/src/shared/logging"
+	"api-order/src/shared/pagination"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type KitRepositoryPostgres struct {
+	DB       *sql.DB
+	Timeouts database.Timeouts
+}
+
+// NewKitRepositoryPostgres wraps the shared connection pool.
+func NewKitRepositoryPostgres(db *sql.DB, timeouts database.Timeouts) *KitRepositoryPostgres {
+	return &KitRepositoryPostgres{DB: db, Timeouts: timeouts}
+}
+
+// Create implements ports.IKit
+func (r *KitRepositoryPostgres) Create(ctx context.Context, kit entities.Kit) (entities.Kit, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpWrite)
+	defer cancel()
+
+	now := time.Now()
+	query := database.Rebind("INSERT INTO kits (user_id, name, description, sampling_interval_seconds, created_at) VALUES (?, ?, ?, ?, ?) RETURNING kit_id")
+	if err := r.DB.QueryRowContext(ctx, query, kit.UserID, kit.Name, kit.Description, kit.SamplingIntervalSeconds, now).Scan(&kit.ID); err != nil {
+		logging.FromContext(ctx).Error("Error executing kit insert statement", "error", err)
+		// A missing user_id becomes domainerrors.ErrMissingReference
+		return entities.Kit{}, database.TranslateError(err)
+	}
+
+	kit.CreatedAt = now
+	return kit, nil
+}
+
+// GetByUserID implements ports.IKit
+func (r *KitRepositoryPostgres) GetByUserID(ctx context.Context, userID int64, page pagination.OffsetParams) ([]entities.Kit, int64, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	// ILIKE keeps the filter case-insensitive like MySQL's default collation
+	where := " WHERE user_id = ?"
+	args := []interface{}{userID}
+	if name, ok := page.Filters["name"]; ok {
+		where += " AND name ILIKE ?"
+		args = append(args, "%"+name+"%")
+	}
+
+	var total int64
+	if err := r.DB.QueryRowContext(ctx, database.Rebind("SELECT COUNT(*) FROM kits"+where), args...).Scan(&total); err != nil {
+		logging.FromContext(ctx).Error("Error counting kits by user ID", "user_id", userID, "error", err)
+		return nil, 0, err
+	}
+
+	column, ok := kitSortColumns[page.Sort.Field]
+	if !ok {
+		column = "name"
+	}
+	direction := "ASC"
+	if page.Sort.Desc {
+		direction = "DESC"
+	}
+
+	query := "SELECT kit_id, user_id, name, description, sampling_interval_seconds, clock_drift_flagged, clock_skew_seconds, created_at FROM kits" +
+		where + " ORDER BY " + column + " " + direction + ", kit_id " + direction + " LIMIT ? OFFSET ?"
+	rows, err := r.DB.QueryContext(ctx, database.Rebind(query), append(args, page.Limit, page.Offset)...)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error querying kits by user ID", "user_id", userID, "error", err)
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	kits := []entities.Kit{}
+	for rows.Next() {
+		kit, err := scanPostgresKit(rows)
+		if err != nil {
+			logging.FromContext(ctx).Error("Error scanning kit row", "error", err)
+			return nil, 0, err
+		}
+		kits = append(kits, kit)
+	}
+	if err = rows.Err(); err != nil {
+		logging.FromContext(ctx).Error("Error after iterating kit rows", "error", err)
+		return nil, 0, err
+	}
+	return kits, total, nil
+}
+
+// CheckKitNameExists implements ports.IKit
+func (r *KitRepositoryPostgres) CheckKitNameExists(ctx context.Context, name string) (bool, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	var exists bool
+	if err := r.DB.QueryRowContext(ctx, database.Rebind("SELECT EXISTS(SELECT 1 FROM kits WHERE name = ?)"), name).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check kit name existence for '%s': %w", name, err)
+	}
+	return exists, nil
+}
+
+// GetByID implements ports.IKit
+func (r *KitRepositoryPostgres) GetByID(ctx context.Context, kitID int64) (entities.Kit, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	query := database.Rebind("SELECT kit_id, user_id, name, description, sampling_interval_seconds, clock_drift_flagged, clock_skew_seconds, created_at FROM kits WHERE kit_id = ?")
+	kit, err := scanPostgresKit(r.DB.QueryRowContext(ctx, query, kitID))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entities.Kit{}, ports.ErrKitNotFound
+		}
+		return entities.Kit{}, fmt.Errorf("failed to get kit %d: %w", kitID, err)
+	}
+	return kit, nil
+}
+
+// UpdateSamplingInterval implements ports.IKit
+func (r *KitRepositoryPostgres) UpdateSamplingInterval(ctx context.Context, kitID int64, seconds int) error {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpWrite)
+	defer cancel()
+
+	result, err := r.DB.ExecContext(ctx, database.Rebind("UPDATE kits SET sampling_interval_seconds = ? WHERE kit_id = ?"), seconds, kitID)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error updating sampling interval of kit", "kit_id", kitID, "error", err)
+		return err
+	}
+	// PostgreSQL counts matched rows, so 0 means the kit does not exist
+	if affected, _ := result.RowsAffected(); affected == 0 {
+		return ports.ErrKitNotFound
+	}
+	return nil
+}
+
+// UpdateClockDrift implements ports.IKit
+func (r *KitRepositoryPostgres) UpdateClockDrift(ctx context.Context, kitID int64, skewSeconds int64, flagged bool) error {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpWrite)
+	defer cancel()
+
+	_, err := r.DB.ExecContext(ctx, database.Rebind("UPDATE kits SET clock_skew_seconds = ?, clock_drift_flagged = ? WHERE kit_id = ?"), skewSeconds, flagged, kitID)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error updating clock drift of kit", "kit_id", kitID, "error", err)
+		return err
+	}
+	return nil
+}
+
+func scanPostgresKit(row rowScanner) (entities.Kit, error) {
+	var kit entities.Kit
+	err := row.Scan(&kit.ID, &kit.UserID, &kit.Name, &kit.Description, &kit.SamplingIntervalSeconds, &kit.ClockDriftFlagged, &kit.ClockSkewSeconds, &kit.CreatedAt)
+	return kit, err
+}
@@ -3,43 +3,33 @@ package http
 import (
 	"api-order/src/kit/application"
 	"api-order/src/kit/domain/ports"
-	"api-order/src/kit/infrastructure/adapters"
 	"api-order/src/kit/infrastructure/http/controllers"
-	"log"
 )
 
-// Declare repository variable specific to kit
-var (
-	kitRepository ports.IKit
-)
+// Dependencies holds what the kit controllers need. It is built by the
+// application container and handed to KitRoutes.
+type Dependencies struct {
+	KitRepository ports.IKit
+}
 
-// Initialize kit dependencies. You might merge this with the client's init
-// or keep them separate if preferred. Let's keep it separate for clarity.
-func InitializeKitDependencies() {
-	var err error
-	kitRepository, err = adapters.NewKitRepositoryMysql()
-	if err != nil {
-		log.Fatalf("Error initializing kit repository: %v", err)
-	}
-	// Add other kit-specific dependencies here if needed in the future
+func NewDependencies(kitRepository ports.IKit) *Dependencies {
+	return &Dependencies{KitRepository: kitRepository}
 }
 
 // Setup function for CreateKitController
-func SetUpCreateKitController() *controllers.CreateKitController {
-	// Ensure dependencies are initialized before setting up controllers
-	if kitRepository == nil {
-		InitializeKitDependencies()
-	}
-	createKitService := application.NewCreateKitUseCase(kitRepository)
+func (d *Dependencies) SetUpCreateKitController() *controllers.CreateKitController {
+	createKitService := application.NewCreateKitUseCase(d.KitRepository)
 	return controllers.NewCreateKitController(createKitService)
 }
 
 // Setup function for GetKitsController
-func SetUpGetKitsController() *controllers.GetKitsController {
-	// Ensure dependencies are initialized
-	if kitRepository == nil {
-		InitializeKitDependencies()
-	}
-	getKitsService := application.NewGetKitsUseCase(kitRepository)
+func (d *Dependencies) SetUpGetKitsController() *controllers.GetKitsController {
+	getKitsService := application.NewGetKitsUseCase(d.KitRepository)
 	return controllers.NewGetKitsController(getKitsService)
 }
+
+// Setup function for UpdateSamplingIntervalController
+func (d *Dependencies) SetUpUpdateSamplingIntervalController() *controllers.UpdateSamplingIntervalController {
+	updateIntervalService := application.NewUpdateKitSamplingIntervalUseCase(d.KitRepository)
+	return controllers.NewUpdateSamplingIntervalController(updateIntervalService)
+}
@@ -3,9 +3,12 @@ package controllers
 import (
 	"api-order/src/kit/application"
 	"api-order/src/kit/domain/entities"
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/logging"
 	"api-order/src/shared/middlewares" // Import middleware package
+	"api-order/src/shared/pagination"
 	"api-order/src/shared/responses"
-	"log"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -20,51 +23,46 @@ func NewGetKitsController(kitService *application.GetKitsUseCase) *GetKitsContro
 }
 
 // @Summary      Get kits for the authenticated user
-// @Description  Retrieves all kits associated with the user identified by the JWT token.
+// @Description  Retrieves a page of the kits associated with the user identified by the JWT token.
 // @Tags         Kits
 // @Produce      json
 // @Security     BearerAuth
+// @Param        limit         query  int     false  "Page size (max 200)" default(50)
+// @Param        offset        query  int     false  "Kits to skip" default(0)
+// @Param        sort          query  string  false  "Sort order" Enums(name, -name, created_at, -created_at) default(name)
+// @Param        filter[name]  query  string  false  "Only kits whose name contains this text"
 // @Success      200  {object}  responses.Response{data=[]entities.Kit} "Kits retrieved successfully"
+// @Failure      400  {object}  responses.Response "Invalid pagination parameters"
 // @Failure      401  {object}  responses.Response "Unauthorized"
 // @Failure      500  {object}  responses.Response "Internal server error"
+// @Failure      504  {object}  responses.Response "Operation timed out"
 // @Router       /v1/kits/ [get]
 func (ctr *GetKitsController) Run(ctx *gin.Context) {
 	// 1. Get UserID from JWT Claims (set by middleware)
 	claimsData, exists := ctx.Get("datUser")
 	if !exists {
-		log.Println("Error: datUser claims not found in context for GetKits.")
-		ctx.JSON(http.StatusUnauthorized, responses.Response{
-			Success: false,
-			Message: "Unauthorized: User claims not found.",
-			Error:   "Authentication context missing.",
-			Data:    nil,
-		})
+		logging.FromContext(ctx.Request.Context()).Error("Error: datUser claims not found in context for GetKits")
+		ctx.Error(middlewares.ErrMissingToken)
 		return
 	}
 
 	customClaims, ok := claimsData.(*middlewares.CustomClaims)
 	if !ok {
-		log.Println("Error: Failed to assert datUser claims to *middlewares.CustomClaims for GetKits.")
-		ctx.JSON(http.StatusInternalServerError, responses.Response{
-			Success: false,
-			Message: "Internal Server Error: Could not process user identity.",
-			Error:   "Type assertion failed for claims.",
-			Data:    nil,
-		})
+		ctx.Error(errors.New("failed to assert datUser claims to *middlewares.CustomClaims"))
 		return
 	}
 	userID := customClaims.ClientID // Get the user ID
 
+	params, err := pagination.ParseOffset(ctx.Request.URL.Query(), application.KitsPageSpec)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
 	// 2. Call the Use Case
-	kits, err := ctr.KitService.Run(userID)
+	kits, page, err := ctr.KitService.Run(ctx.Request.Context(), userID, params)
 	if err != nil {
-		log.Printf("Error getting kits for user %d: %v", userID, err)
-		ctx.JSON(http.StatusInternalServerError, responses.Response{
-			Success: false,
-			Message: "Failed to retrieve kits.",
-			Error:   err.Error(), // Be cautious about exposing raw DB errors
-			Data:    nil,
-		})
+		ctx.Error(err)
 		return
 	}
 
@@ -74,9 +72,10 @@ func (ctr *GetKitsController) Run(ctx *gin.Context) {
 		kits = []entities.Kit{}
 	}
 	ctx.JSON(http.StatusOK, responses.Response{
-		Success: true,
-		Message: "Kits retrieved successfully.",
-		Data:    kits,
-		Error:   nil,
+		Success:    true,
+		Message:    i18n.T(ctx.Request.Context(), "kit.list_retrieved"),
+		Data:       kits,
+		Error:      nil,
+		Pagination: page,
 	})
 }
@@ -0,0 +1,76 @@
+package controllers
+
+import (
+	"api-order/src/kit/application"
+	"api-order/src/kit/infrastructure/http/request"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/middlewares"
+	"api-order/src/shared/responses"
+	"api-order/src/shared/validation"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UpdateSamplingIntervalController struct {
+	KitService *application.UpdateKitSamplingIntervalUseCase
+}
+
+func NewUpdateSamplingIntervalController(kitService *application.UpdateKitSamplingIntervalUseCase) *UpdateSamplingIntervalController {
+	return &UpdateSamplingIntervalController{
+		KitService: kitService,
+	}
+}
+
+// @Summary      Update kit sampling interval
+// @Description  Sets how often the kit is expected to send readings. It is used to find gaps in the data.
+// @Tags         Kits
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        kit_id    path  int  true  "Kit ID" Format(int64)
+// @Param        interval  body  request.UpdateSamplingIntervalRequest true "Expected seconds between readings"
+// @Success      200  {object}  responses.Response{data=entities.Kit} "Sampling interval updated successfully"
+// @Failure      400  {object}  responses.Response "Invalid Kit ID or interval"
+// @Failure      401  {object}  responses.Response "Unauthorized"
+// @Failure      403  {object}  responses.Response "Kit belongs to another user"
+// @Failure      404  {object}  responses.Response "Kit not found"
+// @Failure      500  {object}  responses.Response "Internal server error"
+// @Failure      504  {object}  responses.Response "Operation timed out"
+// @Router       /v1/kits/{kit_id}/sampling-interval [put]
+func (ctr *UpdateSamplingIntervalController) Run(ctx *gin.Context) {
+	kitID, err := strconv.ParseInt(ctx.Param("kit_id"), 10, 64)
+	if err != nil || kitID <= 0 {
+		ctx.Error(domainerrors.InvalidParameter("kit_id", "must be a positive integer"))
+		return
+	}
+
+	var req request.UpdateSamplingIntervalRequest
+	if err := validation.BindJSON(ctx, &req); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	claimsData, exists := ctx.Get("datUser")
+	customClaims, ok := claimsData.(*middlewares.CustomClaims)
+	if !exists || !ok {
+		ctx.Error(middlewares.ErrMissingToken)
+		return
+	}
+
+	kit, err := ctr.KitService.Run(ctx.Request.Context(), kitID, customClaims.ClientID, req.SamplingIntervalSeconds)
+	if err != nil {
+		// Invalid interval, unknown kit and kit of another user answer 400, 404 and 403
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, responses.Response{
+		Success: true,
+		Message: i18n.T(ctx.Request.Context(), "kit.sampling_interval_updated"),
+		Data:    kit,
+		Error:   nil,
+	})
+}
@@ -4,6 +4,13 @@ package request
 type CreateKitRequest struct {
 	Name        string `json:"name" validate:"required,min=3,max=100"`
 	Description string `json:"description" validate:"required"` // Assuming description is mandatory
+	// Expected seconds between readings; defaults to 300 when omitted
+	SamplingIntervalSeconds int `json:"sampling_interval_seconds" validate:"omitempty,min=10,max=86400"`
+}
+
+// Request struct for changing how often a kit is expected to report
+type UpdateSamplingIntervalRequest struct {
+	SamplingIntervalSeconds int `json:"sampling_interval_seconds" validate:"required,min=10,max=86400"`
 }
 
 // No request body needed for GetKits by logged-in user
@@ -8,13 +8,15 @@ import (
 )
 
 // KitRoutes configures routes for the kit feature
-func KitRoutes(router *gin.RouterGroup) {
+func KitRoutes(router *gin.RouterGroup, deps *kithttp.Dependencies) {
 	// Initialize controllers using the setup functions from Dependencies.go
-	createKitController := kithttp.SetUpCreateKitController()
-	getKitsController := kithttp.SetUpGetKitsController()
+	createKitController := deps.SetUpCreateKitController()
+	getKitsController := deps.SetUpGetKitsController()
+	updateSamplingIntervalController := deps.SetUpUpdateSamplingIntervalController()
 
 	// Apply JWTAuthMiddleware to protect these routes
 	// The middleware runs first, setting 'datUser' in context if valid
 	router.POST("/", middlewares.JWTAuthMiddleware(), createKitController.Run)
 	router.GET("/", middlewares.JWTAuthMiddleware(), getKitsController.Run)
+	router.PUT("/:kit_id/sampling-interval", middlewares.JWTAuthMiddleware(), updateSamplingIntervalController.Run)
 }
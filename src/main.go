@@ -2,17 +2,25 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
 
+	database "api-order/src/Database"
+	"api-order/src/config"
+	gardenDataCli "api-order/src/gardendata/infrastructure/cli"
 	"api-order/src/server" // Asegúrate que la ruta del módulo sea correcta
-
-	"github.com/joho/godotenv"
+	"api-order/src/shared/logging"
+	"api-order/src/shared/tracing"
 )
 
 // @title           API Hexagonal Go (Sensor Kits)
 // @version         1.0
-// @description     API para gestionar kits de sensores y sus datos.
+// @description     API para gestionar kits de sensores y sus datos. Los mensajes se devuelven en español (es) o inglés (en) según la preferencia del usuario o Accept-Language.
 // @termsOfService  http://swagger.io/terms/
 
 // @contact.name   API Support
@@ -31,20 +39,76 @@ import (
 // @description Type "Bearer" followed by a space and JWT token.
 
 func main() {
-	err := godotenv.Load()
-	if err != nil {
-		log.Println("No .env file found, using system environment variables")
+	// Subcommands: `api import ...` loads historical readings from a CSV file,
+	// `api migrate up|down|status` manages the database schema
+	if len(os.Args) > 1 && (os.Args[1] == "import" || os.Args[1] == "migrate") {
+		if err := runCommand(os.Args[1], os.Args[2:]); err != nil {
+			log.Fatalf("%s failed: %v", os.Args[1], err)
+		}
+		return
 	}
 
 	// Reemplaza localhost:8080 en @host si usas variables de entorno
-	HOST := os.Getenv("HOST_SERVER")
-	PORT := os.Getenv("PORT_SERVER")
-	// swaggerHost := HOST + ":" + PORT // Puedes usar esto para el @host
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	if err := logging.Setup(os.Stderr, cfg.Logging.Format, cfg.Logging.Level); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+
+	srv, err := server.NewServer(cfg)
+	if err != nil {
+		slog.Error("Failed to start server", "error", err)
+		os.Exit(1)
+	}
+	// SIGTERM (e.g. from Docker or Kubernetes) drains the server instead of killing it
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	runErr := srv.Run(ctx)
 
-	if HOST == "" || PORT == "" {
-		log.Fatal("HOST_SERVER or PORT_SERVER is not set")
+	// Flush the spans still buffered before the process exits
+	flushCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	if err := shutdownTracing(flushCtx); err != nil {
+		slog.Error("Failed to flush traces", "error", err)
 	}
 
-	srv := server.NewServer(HOST, PORT)
-	srv.Run()
+	if runErr != nil {
+		slog.Error("Server stopped with errors", "error", runErr)
+		os.Exit(1)
+	}
+	slog.Info("Server stopped")
+}
+
+// runCommand opens the configured database and runs a subcommand on it.
+func runCommand(name string, args []string) error {
+	dbConfig, err := config.LoadDatabase()
+	if err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	if name == "migrate" {
+		// The command decides what to apply, e.g. `migrate down` must not
+		// reapply what it is about to revert.
+		dbConfig.AutoMigrate = false
+	}
+	db, err := database.Open(dbConfig)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if name == "migrate" {
+		migrator, err := database.NewMigrator(db, dbConfig)
+		if err != nil {
+			return err
+		}
+		return database.RunMigrateCommand(migrator, args, os.Stdout)
+	}
+	c := server.NewContainer(config.Config{Database: dbConfig}, db)
+	return gardenDataCli.RunImportCommand(c.GardenData, c.Metrics, args, os.Stdout)
 }
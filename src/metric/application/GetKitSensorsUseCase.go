@@ -0,0 +1,41 @@
+package application
+
+import (
+	kit "api-order/src/kit/domain/ports"
+	"api-order/src/metric/domain/entities"
+	"api-order/src/metric/domain/ports"
+	"api-order/src/shared/tracing"
+	"context"
+)
+
+type GetKitSensorsUseCase struct {
+	MetricRepository ports.IMetric
+	KitRepository    kit.IKit
+}
+
+func NewGetKitSensorsUseCase(repo ports.IMetric, kitRepo kit.IKit) *GetKitSensorsUseCase {
+	return &GetKitSensorsUseCase{MetricRepository: repo, KitRepository: kitRepo}
+}
+
+// Run returns the sensors declared by a kit owned by userID (empty when it
+// never declared any).
+func (uc *GetKitSensorsUseCase) Run(ctx context.Context, kitID, userID int64) ([]entities.KitSensor, error) {
+	ctx, span := tracing.Start(ctx, "GetKitSensorsUseCase.Run")
+	defer span.End()
+
+	if kitID <= 0 {
+		return nil, ErrInvalidKitID
+	}
+	if _, err := kit.OwnedKit(ctx, uc.KitRepository, kitID, userID); err != nil {
+		return nil, err
+	}
+
+	sensors, err := uc.MetricRepository.GetKitSensors(ctx, kitID)
+	if err != nil {
+		return nil, err
+	}
+	if sensors == nil {
+		return []entities.KitSensor{}, nil
+	}
+	return sensors, nil
+}
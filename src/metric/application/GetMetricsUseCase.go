@@ -0,0 +1,40 @@
+package application
+
+import (
+	"api-order/src/metric/domain/entities"
+	"api-order/src/metric/domain/ports"
+	"api-order/src/shared/pagination"
+	"api-order/src/shared/tracing"
+	"context"
+)
+
+// MetricsPageSpec describes the paging, sorting and filtering accepted by the metrics list.
+var MetricsPageSpec = pagination.Spec{
+	DefaultLimit: 100,
+	MaxLimit:     500,
+	Sorts:        []string{"name", "-name", "created_at", "-created_at"},
+	Filters:      []string{"name"},
+}
+
+type GetMetricsUseCase struct {
+	MetricRepository ports.IMetric
+}
+
+func NewGetMetricsUseCase(repo ports.IMetric) *GetMetricsUseCase {
+	return &GetMetricsUseCase{MetricRepository: repo}
+}
+
+// Run returns one page of the metric registry.
+func (uc *GetMetricsUseCase) Run(ctx context.Context, params pagination.OffsetParams) ([]entities.Metric, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "GetMetricsUseCase.Run")
+	defer span.End()
+
+	metrics, total, err := uc.MetricRepository.GetAll(ctx, params)
+	if err != nil {
+		return nil, nil, err
+	}
+	if metrics == nil {
+		metrics = []entities.Metric{}
+	}
+	return metrics, pagination.OffsetPage(params, total), nil
+}
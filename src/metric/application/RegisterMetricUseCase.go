@@ -0,0 +1,69 @@
+package application
+
+import (
+	"api-order/src/metric/domain/entities"
+	"api-order/src/metric/domain/ports"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/tracing"
+	"context"
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidMetricName = domainerrors.Validation("invalid_metric_name", "metric name must be lowercase snake_case (2-50 chars)")
+var ErrInvalidMetricRange = domainerrors.Validation("invalid_metric_range", "max_value must be greater than min_value")
+var ErrMetricExists = ports.ErrMetricExists
+var ErrMetricAdminRequired = domainerrors.Forbidden("metric_admin_required", "only metric administrators can register metrics")
+
+type RegisterMetricUseCase struct {
+	MetricRepository ports.IMetric
+	// IsAdmin tells whether the email of the caller may change the registry
+	IsAdmin func(email string) bool
+	// Cache is nil when the registry is not cached in memory
+	Cache ports.IMetricCache
+}
+
+func NewRegisterMetricUseCase(repo ports.IMetric, isAdmin func(email string) bool, cache ports.IMetricCache) *RegisterMetricUseCase {
+	return &RegisterMetricUseCase{MetricRepository: repo, IsAdmin: isAdmin, Cache: cache}
+}
+
+// Run validates and stores a new metric definition in the registry. The
+// registry is shared by every kit, so only administrators may add to it.
+func (uc *RegisterMetricUseCase) Run(ctx context.Context, email, name, unit string, minValue, maxValue float64, description string) (entities.Metric, error) {
+	ctx, span := tracing.Start(ctx, "RegisterMetricUseCase.Run")
+	defer span.End()
+
+	if uc.IsAdmin == nil || !uc.IsAdmin(email) {
+		return entities.Metric{}, ErrMetricAdminRequired
+	}
+	if !entities.IsValidMetricName(name) {
+		return entities.Metric{}, ErrInvalidMetricName
+	}
+	if maxValue <= minValue {
+		return entities.Metric{}, ErrInvalidMetricRange
+	}
+
+	if _, err := uc.MetricRepository.GetByName(ctx, name); err == nil {
+		return entities.Metric{}, ErrMetricExists
+	} else if !errors.Is(err, ports.ErrMetricNotFound) {
+		return entities.Metric{}, fmt.Errorf("failed to check metric existence: %w", err)
+	}
+
+	metric := entities.Metric{
+		Name:        name,
+		Unit:        unit,
+		MinValue:    minValue,
+		MaxValue:    maxValue,
+		Description: description,
+	}
+
+	created, err := uc.MetricRepository.Create(ctx, metric)
+	if err != nil {
+		return entities.Metric{}, fmt.Errorf("failed to register metric: %w", err)
+	}
+	// The name may be cached as unknown by a reading sent before
+	if uc.Cache != nil {
+		uc.Cache.Invalidate()
+	}
+	return created, nil
+}
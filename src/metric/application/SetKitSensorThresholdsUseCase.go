@@ -0,0 +1,61 @@
+package application
+
+import (
+	kit "api-order/src/kit/domain/ports"
+	"api-order/src/metric/domain/entities"
+	"api-order/src/metric/domain/ports"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/tracing"
+	"context"
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidThresholds = domainerrors.Validation("invalid_thresholds", "thresholds must be inside the metric range and min_threshold must not exceed max_threshold")
+
+type SetKitSensorThresholdsUseCase struct {
+	MetricRepository ports.IMetric
+	KitRepository    kit.IKit
+	Statistics       ports.IKitStatistics
+}
+
+func NewSetKitSensorThresholdsUseCase(repo ports.IMetric, kitRepo kit.IKit, statistics ports.IKitStatistics) *SetKitSensorThresholdsUseCase {
+	return &SetKitSensorThresholdsUseCase{MetricRepository: repo, KitRepository: kitRepo, Statistics: statistics}
+}
+
+// Run sets the healthy range of a metric for a kit owned by userID. Either
+// bound may be nil.
+func (uc *SetKitSensorThresholdsUseCase) Run(ctx context.Context, kitID, userID int64, metricName string, minThreshold, maxThreshold *float64) (entities.KitSensor, error) {
+	ctx, span := tracing.Start(ctx, "SetKitSensorThresholdsUseCase.Run")
+	defer span.End()
+
+	if kitID <= 0 {
+		return entities.KitSensor{}, ErrInvalidKitID
+	}
+	if _, err := kit.OwnedKit(ctx, uc.KitRepository, kitID, userID); err != nil {
+		return entities.KitSensor{}, err
+	}
+
+	metric, err := uc.MetricRepository.GetByName(ctx, metricName)
+	if err != nil {
+		if errors.Is(err, ports.ErrMetricNotFound) {
+			return entities.KitSensor{}, fmt.Errorf("%w: %s", ErrUnknownMetric, metricName)
+		}
+		return entities.KitSensor{}, fmt.Errorf("failed to resolve metric %s: %w", metricName, err)
+	}
+
+	if minThreshold != nil && !metric.InRange(*minThreshold) ||
+		maxThreshold != nil && !metric.InRange(*maxThreshold) ||
+		minThreshold != nil && maxThreshold != nil && *minThreshold > *maxThreshold {
+		return entities.KitSensor{}, ErrInvalidThresholds
+	}
+
+	sensor, err := uc.MetricRepository.SetKitSensorThresholds(ctx, kitID, metricName, minThreshold, maxThreshold)
+	if err != nil {
+		return entities.KitSensor{}, fmt.Errorf("failed to update kit sensor thresholds: %w", err)
+	}
+	if uc.Statistics != nil {
+		uc.Statistics.Invalidate(kitID)
+	}
+	return sensor, nil
+}
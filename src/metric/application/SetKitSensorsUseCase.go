@@ -0,0 +1,67 @@
+package application
+
+import (
+	kit "api-order/src/kit/domain/ports"
+	"api-order/src/metric/domain/entities"
+	"api-order/src/metric/domain/ports"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/tracing"
+	"context"
+	"errors"
+	"fmt"
+)
+
+var ErrUnknownMetric = domainerrors.Validation("unknown_metric", "metric is not registered")
+
+// ErrInvalidKitID is returned for a kit id that is not positive.
+var ErrInvalidKitID = domainerrors.Validation("invalid_kit_id", "invalid kit_id provided")
+
+type SetKitSensorsUseCase struct {
+	MetricRepository ports.IMetric
+	KitRepository    kit.IKit
+	Statistics       ports.IKitStatistics
+}
+
+func NewSetKitSensorsUseCase(repo ports.IMetric, kitRepo kit.IKit, statistics ports.IKitStatistics) *SetKitSensorsUseCase {
+	return &SetKitSensorsUseCase{MetricRepository: repo, KitRepository: kitRepo, Statistics: statistics}
+}
+
+// Run replaces the sensors declared by a kit owned by userID. Every metric
+// must already be registered. Metrics dropped from the list lose their thresholds.
+func (uc *SetKitSensorsUseCase) Run(ctx context.Context, kitID, userID int64, metricNames []string) ([]entities.KitSensor, error) {
+	ctx, span := tracing.Start(ctx, "SetKitSensorsUseCase.Run")
+	defer span.End()
+
+	if kitID <= 0 {
+		return nil, ErrInvalidKitID
+	}
+	if _, err := kit.OwnedKit(ctx, uc.KitRepository, kitID, userID); err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(metricNames))
+	unique := make([]string, 0, len(metricNames))
+	for _, name := range metricNames {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		if _, err := uc.MetricRepository.GetByName(ctx, name); err != nil {
+			if errors.Is(err, ports.ErrMetricNotFound) {
+				return nil, fmt.Errorf("%w: %s", ErrUnknownMetric, name)
+			}
+			return nil, fmt.Errorf("failed to resolve metric %s: %w", name, err)
+		}
+		unique = append(unique, name)
+	}
+
+	sensors, err := uc.MetricRepository.SetKitSensors(ctx, kitID, unique)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update kit sensors: %w", err)
+	}
+	if uc.Statistics != nil {
+		uc.Statistics.Invalidate(kitID)
+	}
+	return sensors, nil
+}
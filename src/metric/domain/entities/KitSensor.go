@@ -0,0 +1,25 @@
+package entities
+
+import "time"
+
+// KitSensor declares that a kit carries a sensor for the given metric.
+// The optional thresholds bound the values the user considers healthy for the
+// plants, and are narrower than the metric's physically valid range.
+type KitSensor struct {
+	KitID        int64     `json:"kit_id"`
+	MetricName   string    `json:"metric_name"`
+	MinThreshold *float64  `json:"min_threshold"`
+	MaxThreshold *float64  `json:"max_threshold"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// WithinThresholds reports whether value respects the configured thresholds.
+func (s *KitSensor) WithinThresholds(value float64) bool {
+	if s.MinThreshold != nil && value < *s.MinThreshold {
+		return false
+	}
+	if s.MaxThreshold != nil && value > *s.MaxThreshold {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package entities
+
+import (
+	"regexp"
+	"time"
+)
+
+// Names of the metrics every kit has reported since the first firmware.
+// They map one to one to the fixed columns of the garden_data table.
+const (
+	MetricTemperature         = "temperature"
+	MetricGroundHumidity      = "ground_humidity"
+	MetricEnvironmentHumidity = "environment_humidity"
+	MetricPhLevel             = "ph_level"
+)
+
+var metricNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,49}$`)
+
+// Metric describes a kind of sensor reading (temperature, co2, light...)
+// together with its unit and the physically valid range of values.
+type Metric struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Unit        string    `json:"unit"`
+	MinValue    float64   `json:"min_value"`
+	MaxValue    float64   `json:"max_value"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// InRange reports whether value is inside the valid range of the metric.
+func (m *Metric) InRange(value float64) bool {
+	return value >= m.MinValue && value <= m.MaxValue
+}
+
+// IsValidMetricName checks that a metric name is lowercase snake_case.
+func IsValidMetricName(name string) bool {
+	return metricNamePattern.MatchString(name)
+}
+
+// IsBuiltinMetric reports whether name is one of the four legacy metrics.
+func IsBuiltinMetric(name string) bool {
+	switch name {
+	case MetricTemperature, MetricGroundHumidity, MetricEnvironmentHumidity, MetricPhLevel:
+		return true
+	default:
+		return false
+	}
+}
+
+// BuiltinMetrics returns the definitions seeded into the registry so the
+// legacy fixed payload keeps validating against it.
+func BuiltinMetrics() []Metric {
+	return []Metric{
+		{Name: MetricTemperature, Unit: "°C", MinValue: -40, MaxValue: 85, Description: "Air temperature"},
+		{Name: MetricGroundHumidity, Unit: "%", MinValue: 0, MaxValue: 100, Description: "Soil moisture"},
+		{Name: MetricEnvironmentHumidity, Unit: "%", MinValue: 0, MaxValue: 100, Description: "Relative air humidity"},
+		{Name: MetricPhLevel, Unit: "pH", MinValue: 0, MaxValue: 14, Description: "Soil pH"},
+	}
+}
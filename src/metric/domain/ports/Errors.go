@@ -0,0 +1,9 @@
+package ports
+
+import "api-order/src/shared/domainerrors"
+
+// ErrMetricNotFound is returned by repositories when a metric name is not registered.
+var ErrMetricNotFound = domainerrors.NotFound("metric_not_found", "metric not found")
+
+// ErrMetricExists is returned by Create when the metric name is already registered.
+var ErrMetricExists = domainerrors.Conflict("metric_exists", "metric already registered")
@@ -0,0 +1,40 @@
+package ports
+
+import (
+	"api-order/src/metric/domain/entities"
+	"api-order/src/shared/pagination"
+	"context"
+)
+
+// IMetric defines the interface for the metric registry repository.
+type IMetric interface {
+	// Create registers a new metric definition.
+	Create(ctx context.Context, metric entities.Metric) (entities.Metric, error)
+	// GetAll returns one page of the registered metrics and the total number matching the filters.
+	GetAll(ctx context.Context, page pagination.OffsetParams) ([]entities.Metric, int64, error)
+	// GetByName returns a single metric definition.
+	GetByName(ctx context.Context, name string) (entities.Metric, error)
+	// EnsureMetrics inserts the given definitions when they are not registered yet.
+	EnsureMetrics(ctx context.Context, metrics []entities.Metric) error
+
+	// GetKitSensors returns the metrics a kit declares to carry.
+	GetKitSensors(ctx context.Context, kitID int64) ([]entities.KitSensor, error)
+	// SetKitSensors replaces the list of metrics a kit carries, keeping the
+	// thresholds of the metrics that stay in the list.
+	SetKitSensors(ctx context.Context, kitID int64, metricNames []string) ([]entities.KitSensor, error)
+	// SetKitSensorThresholds sets the thresholds of a kit sensor, declaring it if needed.
+	SetKitSensorThresholds(ctx context.Context, kitID int64, metricName string, minThreshold, maxThreshold *float64) (entities.KitSensor, error)
+}
+
+// IKitStatistics is told when the thresholds of a kit change, so statistics
+// computed with the old ones are dropped.
+type IKitStatistics interface {
+	// Invalidate drops every cached statistic of the kit.
+	Invalidate(kitID int64)
+}
+
+// IMetricCache is implemented by registries that keep definitions in memory.
+type IMetricCache interface {
+	// Invalidate drops the cached definitions, e.g. after a metric is registered.
+	Invalidate()
+}
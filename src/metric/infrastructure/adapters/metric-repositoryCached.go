@@ -0,0 +1,70 @@
+package adapters
+
+import (
+	"api-order/src/metric/domain/entities"
+	"api-order/src/metric/domain/ports"
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+type cachedMetric struct {
+	metric    entities.Metric
+	found     bool
+	expiresAt time.Time
+}
+
+// MetricRepositoryCached keeps the metric definitions looked up by name in
+// process memory, so validating readings does not query the registry for
+// every metric of every reading. Unknown names are cached too. Entries expire
+// after ttl, so metrics registered by other instances are picked up; the
+// instance that registers a metric calls Invalidate.
+type MetricRepositoryCached struct {
+	ports.IMetric
+
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cachedMetric
+}
+
+func NewMetricRepositoryCached(inner ports.IMetric, ttl time.Duration) *MetricRepositoryCached {
+	return &MetricRepositoryCached{IMetric: inner, ttl: ttl, entries: make(map[string]cachedMetric)}
+}
+
+// GetByName implements ports.IMetric
+func (r *MetricRepositoryCached) GetByName(ctx context.Context, name string) (entities.Metric, error) {
+	r.mu.Lock()
+	entry, ok := r.entries[name]
+	r.mu.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		if !entry.found {
+			return entities.Metric{}, ports.ErrMetricNotFound
+		}
+		return entry.metric, nil
+	}
+
+	metric, err := r.IMetric.GetByName(ctx, name)
+	if err != nil && !errors.Is(err, ports.ErrMetricNotFound) {
+		return entities.Metric{}, err
+	}
+
+	r.mu.Lock()
+	r.entries[name] = cachedMetric{metric: metric, found: err == nil, expiresAt: time.Now().Add(r.ttl)}
+	r.mu.Unlock()
+	return metric, err
+}
+
+// EnsureMetrics implements ports.IMetric
+func (r *MetricRepositoryCached) EnsureMetrics(ctx context.Context, metrics []entities.Metric) error {
+	defer r.Invalidate()
+	return r.IMetric.EnsureMetrics(ctx, metrics)
+}
+
+// Invalidate implements ports.IMetricCache
+func (r *MetricRepositoryCached) Invalidate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries = make(map[string]cachedMetric)
+}
@@ -0,0 +1,178 @@
+package adapters
+
+import (
+	"api-order/src/metric/domain/entities"
+	"api-order/src/metric/domain/ports"
+	"api-order/src/shared/pagination"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+type kitSensorKey struct {
+	kitID  int64
+	metric string
+}
+
+// MetricRepositoryMemory keeps the metric catalog and kit sensors in process
+// memory. It backs the HTTP test suite.
+type MetricRepositoryMemory struct {
+	mu      sync.RWMutex
+	nextID  int64
+	metrics map[string]entities.Metric
+	sensors map[kitSensorKey]entities.KitSensor
+}
+
+func NewMetricRepositoryMemory() *MetricRepositoryMemory {
+	return &MetricRepositoryMemory{
+		metrics: make(map[string]entities.Metric),
+		sensors: make(map[kitSensorKey]entities.KitSensor),
+	}
+}
+
+// Create implements ports.IMetric
+func (r *MetricRepositoryMemory) Create(ctx context.Context, metric entities.Metric) (entities.Metric, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.metrics[metric.Name]; exists {
+		return entities.Metric{}, ports.ErrMetricExists
+	}
+	return r.insert(metric), nil
+}
+
+func (r *MetricRepositoryMemory) insert(metric entities.Metric) entities.Metric {
+	r.nextID++
+	metric.ID = r.nextID
+	metric.CreatedAt = time.Now()
+	r.metrics[metric.Name] = metric
+	return metric
+}
+
+// GetAll implements ports.IMetric
+func (r *MetricRepositoryMemory) GetAll(ctx context.Context, page pagination.OffsetParams) ([]entities.Metric, int64, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	name, filterByName := page.Filters["name"]
+	matches := []entities.Metric{}
+	for _, metric := range r.metrics {
+		if filterByName && !strings.Contains(strings.ToLower(metric.Name), strings.ToLower(name)) {
+			continue
+		}
+		matches = append(matches, metric)
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if page.Sort.Desc {
+			a, b = b, a
+		}
+		if page.Sort.Field == "created_at" && !a.CreatedAt.Equal(b.CreatedAt) {
+			return a.CreatedAt.Before(b.CreatedAt)
+		}
+		if page.Sort.Field != "created_at" && a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.ID < b.ID
+	})
+
+	total := int64(len(matches))
+	if page.Offset >= len(matches) {
+		return []entities.Metric{}, total, nil
+	}
+	matches = matches[page.Offset:]
+	if len(matches) > page.Limit {
+		matches = matches[:page.Limit]
+	}
+	return matches, total, nil
+}
+
+// GetByName implements ports.IMetric
+func (r *MetricRepositoryMemory) GetByName(ctx context.Context, name string) (entities.Metric, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	metric, ok := r.metrics[name]
+	if !ok {
+		return entities.Metric{}, fmt.Errorf("%w: %s", ports.ErrMetricNotFound, name)
+	}
+	return metric, nil
+}
+
+// EnsureMetrics implements ports.IMetric
+func (r *MetricRepositoryMemory) EnsureMetrics(ctx context.Context, metrics []entities.Metric) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, metric := range metrics {
+		if _, exists := r.metrics[metric.Name]; !exists {
+			r.insert(metric)
+		}
+	}
+	return nil
+}
+
+// GetKitSensors implements ports.IMetric
+func (r *MetricRepositoryMemory) GetKitSensors(ctx context.Context, kitID int64) ([]entities.KitSensor, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.kitSensors(kitID), nil
+}
+
+func (r *MetricRepositoryMemory) kitSensors(kitID int64) []entities.KitSensor {
+	sensors := []entities.KitSensor{}
+	for key, sensor := range r.sensors {
+		if key.kitID == kitID {
+			sensors = append(sensors, sensor)
+		}
+	}
+	sort.Slice(sensors, func(i, j int) bool { return sensors[i].MetricName < sensors[j].MetricName })
+	return sensors
+}
+
+// SetKitSensors implements ports.IMetric
+func (r *MetricRepositoryMemory) SetKitSensors(ctx context.Context, kitID int64, metricNames []string) ([]entities.KitSensor, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	keep := make(map[string]bool, len(metricNames))
+	for _, name := range metricNames {
+		keep[name] = true
+	}
+	for key := range r.sensors {
+		if key.kitID == kitID && !keep[key.metric] {
+			delete(r.sensors, key)
+		}
+	}
+
+	// Existing sensors keep their thresholds
+	now := time.Now()
+	for _, name := range metricNames {
+		key := kitSensorKey{kitID, name}
+		if _, exists := r.sensors[key]; !exists {
+			r.sensors[key] = entities.KitSensor{KitID: kitID, MetricName: name, CreatedAt: now}
+		}
+	}
+	return r.kitSensors(kitID), nil
+}
+
+// SetKitSensorThresholds implements ports.IMetric
+func (r *MetricRepositoryMemory) SetKitSensorThresholds(ctx context.Context, kitID int64, metricName string, minThreshold, maxThreshold *float64) (entities.KitSensor, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := kitSensorKey{kitID, metricName}
+	sensor, exists := r.sensors[key]
+	if !exists {
+		sensor = entities.KitSensor{KitID: kitID, MetricName: metricName, CreatedAt: time.Now()}
+	}
+	sensor.MinThreshold = minThreshold
+	sensor.MaxThreshold = maxThreshold
+	r.sensors[key] = sensor
+	return sensor, nil
+}
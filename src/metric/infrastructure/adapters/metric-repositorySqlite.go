@@ -0,0 +1,255 @@
+package adapters
+
+import (
+	database "api-order/src/Database"
+	"api-order/src/metric/domain/entities"
+	"api-order/src/metric/domain/ports"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/logging"
+	"api-order/src/shared/pagination"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type MetricRepositorySqlite struct {
+	DB       *sql.DB
+	Timeouts database.Timeouts
+}
+
+// NewMetricRepositorySqlite wraps the shared connection pool.
+func NewMetricRepositorySqlite(db *sql.DB, timeouts database.Timeouts) *MetricRepositorySqlite {
+	return &MetricRepositorySqlite{DB: db, Timeouts: timeouts}
+}
+
+// Create implements ports.IMetric
+func (r *MetricRepositorySqlite) Create(ctx context.Context, metric entities.Metric) (entities.Metric, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpWrite)
+	defer cancel()
+
+	query := "INSERT INTO metrics (name, unit, min_value, max_value, description, created_at) VALUES (?, ?, ?, ?, ?, ?)"
+	now := time.Now()
+	result, err := r.DB.ExecContext(ctx, query, database.SQLiteArgs(metric.Name, metric.Unit, metric.MinValue, metric.MaxValue, metric.Description, now)...)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error inserting metric", "metric", metric.Name, "error", err)
+		if err = database.TranslateError(err); errors.Is(err, domainerrors.ErrDuplicate) {
+			return entities.Metric{}, ports.ErrMetricExists.Wrap(err)
+		}
+		return entities.Metric{}, err
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return entities.Metric{}, fmt.Errorf("failed to retrieve last insert ID: %w", err)
+	}
+
+	metric.ID = id
+	metric.CreatedAt = now
+	return metric, nil
+}
+
+// GetAll implements ports.IMetric
+func (r *MetricRepositorySqlite) GetAll(ctx context.Context, page pagination.OffsetParams) ([]entities.Metric, int64, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	where := ""
+	var args []interface{}
+	if name, ok := page.Filters["name"]; ok {
+		where = " WHERE name LIKE ?"
+		args = append(args, "%"+name+"%")
+	}
+
+	var total int64
+	if err := r.DB.QueryRowContext(ctx, "SELECT COUNT(*) FROM metrics"+where, args...).Scan(&total); err != nil {
+		logging.FromContext(ctx).Error("Error counting metrics", "error", err)
+		return nil, 0, err
+	}
+
+	column, ok := metricSortColumns[page.Sort.Field]
+	if !ok {
+		column = "name"
+	}
+	direction := "ASC"
+	if page.Sort.Desc {
+		direction = "DESC"
+	}
+
+	query := "SELECT metric_id, name, unit, min_value, max_value, description, created_at FROM metrics" +
+		where + " ORDER BY " + column + " " + direction + ", metric_id " + direction + " LIMIT ? OFFSET ?"
+	rows, err := r.DB.QueryContext(ctx, query, append(args, page.Limit, page.Offset)...)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error querying metrics", "error", err)
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	metrics := []entities.Metric{}
+	for rows.Next() {
+		metric, err := scanSqliteMetric(rows)
+		if err != nil {
+			logging.FromContext(ctx).Error("Error scanning metric row", "error", err)
+			return nil, 0, err
+		}
+		metrics = append(metrics, metric)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	return metrics, total, nil
+}
+
+// GetByName implements ports.IMetric
+func (r *MetricRepositorySqlite) GetByName(ctx context.Context, name string) (entities.Metric, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	query := "SELECT metric_id, name, unit, min_value, max_value, description, created_at FROM metrics WHERE name = ?"
+	metric, err := scanSqliteMetric(r.DB.QueryRowContext(ctx, query, name))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entities.Metric{}, fmt.Errorf("%w: %s", ports.ErrMetricNotFound, name)
+		}
+		return entities.Metric{}, fmt.Errorf("failed to scan metric %s: %w", name, err)
+	}
+	return metric, nil
+}
+
+// EnsureMetrics implements ports.IMetric
+func (r *MetricRepositorySqlite) EnsureMetrics(ctx context.Context, metrics []entities.Metric) error {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpWrite)
+	defer cancel()
+
+	query := "INSERT OR IGNORE INTO metrics (name, unit, min_value, max_value, description, created_at) VALUES (?, ?, ?, ?, ?, ?)"
+	now := time.Now()
+	for _, metric := range metrics {
+		if _, err := r.DB.ExecContext(ctx, query, database.SQLiteArgs(metric.Name, metric.Unit, metric.MinValue, metric.MaxValue, metric.Description, now)...); err != nil {
+			return fmt.Errorf("failed to seed metric %s: %w", metric.Name, err)
+		}
+	}
+	return nil
+}
+
+// GetKitSensors implements ports.IMetric
+func (r *MetricRepositorySqlite) GetKitSensors(ctx context.Context, kitID int64) ([]entities.KitSensor, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	return r.kitSensors(ctx, r.DB, kitID)
+}
+
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// kitSensors reads the sensors of a kit through db or an open transaction.
+func (r *MetricRepositorySqlite) kitSensors(ctx context.Context, db queryer, kitID int64) ([]entities.KitSensor, error) {
+	query := "SELECT kit_id, metric_name, min_threshold, max_threshold, created_at FROM kit_sensors WHERE kit_id = ? ORDER BY metric_name"
+	rows, err := db.QueryContext(ctx, query, kitID)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error querying sensors for kit", "kit_id", kitID, "error", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	sensors := []entities.KitSensor{}
+	for rows.Next() {
+		sensor, err := scanSqliteKitSensor(rows)
+		if err != nil {
+			return nil, err
+		}
+		sensors = append(sensors, sensor)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return sensors, nil
+}
+
+// SetKitSensors implements ports.IMetric
+func (r *MetricRepositorySqlite) SetKitSensors(ctx context.Context, kitID int64, metricNames []string) ([]entities.KitSensor, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpWrite)
+	defer cancel()
+
+	tx, err := r.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if len(metricNames) == 0 {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM kit_sensors WHERE kit_id = ?", kitID); err != nil {
+			return nil, fmt.Errorf("failed to clear kit sensors: %w", err)
+		}
+	} else {
+		args := []interface{}{kitID}
+		for _, name := range metricNames {
+			args = append(args, name)
+		}
+		query := "DELETE FROM kit_sensors WHERE kit_id = ? AND metric_name NOT IN (?" + strings.Repeat(", ?", len(metricNames)-1) + ")"
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return nil, fmt.Errorf("failed to remove kit sensors: %w", err)
+		}
+	}
+
+	// Existing rows keep their thresholds
+	now := time.Now()
+	for _, name := range metricNames {
+		if _, err := tx.ExecContext(ctx, "INSERT OR IGNORE INTO kit_sensors (kit_id, metric_name, created_at) VALUES (?, ?, ?)", database.SQLiteArgs(kitID, name, now)...); err != nil {
+			logging.FromContext(ctx).Error("Error inserting sensor for kit", "sensor", name, "kit_id", kitID, "error", err)
+			return nil, database.TranslateError(err)
+		}
+	}
+
+	sensors, err := r.kitSensors(ctx, tx, kitID)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit kit sensors: %w", err)
+	}
+	return sensors, nil
+}
+
+// SetKitSensorThresholds implements ports.IMetric
+func (r *MetricRepositorySqlite) SetKitSensorThresholds(ctx context.Context, kitID int64, metricName string, minThreshold, maxThreshold *float64) (entities.KitSensor, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpWrite)
+	defer cancel()
+
+	query := `
+        INSERT INTO kit_sensors (kit_id, metric_name, min_threshold, max_threshold, created_at)
+        VALUES (?, ?, ?, ?, ?)
+        ON CONFLICT (kit_id, metric_name) DO UPDATE SET min_threshold = excluded.min_threshold, max_threshold = excluded.max_threshold
+    `
+	if _, err := r.DB.ExecContext(ctx, query, database.SQLiteArgs(kitID, metricName, minThreshold, maxThreshold, time.Now())...); err != nil {
+		logging.FromContext(ctx).Error("Error setting thresholds for kit", "metric", metricName, "kit_id", kitID, "error", err)
+		return entities.KitSensor{}, database.TranslateError(err)
+	}
+
+	row := r.DB.QueryRowContext(ctx, "SELECT kit_id, metric_name, min_threshold, max_threshold, created_at FROM kit_sensors WHERE kit_id = ? AND metric_name = ?", kitID, metricName)
+	return scanSqliteKitSensor(row)
+}
+
+func scanSqliteMetric(row rowScanner) (entities.Metric, error) {
+	var metric entities.Metric
+	err := row.Scan(&metric.ID, &metric.Name, &metric.Unit, &metric.MinValue, &metric.MaxValue, &metric.Description, database.SQLiteTime(&metric.CreatedAt))
+	return metric, err
+}
+
+func scanSqliteKitSensor(row rowScanner) (entities.KitSensor, error) {
+	var sensor entities.KitSensor
+	var minThreshold, maxThreshold sql.NullFloat64
+	if err := row.Scan(&sensor.KitID, &sensor.MetricName, &minThreshold, &maxThreshold, database.SQLiteTime(&sensor.CreatedAt)); err != nil {
+		return entities.KitSensor{}, err
+	}
+	if minThreshold.Valid {
+		sensor.MinThreshold = &minThreshold.Float64
+	}
+	if maxThreshold.Valid {
+		sensor.MaxThreshold = &maxThreshold.Float64
+	}
+	return sensor, nil
+}
@@ -0,0 +1,57 @@
+package http
+
+import (
+	"api-order/src/config"
+	kit "api-order/src/kit/domain/ports"
+	"api-order/src/metric/application"
+	"api-order/src/metric/domain/entities"
+	"api-order/src/metric/domain/ports"
+	"api-order/src/metric/infrastructure/http/controllers"
+	"context"
+	"fmt"
+)
+
+// Dependencies holds what the metric controllers need. It is built by the
+// application container and handed to MetricRoutes.
+type Dependencies struct {
+	MetricRepository ports.IMetric
+	KitRepository    kit.IKit
+	// Statistics caches the kit statistics computed with the thresholds
+	Statistics ports.IKitStatistics
+	Config     config.MetricConfig
+}
+
+func NewDependencies(metricRepository ports.IMetric, kitRepository kit.IKit, statistics ports.IKitStatistics, cfg config.MetricConfig) *Dependencies {
+	return &Dependencies{MetricRepository: metricRepository, KitRepository: kitRepository, Statistics: statistics, Config: cfg}
+}
+
+// SeedBuiltinMetrics makes sure the built-in metrics exist in the registry so
+// the legacy fixed payload keeps validating. It runs before data ingestion is served.
+func (d *Dependencies) SeedBuiltinMetrics(ctx context.Context) error {
+	if err := d.MetricRepository.EnsureMetrics(ctx, entities.BuiltinMetrics()); err != nil {
+		return fmt.Errorf("error seeding built-in metrics: %w", err)
+	}
+	return nil
+}
+
+func (d *Dependencies) SetUpRegisterMetricController() *controllers.RegisterMetricController {
+	// The container may wrap the registry in an in-memory cache
+	cache, _ := d.MetricRepository.(ports.IMetricCache)
+	return controllers.NewRegisterMetricController(application.NewRegisterMetricUseCase(d.MetricRepository, d.Config.IsAdmin, cache))
+}
+
+func (d *Dependencies) SetUpGetMetricsController() *controllers.GetMetricsController {
+	return controllers.NewGetMetricsController(application.NewGetMetricsUseCase(d.MetricRepository))
+}
+
+func (d *Dependencies) SetUpSetKitSensorsController() *controllers.SetKitSensorsController {
+	return controllers.NewSetKitSensorsController(application.NewSetKitSensorsUseCase(d.MetricRepository, d.KitRepository, d.Statistics))
+}
+
+func (d *Dependencies) SetUpGetKitSensorsController() *controllers.GetKitSensorsController {
+	return controllers.NewGetKitSensorsController(application.NewGetKitSensorsUseCase(d.MetricRepository, d.KitRepository))
+}
+
+func (d *Dependencies) SetUpSetKitSensorThresholdsController() *controllers.SetKitSensorThresholdsController {
+	return controllers.NewSetKitSensorThresholdsController(application.NewSetKitSensorThresholdsUseCase(d.MetricRepository, d.KitRepository, d.Statistics))
+}
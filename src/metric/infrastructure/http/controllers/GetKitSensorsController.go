@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"api-order/src/metric/application"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/middlewares"
+	"api-order/src/shared/responses"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GetKitSensorsController struct {
+	SensorService *application.GetKitSensorsUseCase
+}
+
+func NewGetKitSensorsController(service *application.GetKitSensorsUseCase) *GetKitSensorsController {
+	return &GetKitSensorsController{SensorService: service}
+}
+
+// @Summary      Get kit sensors
+// @Description  Returns the metrics a kit declared to carry.
+// @Tags         Metrics
+// @Produce      json
+// @Security     BearerAuth
+// @Param        kit_id path int true "Kit ID" Format(int64)
+// @Success      200  {object}  responses.Response{data=[]entities.KitSensor} "Kit sensors retrieved successfully"
+// @Failure      400  {object}  responses.Response "Invalid Kit ID provided"
+// @Failure      401  {object}  responses.Response "Unauthorized"
+// @Failure      403  {object}  responses.Response "Kit belongs to another user"
+// @Failure      404  {object}  responses.Response "Kit not found"
+// @Failure      500  {object}  responses.Response "Internal server error"
+// @Failure      504  {object}  responses.Response "Operation timed out"
+// @Router       /v1/metrics/kit/{kit_id} [get]
+func (ctr *GetKitSensorsController) Run(ctx *gin.Context) {
+	kitID, err := strconv.ParseInt(ctx.Param("kit_id"), 10, 64)
+	if err != nil || kitID <= 0 {
+		ctx.Error(domainerrors.InvalidParameter("kit_id", "must be a positive integer"))
+		return
+	}
+
+	claimsData, exists := ctx.Get("datUser")
+	customClaims, ok := claimsData.(*middlewares.CustomClaims)
+	if !exists || !ok {
+		ctx.Error(middlewares.ErrMissingToken)
+		return
+	}
+
+	sensors, err := ctr.SensorService.Run(ctx.Request.Context(), kitID, customClaims.ClientID)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, responses.Response{
+		Success: true,
+		Message: i18n.T(ctx.Request.Context(), "metric.sensors_retrieved"),
+		Data:    sensors,
+		Error:   nil,
+	})
+}
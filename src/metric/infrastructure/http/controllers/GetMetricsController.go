@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"api-order/src/metric/application"
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/pagination"
+	"api-order/src/shared/responses"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GetMetricsController struct {
+	MetricService *application.GetMetricsUseCase
+}
+
+func NewGetMetricsController(service *application.GetMetricsUseCase) *GetMetricsController {
+	return &GetMetricsController{MetricService: service}
+}
+
+// @Summary      List registered metrics
+// @Description  Returns a page of the metric definitions known to the registry, including the four built-in ones.
+// @Tags         Metrics
+// @Produce      json
+// @Security     BearerAuth
+// @Param        limit         query  int     false  "Page size (max 500)" default(100)
+// @Param        offset        query  int     false  "Metrics to skip" default(0)
+// @Param        sort          query  string  false  "Sort order" Enums(name, -name, created_at, -created_at) default(name)
+// @Param        filter[name]  query  string  false  "Only metrics whose name contains this text"
+// @Success      200  {object}  responses.Response{data=[]entities.Metric} "Metrics retrieved successfully"
+// @Failure      400  {object}  responses.Response "Invalid pagination parameters"
+// @Failure      401  {object}  responses.Response "Unauthorized"
+// @Failure      500  {object}  responses.Response "Internal server error"
+// @Failure      504  {object}  responses.Response "Operation timed out"
+// @Router       /v1/metrics/ [get]
+func (ctr *GetMetricsController) Run(ctx *gin.Context) {
+	params, err := pagination.ParseOffset(ctx.Request.URL.Query(), application.MetricsPageSpec)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	metrics, page, err := ctr.MetricService.Run(ctx.Request.Context(), params)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, responses.Response{
+		Success:    true,
+		Message:    i18n.T(ctx.Request.Context(), "metric.list_retrieved"),
+		Data:       metrics,
+		Error:      nil,
+		Pagination: page,
+	})
+}
@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"api-order/src/metric/application"
+	"api-order/src/metric/infrastructure/http/request"
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/middlewares"
+	"api-order/src/shared/responses"
+	"api-order/src/shared/validation"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RegisterMetricController struct {
+	MetricService *application.RegisterMetricUseCase
+}
+
+func NewRegisterMetricController(service *application.RegisterMetricUseCase) *RegisterMetricController {
+	return &RegisterMetricController{
+		MetricService: service,
+	}
+}
+
+// @Summary      Register a metric
+// @Description  Adds a new metric definition (name, unit and valid range) to the registry so kits can report it. Only the administrators listed in METRIC_ADMIN_EMAILS may register metrics.
+// @Tags         Metrics
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        metric body request.RegisterMetricRequest true "Metric definition"
+// @Success      201  {object}  responses.Response{data=entities.Metric} "Metric registered successfully"
+// @Failure      400  {object}  responses.Response "Invalid request body or validation failed"
+// @Failure      401  {object}  responses.Response "Unauthorized"
+// @Failure      403  {object}  responses.Response "User is not a metric administrator"
+// @Failure      409  {object}  responses.Response "Metric already registered"
+// @Failure      500  {object}  responses.Response "Internal server error"
+// @Failure      504  {object}  responses.Response "Operation timed out"
+// @Router       /v1/metrics/ [post]
+func (ctr *RegisterMetricController) Run(ctx *gin.Context) {
+	var req request.RegisterMetricRequest
+
+	if err := validation.BindJSON(ctx, &req); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	claimsData, exists := ctx.Get("datUser")
+	customClaims, ok := claimsData.(*middlewares.CustomClaims)
+	if !exists || !ok {
+		ctx.Error(middlewares.ErrMissingToken)
+		return
+	}
+
+	metric, err := ctr.MetricService.Run(ctx.Request.Context(), customClaims.Email, req.Name, req.Unit, req.MinValue, req.MaxValue, req.Description)
+	if err != nil {
+		// Invalid definitions answer 400, non administrators 403 and names already registered 409
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, responses.Response{
+		Success: true,
+		Message: i18n.T(ctx.Request.Context(), "metric.registered"),
+		Data:    metric,
+		Error:   nil,
+	})
+}
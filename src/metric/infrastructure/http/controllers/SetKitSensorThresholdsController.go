@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"api-order/src/metric/application"
+	"api-order/src/metric/infrastructure/http/request"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/middlewares"
+	"api-order/src/shared/responses"
+	"api-order/src/shared/validation"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SetKitSensorThresholdsController struct {
+	ThresholdService *application.SetKitSensorThresholdsUseCase
+}
+
+func NewSetKitSensorThresholdsController(service *application.SetKitSensorThresholdsUseCase) *SetKitSensorThresholdsController {
+	return &SetKitSensorThresholdsController{ThresholdService: service}
+}
+
+// @Summary      Set kit sensor thresholds
+// @Description  Sets the healthy min/max range of a metric for a kit, declaring the sensor if needed. Statistics report the percentage of time inside this range.
+// @Tags         Metrics
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        kit_id      path  int     true  "Kit ID" Format(int64)
+// @Param        metric      path  string  true  "Metric name"
+// @Param        thresholds  body  request.SetKitSensorThresholdsRequest true "Threshold values"
+// @Success      200  {object}  responses.Response{data=entities.KitSensor} "Thresholds updated successfully"
+// @Failure      400  {object}  responses.Response "Invalid Kit ID, unknown metric or invalid thresholds"
+// @Failure      401  {object}  responses.Response "Unauthorized"
+// @Failure      403  {object}  responses.Response "Kit belongs to another user"
+// @Failure      404  {object}  responses.Response "Kit not found"
+// @Failure      500  {object}  responses.Response "Internal server error"
+// @Failure      504  {object}  responses.Response "Operation timed out"
+// @Router       /v1/metrics/kit/{kit_id}/thresholds/{metric} [put]
+func (ctr *SetKitSensorThresholdsController) Run(ctx *gin.Context) {
+	kitID, err := strconv.ParseInt(ctx.Param("kit_id"), 10, 64)
+	if err != nil || kitID <= 0 {
+		ctx.Error(domainerrors.InvalidParameter("kit_id", "must be a positive integer"))
+		return
+	}
+
+	var req request.SetKitSensorThresholdsRequest
+	if err := validation.BindJSON(ctx, &req); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	claimsData, exists := ctx.Get("datUser")
+	customClaims, ok := claimsData.(*middlewares.CustomClaims)
+	if !exists || !ok {
+		ctx.Error(middlewares.ErrMissingToken)
+		return
+	}
+
+	metricName := ctx.Param("metric")
+	sensor, err := ctr.ThresholdService.Run(ctx.Request.Context(), kitID, customClaims.ClientID, metricName, req.MinThreshold, req.MaxThreshold)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, responses.Response{
+		Success: true,
+		Message: i18n.T(ctx.Request.Context(), "metric.thresholds_updated"),
+		Data:    sensor,
+		Error:   nil,
+	})
+}
@@ -0,0 +1,75 @@
+package controllers
+
+import (
+	"api-order/src/metric/application"
+	"api-order/src/metric/infrastructure/http/request"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/middlewares"
+	"api-order/src/shared/responses"
+	"api-order/src/shared/validation"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SetKitSensorsController struct {
+	SensorService *application.SetKitSensorsUseCase
+}
+
+func NewSetKitSensorsController(service *application.SetKitSensorsUseCase) *SetKitSensorsController {
+	return &SetKitSensorsController{
+		SensorService: service,
+	}
+}
+
+// @Summary      Declare kit sensors
+// @Description  Replaces the list of metrics a kit carries. Ingestion rejects non built-in metrics the kit did not declare.
+// @Tags         Metrics
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        kit_id path int true "Kit ID" Format(int64)
+// @Param        sensors body request.SetKitSensorsRequest true "Metric names carried by the kit"
+// @Success      200  {object}  responses.Response{data=[]entities.KitSensor} "Kit sensors updated successfully"
+// @Failure      400  {object}  responses.Response "Invalid Kit ID, request body or unknown metric"
+// @Failure      401  {object}  responses.Response "Unauthorized"
+// @Failure      403  {object}  responses.Response "Kit belongs to another user"
+// @Failure      404  {object}  responses.Response "Kit not found"
+// @Failure      500  {object}  responses.Response "Internal server error"
+// @Failure      504  {object}  responses.Response "Operation timed out"
+// @Router       /v1/metrics/kit/{kit_id} [put]
+func (ctr *SetKitSensorsController) Run(ctx *gin.Context) {
+	kitID, err := strconv.ParseInt(ctx.Param("kit_id"), 10, 64)
+	if err != nil || kitID <= 0 {
+		ctx.Error(domainerrors.InvalidParameter("kit_id", "must be a positive integer"))
+		return
+	}
+
+	var req request.SetKitSensorsRequest
+	if err := validation.BindJSON(ctx, &req); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	claimsData, exists := ctx.Get("datUser")
+	customClaims, ok := claimsData.(*middlewares.CustomClaims)
+	if !exists || !ok {
+		ctx.Error(middlewares.ErrMissingToken)
+		return
+	}
+
+	sensors, err := ctr.SensorService.Run(ctx.Request.Context(), kitID, customClaims.ClientID, req.Metrics)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, responses.Response{
+		Success: true,
+		Message: i18n.T(ctx.Request.Context(), "metric.sensors_updated"),
+		Data:    sensors,
+		Error:   nil,
+	})
+}
@@ -0,0 +1,21 @@
+package request
+
+// Request body for registering a new metric definition
+type RegisterMetricRequest struct {
+	Name        string  `json:"name" validate:"required,min=2,max=50"`
+	Unit        string  `json:"unit" validate:"required,max=20"`
+	MinValue    float64 `json:"min_value"`
+	MaxValue    float64 `json:"max_value" validate:"gtfield=MinValue"`
+	Description string  `json:"description" validate:"max=255"`
+}
+
+// Request body for declaring the sensors carried by a kit
+type SetKitSensorsRequest struct {
+	Metrics []string `json:"metrics" validate:"required,dive,required"`
+}
+
+// Request body for setting the healthy range of a kit sensor. Omit a bound to clear it.
+type SetKitSensorThresholdsRequest struct {
+	MinThreshold *float64 `json:"min_threshold"`
+	MaxThreshold *float64 `json:"max_threshold"`
+}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	metrichttp "api-order/src/metric/infrastructure/http" // Alias import
+	"api-order/src/shared/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MetricRoutes configures routes for the metric registry and kit sensors
+func MetricRoutes(router *gin.RouterGroup, deps *metrichttp.Dependencies) {
+	registerMetricController := deps.SetUpRegisterMetricController()
+	getMetricsController := deps.SetUpGetMetricsController()
+	setKitSensorsController := deps.SetUpSetKitSensorsController()
+	getKitSensorsController := deps.SetUpGetKitSensorsController()
+	setThresholdsController := deps.SetUpSetKitSensorThresholdsController()
+
+	router.GET("/", middlewares.JWTAuthMiddleware(), getMetricsController.Run)
+	router.POST("/", middlewares.JWTAuthMiddleware(), registerMetricController.Run)
+	router.GET("/kit/:kit_id", middlewares.JWTAuthMiddleware(), getKitSensorsController.Run)
+	router.PUT("/kit/:kit_id", middlewares.JWTAuthMiddleware(), setKitSensorsController.Run)
+	router.PUT("/kit/:kit_id/thresholds/:metric", middlewares.JWTAuthMiddleware(), setThresholdsController.Run)
+}
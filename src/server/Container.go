@@ -0,0 +1,204 @@
+package server
+
+import (
+	database "api-order/src/Database"
+	alert "api-order/src/alert/domain/ports"
+	alertAdpt "api-order/src/alert/infrastructure/adapters"
+	alertHttp "api-order/src/alert/infrastructure/http"
+	"api-order/src/config"
+	gardenData "api-order/src/gardendata/domain/ports"
+	dataAdpt "api-order/src/gardendata/infrastructure/adapters"
+	dataHttp "api-order/src/gardendata/infrastructure/http"
+	kit "api-order/src/kit/domain/ports"
+	kitAdpt "api-order/src/kit/infrastructure/adapters"
+	kitHttp "api-order/src/kit/infrastructure/http"
+	metric "api-order/src/metric/domain/ports"
+	metricAdpt "api-order/src/metric/infrastructure/adapters"
+	metricHttp "api-order/src/metric/infrastructure/http"
+	"api-order/src/shared/monitoring"
+	"api-order/src/shared/preferences"
+	user "api-order/src/user/domain/ports"
+	userAdpt "api-order/src/user/infrastructure/adapters"
+	userHttp "api-order/src/user/infrastructure/http"
+	"context"
+	"database/sql"
+)
+
+// Container is the composition root of the API: the configuration, the shared
+// connection pool and one instance of every repository. Modules receive their
+// dependencies from it instead of connecting on their own.
+type Container struct {
+	Config config.Config
+	DB     *sql.DB
+	// Timeouts bounds the queries of every repository built on DB.
+	Timeouts database.Timeouts
+
+	Users           user.IUser
+	Kits            kit.IKit
+	Alerts          alert.IAlert
+	Metrics         metric.IMetric
+	GardenData      gardenData.IGardenData
+	Rollups         gardenData.IGardenDataRollup
+	AnomalySettings gardenData.IAnomalySettings
+	// Statistics caches the computed kit statistics. The garden data module
+	// fills it and the metric module drops a kit when its thresholds change.
+	Statistics gardenData.IStatisticsCache
+}
+
+// NewContainer builds the repositories of cfg.Database.Driver on top of db.
+func NewContainer(cfg config.Config, db *sql.DB) *Container {
+	timeouts := database.NewTimeouts(cfg.Database)
+	switch cfg.Database.Driver {
+	case config.DriverSQLite:
+		return newSQLiteContainer(cfg, db, timeouts)
+	case config.DriverPostgres:
+		return newPostgresContainer(cfg, db, timeouts)
+	}
+	return &Container{
+		Config:          cfg,
+		DB:              db,
+		Timeouts:        timeouts,
+		Users:           userAdpt.NewUserRepositoryMysql(db, timeouts),
+		Kits:            kitAdpt.NewKitRepositoryMysql(db, timeouts),
+		Alerts:          alertAdpt.NewAlertRepositoryMysql(db, timeouts),
+		Metrics:         metricAdpt.NewMetricRepositoryMysql(db, timeouts),
+		GardenData:      dataAdpt.NewGardenDataRepositoryMysql(db, timeouts),
+		Rollups:         dataAdpt.NewGardenDataRollupRepositoryMysql(db, timeouts),
+		AnomalySettings: dataAdpt.NewAnomalySettingsRepositoryMysql(db, timeouts),
+	}
+}
+
+func newSQLiteContainer(cfg config.Config, db *sql.DB, timeouts database.Timeouts) *Container {
+	return &Container{
+		Config:          cfg,
+		DB:              db,
+		Timeouts:        timeouts,
+		Users:           userAdpt.NewUserRepositorySqlite(db, timeouts),
+		Kits:            kitAdpt.NewKitRepositorySqlite(db, timeouts),
+		Alerts:          alertAdpt.NewAlertRepositorySqlite(db, timeouts),
+		Metrics:         metricAdpt.NewMetricRepositorySqlite(db, timeouts),
+		GardenData:      dataAdpt.NewGardenDataRepositorySqlite(db, timeouts),
+		Rollups:         dataAdpt.NewGardenDataRollupRepositorySqlite(db, timeouts),
+		AnomalySettings: dataAdpt.NewAnomalySettingsRepositorySqlite(db, timeouts),
+	}
+}
+
+func newPostgresContainer(cfg config.Config, db *sql.DB, timeouts database.Timeouts) *Container {
+	timescale := cfg.Database.Timescale
+	return &Container{
+		Config:          cfg,
+		DB:              db,
+		Timeouts:        timeouts,
+		Users:           userAdpt.NewUserRepositoryPostgres(db, timeouts),
+		Kits:            kitAdpt.NewKitRepositoryPostgres(db, timeouts),
+		Alerts:          alertAdpt.NewAlertRepositoryPostgres(db, timeouts),
+		Metrics:         metricAdpt.NewMetricRepositoryPostgres(db, timeouts),
+		GardenData:      dataAdpt.NewGardenDataRepositoryPostgres(db, timescale, timeouts),
+		Rollups:         dataAdpt.NewGardenDataRollupRepositoryPostgres(db, timescale, timeouts),
+		AnomalySettings: dataAdpt.NewAnomalySettingsRepositoryPostgres(db, timeouts),
+	}
+}
+
+// NewMemoryContainer builds in-memory repositories, so the API can be served
+// without a database, e.g. by the HTTP test suite. DB is nil.
+func NewMemoryContainer(cfg config.Config) *Container {
+	gardenData := dataAdpt.NewGardenDataRepositoryMemory()
+	return &Container{
+		Config:          cfg,
+		Timeouts:        database.NewTimeouts(cfg.Database),
+		Users:           userAdpt.NewUserRepositoryMemory(),
+		Kits:            kitAdpt.NewKitRepositoryMemory(),
+		Alerts:          alertAdpt.NewAlertRepositoryMemory(),
+		Metrics:         metricAdpt.NewMetricRepositoryMemory(),
+		GardenData:      gardenData,
+		Rollups:         dataAdpt.NewGardenDataRollupRepositoryMemory(gardenData),
+		AnomalySettings: dataAdpt.NewAnomalySettingsRepositoryMemory(),
+	}
+}
+
+// cacheMetrics serves metric definitions from memory, so readings are
+// validated without a registry query per metric.
+func (c *Container) cacheMetrics() {
+	if _, ok := c.Metrics.(*metricAdpt.MetricRepositoryCached); !ok {
+		c.Metrics = metricAdpt.NewMetricRepositoryCached(c.Metrics, c.Config.Metric.RegistryCacheTTL)
+	}
+}
+
+// cacheUsers serves the users behind the tokens from memory, so the
+// preferences of each authenticated request are not read from the database.
+func (c *Container) cacheUsers() {
+	if _, ok := c.Users.(*userAdpt.UserRepositoryCached); !ok {
+		c.Users = userAdpt.NewUserRepositoryCached(c.Users, c.Config.JWT.UserCacheTTL)
+	}
+}
+
+// userPreferences loads the stored display preferences of a user.
+func (c *Container) userPreferences(ctx context.Context, userID int64) (preferences.Preferences, error) {
+	u, err := c.Users.GetById(ctx, userID)
+	if err != nil {
+		return preferences.Preferences{}, err
+	}
+	return u.Preferences(), nil
+}
+
+// instrument exposes the pool statistics and counts the domain events stored
+// through the repositories, whatever the backend.
+func (c *Container) instrument() {
+	monitoring.SetDB(c.DB, c.Config.Database.Driver)
+	if _, ok := c.Alerts.(*alertAdpt.AlertRepositoryInstrumented); !ok {
+		c.Alerts = alertAdpt.NewAlertRepositoryInstrumented(c.Alerts)
+	}
+	if _, ok := c.GardenData.(*dataAdpt.GardenDataRepositoryInstrumented); !ok {
+		c.GardenData = dataAdpt.NewGardenDataRepositoryInstrumented(c.GardenData, c.Config.GardenData.ReadingsByKit)
+	}
+}
+
+// Close releases the connection pool, if any.
+func (c *Container) Close() error {
+	if c.DB == nil {
+		return nil
+	}
+	return c.DB.Close()
+}
+
+// UserDependencies returns what the user routes need.
+func (c *Container) UserDependencies() (*userHttp.Dependencies, error) {
+	return userHttp.NewDependencies(c.Users, c.Kits)
+}
+
+// KitDependencies returns what the kit routes need.
+func (c *Container) KitDependencies() *kitHttp.Dependencies {
+	return kitHttp.NewDependencies(c.Kits)
+}
+
+// AlertDependencies returns what the alert routes need.
+func (c *Container) AlertDependencies() *alertHttp.Dependencies {
+	return alertHttp.NewDependencies(c.Alerts)
+}
+
+// MetricDependencies returns what the metric routes need.
+func (c *Container) MetricDependencies() *metricHttp.Dependencies {
+	return metricHttp.NewDependencies(c.Metrics, c.Kits, c.statistics(), c.Config.Metric)
+}
+
+// GardenDataDependencies returns what the garden data routes and jobs need.
+func (c *Container) GardenDataDependencies() *dataHttp.Dependencies {
+	return dataHttp.NewDependencies(dataHttp.Repositories{
+		GardenData:      c.GardenData,
+		Rollups:         c.Rollups,
+		AnomalySettings: c.AnomalySettings,
+		Metrics:         c.Metrics,
+		Alerts:          c.Alerts,
+		Kits:            c.Kits,
+		Users:           c.Users,
+		Statistics:      c.statistics(),
+	}, c.Config.GardenData)
+}
+
+// statistics returns the statistics cache, creating it on first use.
+func (c *Container) statistics() gardenData.IStatisticsCache {
+	if c.Statistics == nil {
+		c.Statistics = dataAdpt.NewStatisticsCacheMemory(c.Config.GardenData.StatisticsCacheTTL)
+	}
+	return c.Statistics
+}
@@ -0,0 +1,76 @@
+package server
+
+import (
+	database "api-order/src/Database"
+	"api-order/src/shared/buildinfo"
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/monitoring"
+	"api-order/src/shared/responses"
+	"log/slog"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DependencyStatus is the readiness of one dependency. The probe is public, so
+// it does not say why a dependency is down; the reason is logged instead.
+type DependencyStatus struct {
+	Status string `json:"status"`
+}
+
+// Readiness is the body of /readyz.
+type Readiness struct {
+	Draining     bool                        `json:"draining"`
+	Dependencies map[string]DependencyStatus `json:"dependencies"`
+}
+
+func dependencyStatus(name string, err error) DependencyStatus {
+	if err != nil {
+		slog.Warn("Dependency is down", "dependency", name, "error", err)
+		return DependencyStatus{Status: "down"}
+	}
+	return DependencyStatus{Status: "up"}
+}
+
+// registerProbes adds the endpoints polled by the orchestrator and by
+// Prometheus. They live outside /v1 and need no token.
+func (s *Server) registerProbes() {
+	// Liveness: the process serves requests, whatever its dependencies do.
+	s.engine.GET("/healthz", func(c *gin.Context) {
+		c.JSON(http.StatusOK, responses.Response{Success: true, Message: i18n.T(c.Request.Context(), "health.alive")})
+	})
+	s.engine.GET("/readyz", s.readiness)
+	s.engine.GET("/version", func(c *gin.Context) {
+		c.JSON(http.StatusOK, responses.Response{Success: true, Message: i18n.T(c.Request.Context(), "health.build_info"), Data: buildinfo.Get()})
+	})
+	s.engine.GET("/metrics", gin.WrapH(monitoring.Handler()))
+}
+
+// readiness pings the database and checks the background workers. It answers
+// 503 when one of them is down or the server is draining for shutdown, so the
+// instance is taken out of the load balancer before it stops.
+func (s *Server) readiness(c *gin.Context) {
+	ready := Readiness{
+		Draining:     s.draining.Load(),
+		Dependencies: map[string]DependencyStatus{},
+	}
+	healthy := !ready.Draining
+
+	if s.container.DB != nil {
+		ctx, cancel := s.container.Timeouts.WithTimeout(c.Request.Context(), database.OpRead)
+		err := s.container.DB.PingContext(ctx)
+		cancel()
+		ready.Dependencies["database"] = dependencyStatus("database", err)
+		healthy = healthy && err == nil
+	}
+	for name, err := range s.workers.Health(c.Request.Context()) {
+		ready.Dependencies["worker:"+name] = dependencyStatus("worker:"+name, err)
+		healthy = healthy && err == nil
+	}
+
+	if !healthy {
+		c.JSON(http.StatusServiceUnavailable, responses.Response{Success: false, Message: i18n.T(c.Request.Context(), "health.not_ready"), Error: "one or more dependencies are unavailable or the server is shutting down", Data: ready})
+		return
+	}
+	c.JSON(http.StatusOK, responses.Response{Success: true, Message: i18n.T(c.Request.Context(), "health.ready"), Data: ready})
+}
@@ -7,10 +7,24 @@ import (
 	"api-order/src/config"
 	dataRoutes "api-order/src/gardendata/infrastructure/http/routes"
 	kitRoutes "api-order/src/kit/infrastructure/http/routes"
+	metricRoutes "api-order/src/metric/infrastructure/http/routes"
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/lifecycle"
+	"api-order/src/shared/logging"
+	"api-order/src/shared/middlewares"
+	"api-order/src/shared/monitoring"
+	"api-order/src/shared/preferences"
 	userRoutes "api-order/src/user/infrastructure/http/routes"
-	"log"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 
 	// ¡IMPORTANTE! Importa los documentos generados por swag
 	// Reemplaza 'api-order' con el nombre real de tu módulo en go.mod
@@ -21,41 +35,85 @@ import (
 )
 
 type Server struct {
-	engine   *gin.Engine
-	http     string
-	port     string
-	httpAddr string
+	engine    *gin.Engine
+	container *Container
+	httpAddr  string
+	// workers are the background jobs of every module, started by Run.
+	workers *lifecycle.Manager
+	// draining is set once shutdown begins, so /readyz starts failing.
+	draining *atomic.Bool
 }
 
-func NewServer(http, port string) Server {
+// NewServer opens the database described by cfg, builds the application
+// container and registers every module on it.
+func NewServer(cfg config.Config) (Server, error) {
+	db, err := database.Open(cfg.Database)
+	if err != nil {
+		return Server{}, err
+	}
+	srv, err := NewServerWithContainer(NewContainer(cfg, db))
+	if err != nil {
+		db.Close()
+		return Server{}, err
+	}
+	return srv, nil
+}
+
+// NewServerWithContainer registers every module on an already built container,
+// e.g. one backed by another database.
+func NewServerWithContainer(container *Container) (Server, error) {
 	gin.SetMode(gin.ReleaseMode) // O gin.DebugMode durante el desarrollo
+	jwtConfig := container.Config.JWT
+	middlewares.SetJWT([]byte(jwtConfig.SecretKey), jwtConfig.TTL, jwtConfig.Issuer)
+
+	container.cacheMetrics()
+	container.cacheUsers()
+	container.instrument()
 
 	srv := Server{
-		engine:   gin.New(), // Considera gin.Default() si quieres los middlewares por defecto (Logger, Recovery)
-		http:     http,
-		port:     port,
-		httpAddr: http + ":" + port,
+		engine:    gin.New(), // Considera gin.Default() si quieres los middlewares por defecto (Logger, Recovery)
+		container: container,
+		httpAddr:  container.Config.Server.Addr(),
+		workers:   lifecycle.NewManager(),
+		draining:  &atomic.Bool{},
 	}
 
 	// Middlewares
-	if gin.Mode() == gin.DebugMode {
-		srv.engine.Use(gin.Logger()) // Añadir logger en modo debug
-	}
+	srv.engine.Use(otelgin.Middleware(container.Config.Tracing.ServiceName, otelgin.WithFilter(traced)))
+	srv.engine.Use(logging.Middleware()) // Request ID y log de cada petición
+	// Outside Recovery, so requests that panic are counted as 500s
+	srv.engine.Use(monitoring.Middleware())
+	// Idioma de los mensajes según Accept-Language; el JWT aplica la preferencia del usuario
+	srv.engine.Use(i18n.Middleware())
+	// Unidades, zona horaria e idioma guardados del usuario, tras validar el JWT en cualquier ruta
+	srv.engine.Use(preferences.Middleware(container.userPreferences))
+	// Respuesta única para los errores que los handlers adjuntan con ctx.Error
+	srv.engine.Use(middlewares.ErrorHandler())
 	srv.engine.Use(gin.Recovery()) // Añadir recovery para panics
-	srv.engine.Use(config.ConfigurationCors())
-	database.Connect()
+	srv.engine.Use(config.ConfigurationCors(container.Config.Cors))
 	srv.engine.RedirectTrailingSlash = true
-	srv.registerRoutes()
+	if err := srv.registerRoutes(); err != nil {
+		return Server{}, err
+	}
+	return srv, nil
+}
 
-	return srv
+// traced leaves the probes and the metrics scrapes out of the traces.
+func traced(r *http.Request) bool {
+	switch r.URL.Path {
+	case "/healthz", "/readyz", "/metrics":
+		return false
+	}
+	return true
 }
 
-func (s *Server) registerRoutes() {
+func (s *Server) registerRoutes() error {
 	// Ruta para Swagger UI
 	// Asegúrate que el BasePath ('/v1' en este caso) no interfiera.
 	// Sirviendo Swagger fuera del grupo /v1 es común.
 	s.engine.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
-	log.Println("Swagger UI available at /swagger/index.html")
+	slog.Info("Swagger UI available", "path", "/swagger/index.html")
+	s.registerProbes()
 
 	// Grupos de rutas v1
 	v1 := s.engine.Group("/v1") // Agrupa todas tus rutas bajo /v1
@@ -64,18 +122,85 @@ func (s *Server) registerRoutes() {
 	kitRoutesGroup := v1.Group("/kits")
 	alertRoutesGroup := v1.Group("/alerts")
 	dataRoutesGroup := v1.Group("/garden/data")
+	metricRoutesGroup := v1.Group("/metrics")
+
+	userDeps, err := s.container.UserDependencies()
+	if err != nil {
+		return err
+	}
+	metricDeps := s.container.MetricDependencies()
+	// Seeds the built-in metrics before data ingestion is served
+	if err := metricDeps.SeedBuiltinMetrics(context.Background()); err != nil {
+		return err
+	}
+	dataDeps := s.container.GardenDataDependencies()
 
-	kitRoutes.KitRoutes(kitRoutesGroup)
-	alertRoutes.AlertRoutes(alertRoutesGroup)
-	userRoutes.UserRoutes(userRoutesGroup)
-	dataRoutes.GardenDataRoutes(dataRoutesGroup)
+	kitRoutes.KitRoutes(kitRoutesGroup, s.container.KitDependencies())
+	alertRoutes.AlertRoutes(alertRoutesGroup, s.container.AlertDependencies())
+	userRoutes.UserRoutes(userRoutesGroup, userDeps)
+	metricRoutes.MetricRoutes(metricRoutesGroup, metricDeps)
+	dataRoutes.GardenDataRoutes(dataRoutesGroup, dataDeps)
 
+	// Background jobs
+	dataDeps.RegisterWorkers(s.workers)
+	return nil
 }
 
-func (s *Server) Run() {
-	log.Println("Server running on " + s.httpAddr)
-	// Usa ListenAndServe para manejar errores de inicio
-	if err := s.engine.Run(s.httpAddr); err != nil {
-		log.Fatalf("Failed to run server: %v", err)
+// Handler returns the engine with every route registered, to serve it from
+// another listener or to drive it with httptest.
+func (s *Server) Handler() http.Handler {
+	return s.engine
+}
+
+// Run serves HTTP and runs the background workers until ctx is done, e.g. on
+// SIGINT or SIGTERM. It then stops accepting connections, waits up to the
+// shutdown timeout for in-flight requests, stops the workers in reverse order
+// and closes the database.
+func (s *Server) Run(ctx context.Context) error {
+	cfg := s.container.Config.Server
+	if err := s.workers.Start(ctx); err != nil {
+		return errors.Join(err, s.container.Close())
+	}
+
+	httpServer := &http.Server{
+		Addr:         s.httpAddr,
+		Handler:      s.engine,
+		ReadTimeout:  cfg.ReadTimeout,
+		WriteTimeout: cfg.WriteTimeout,
+		IdleTimeout:  cfg.IdleTimeout,
+	}
+	serveErr := make(chan error, 1)
+	go func() {
+		slog.Info("Server running", "addr", s.httpAddr)
+		serveErr <- httpServer.ListenAndServe()
+	}()
+
+	var errs []error
+	select {
+	case err := <-serveErr:
+		errs = append(errs, fmt.Errorf("failed to run server: %w", err))
+	case <-ctx.Done():
+		slog.Info("Shutting down, waiting for in-flight requests", "timeout", cfg.ShutdownTimeout)
+	}
+	s.draining.Store(true)
+	if cfg.DrainDelay > 0 {
+		// Keep serving while the load balancer notices /readyz failing.
+		time.Sleep(cfg.DrainDelay)
+	}
+
+	shutdownCtx, cancel := context.WithCancel(context.Background())
+	if cfg.ShutdownTimeout > 0 {
+		shutdownCtx, cancel = context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	}
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to drain HTTP requests: %w", err))
+	}
+	if err := s.workers.Stop(shutdownCtx); err != nil {
+		errs = append(errs, err)
+	}
+	if err := s.container.Close(); err != nil {
+		errs = append(errs, fmt.Errorf("failed to close the database: %w", err))
 	}
+	return errors.Join(errs...)
 }
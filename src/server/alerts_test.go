@@ -0,0 +1,80 @@
+package server_test
+
+import (
+	"net/http"
+	"testing"
+)
+
+type alertBody struct {
+	AlertID   int    `json:"alert_id"`
+	KitID     int    `json:"kit_id"`
+	AlertType string `json:"alert_type"`
+	Message   string `json:"message"`
+}
+
+func TestRegisterAlert(t *testing.T) {
+	api := newTestAPI(t)
+	_, token := api.signUp("ada@example.com")
+	kitID := api.createKit(token, "greenhouse")
+
+	response := api.expect(http.StatusCreated, http.MethodPost, "/v1/alerts/", "", map[string]interface{}{
+		"kit_id": kitID, "alert_type": "under_min", "message": "Soil too dry",
+	})
+	var alert alertBody
+	api.decode(response, &alert)
+	if alert.AlertID == 0 || alert.KitID != int(kitID) || alert.AlertType != "under_min" {
+		t.Fatalf("got alert %+v", alert)
+	}
+
+	api.expect(http.StatusBadRequest, http.MethodPost, "/v1/alerts/", "", map[string]interface{}{
+		"kit_id": kitID, "alert_type": "unknown", "message": "Soil too dry",
+	})
+	api.expect(http.StatusBadRequest, http.MethodPost, "/v1/alerts/", "", map[string]interface{}{
+		"alert_type": "under_min", "message": "Soil too dry",
+	})
+}
+
+func TestGetAlertsPagesByCursor(t *testing.T) {
+	api := newTestAPI(t)
+	_, token := api.signUp("ada@example.com")
+	kitID := api.createKit(token, "greenhouse")
+	for _, alertType := range []string{"under_min", "higher_max", "under_min"} {
+		api.expect(http.StatusCreated, http.MethodPost, "/v1/alerts/", "", map[string]interface{}{
+			"kit_id": kitID, "alert_type": alertType, "message": "Reading out of range",
+		})
+	}
+
+	seen := map[int]bool{}
+	next := path("/v1/alerts/%d?limit=2", kitID)
+	for pages := 0; next != ""; pages++ {
+		if pages == 3 {
+			t.Fatal("cursor paging did not end")
+		}
+		response := api.expect(http.StatusOK, http.MethodGet, next, token, nil)
+		var alerts []alertBody
+		api.decode(response, &alerts)
+		for _, alert := range alerts {
+			if seen[alert.AlertID] {
+				t.Fatalf("alert %d returned twice", alert.AlertID)
+			}
+			seen[alert.AlertID] = true
+		}
+		next = ""
+		if response.Pagination != nil && response.Pagination.HasMore {
+			next = path("/v1/alerts/%d?limit=2&cursor=%s", kitID, response.Pagination.NextCursor)
+		}
+	}
+	if len(seen) != 3 {
+		t.Fatalf("got %d alerts across pages, want 3", len(seen))
+	}
+
+	response := api.expect(http.StatusOK, http.MethodGet, path("/v1/alerts/%d?filter[alert_type]=higher_max", kitID), token, nil)
+	var alerts []alertBody
+	api.decode(response, &alerts)
+	if len(alerts) != 1 || alerts[0].AlertType != "higher_max" {
+		t.Fatalf("got %+v, want the single higher_max alert", alerts)
+	}
+
+	api.expect(http.StatusUnauthorized, http.MethodGet, path("/v1/alerts/%d", kitID), "", nil)
+	api.expect(http.StatusBadRequest, http.MethodGet, path("/v1/alerts/%d?cursor=bogus", kitID), token, nil)
+}
@@ -0,0 +1,53 @@
+package server_test
+
+import (
+	"api-order/src/config"
+	"api-order/src/server"
+	"testing"
+)
+
+// contractSuite lists the tests every storage backend must pass.
+var contractSuite = map[string]func(*testing.T){
+	"RegisterUser":                    TestRegisterUser,
+	"RegisterUserRejectsTakenKitCode": TestRegisterUserRejectsTakenKitCode,
+	"Login":                           TestLogin,
+	"GetUserRequiresToken":            TestGetUserRequiresToken,
+	"UpdateUser":                      TestUpdateUser,
+	"CreateKit":                       TestCreateKit,
+	"GetKitsListsOnlyOwnKits":         TestGetKitsListsOnlyOwnKits,
+	"UpdateSamplingInterval":          TestUpdateSamplingInterval,
+	"RegisterAlert":                   TestRegisterAlert,
+	"GetAlertsPagesByCursor":          TestGetAlertsPagesByCursor,
+	"GetMetricsListsBuiltins":         TestGetMetricsListsBuiltins,
+	"RegisterMetric":                  TestRegisterMetric,
+	"KitSensors":                      TestKitSensors,
+	"RegisterGardenData":              TestRegisterGardenData,
+	"GetRecentGardenData":             TestGetRecentGardenData,
+	"GetRecentMetricSamples":          TestGetRecentMetricSamples,
+	"IngestionRaisesSpikeAlert":       TestIngestionRaisesSpikeAlert,
+	"ClockSkewFollowsReadings":        TestClockSkewFollowsReadings,
+	"AnomalySettings":                 TestAnomalySettings,
+	"StatisticsAndCompleteness":       TestStatisticsAndCompleteness,
+	"ExportGardenData":                TestExportGardenData,
+	"ImportGardenData":                TestImportGardenData,
+	"Probes":                          TestProbes,
+	"PrometheusMetrics":               TestPrometheusMetrics,
+	"Tracing":                         TestTracing,
+	"ErrorCodes":                      TestErrorCodes,
+	"UserLocale":                      TestUserLocale,
+	"UserPreferences":                 TestUserPreferences,
+	"GardenDataFollowsPreferences":    TestGardenDataFollowsPreferences,
+	"LocaleFollowsPreferences":        TestLocaleFollowsPreferences,
+}
+
+// runContractSuite reruns contractSuite with every testAPI built on the
+// container returned by open, which gets a fresh database per test.
+func runContractSuite(t *testing.T, open func(t *testing.T, cfg config.Config) *server.Container) {
+	memory := newContainer
+	newContainer = open
+	defer func() { newContainer = memory }()
+
+	for name, test := range contractSuite {
+		t.Run(name, test)
+	}
+}
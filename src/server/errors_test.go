@@ -0,0 +1,108 @@
+package server_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestErrorCodes checks that failures carry the stable code of their cause,
+// whichever endpoint reports them.
+func TestErrorCodes(t *testing.T) {
+	api := newTestAPI(t)
+	_, token := api.signUp("ada@example.com")
+	_, otherToken := api.signUp("grace@example.com")
+	kitID := api.createKit(token, "greenhouse")
+
+	cases := []struct {
+		name   string
+		status int
+		method string
+		path   string
+		token  string
+		body   interface{}
+		code   string
+	}{
+		{"missing token", http.StatusUnauthorized, http.MethodGet, "/v1/kits/", "", nil, "missing_token"},
+		{"invalid token", http.StatusUnauthorized, http.MethodGet, "/v1/kits/", "not-a-token", nil, "invalid_token"},
+		{"invalid credentials", http.StatusUnauthorized, http.MethodPost, "/v1/users/login", "", map[string]string{
+			"email": "ada@example.com", "password": "wrong-password",
+		}, "invalid_credentials"},
+		{"unknown user", http.StatusNotFound, http.MethodGet, "/v1/users/999", token, nil, "user_not_found"},
+		{"taken email", http.StatusConflict, http.MethodPost, "/v1/users/", "", map[string]string{
+			"first_name": "Ada", "last_name": "Lovelace", "email": "ada@example.com", "password": "secret123", "kit_code": "another-code",
+		}, "user_email_exists"},
+		{"validation", http.StatusBadRequest, http.MethodPost, "/v1/kits/", token, map[string]interface{}{}, "validation_failed"},
+		{"path parameter", http.StatusBadRequest, http.MethodGet, "/v1/garden/data/kit/abc/statistics", token, nil, "invalid_parameter"},
+		{"pagination", http.StatusBadRequest, http.MethodGet, path("/v1/alerts/%d?cursor=bogus", kitID), token, nil, "invalid_pagination"},
+		{"not owned", http.StatusForbidden, http.MethodPut, path("/v1/kits/%d/sampling-interval", kitID), otherToken, map[string]interface{}{
+			"sampling_interval_seconds": 60,
+		}, "kit_not_owned"},
+		{"unknown kit", http.StatusNotFound, http.MethodPut, "/v1/kits/999/sampling-interval", token, map[string]interface{}{
+			"sampling_interval_seconds": 60,
+		}, "kit_not_found"},
+		{"unknown metric", http.StatusBadRequest, http.MethodPost, "/v1/garden/data/", "", map[string]interface{}{
+			"kit_id": kitID, "metrics": map[string]float64{"not_a_metric": 1}, "time": time.Now().Unix(),
+		}, "unknown_metric"},
+		{"unknown import job", http.StatusNotFound, http.MethodGet, "/v1/garden/data/import/jobs/unknown", token, nil, "import_job_not_found"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			response := api.expect(c.status, c.method, c.path, c.token, c.body)
+			if response.Success || response.Code != c.code || response.Message == "" {
+				t.Fatalf("got success %v, code %q, message %q, want code %q", response.Success, response.Code, response.Message, c.code)
+			}
+		})
+	}
+}
+
+// TestValidationFieldErrors checks that invalid bodies list each rejected
+// field by its JSON name, with a message for the user.
+func TestValidationFieldErrors(t *testing.T) {
+	api := newTestAPI(t)
+	_, token := api.signUp("ada@example.com")
+
+	cases := []struct {
+		name   string
+		path   string
+		token  string
+		body   interface{}
+		fields []fieldError
+	}{
+		{"rules", "/v1/users/", "", map[string]string{
+			"first_name": "Ada", "last_name": "Lovelace", "email": "not-an-email", "password": "123", "kit_code": "code",
+		}, []fieldError{{Field: "email", Rule: "email"}, {Field: "password", Rule: "min", Param: "6"}}},
+		{"missing", "/v1/users/login", "", map[string]string{}, []fieldError{
+			{Field: "email", Rule: "required"}, {Field: "password", Rule: "required"},
+		}},
+		{"cross field", "/v1/metrics/", token, map[string]interface{}{
+			"name": "co2", "unit": "ppm", "min_value": 10, "max_value": 5,
+		}, []fieldError{{Field: "max_value", Rule: "gtfield", Param: "min_value"}}},
+		{"wrong type", "/v1/garden/data/", "", map[string]interface{}{
+			"kit_id": "one", "time": time.Now().Unix(),
+		}, []fieldError{{Field: "kit_id", Rule: "type", Param: "int64"}}},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			response := api.expect(http.StatusBadRequest, http.MethodPost, c.path, c.token, c.body)
+			if response.Code != "validation_failed" {
+				t.Fatalf("got code %q, want validation_failed", response.Code)
+			}
+			if len(response.Fields) != len(c.fields) {
+				t.Fatalf("got fields %+v, want %+v", response.Fields, c.fields)
+			}
+			for i, want := range c.fields {
+				got := response.Fields[i]
+				if got.Field != want.Field || got.Rule != want.Rule || got.Param != want.Param || got.Message == "" {
+					t.Fatalf("field %d: got %+v, want %+v with a message", i, got, want)
+				}
+			}
+		})
+	}
+
+	// A body that is not a JSON object has no fields to point at
+	response := api.expect(http.StatusBadRequest, http.MethodPost, "/v1/users/login", "", "{")
+	if response.Code != "invalid_request" || len(response.Fields) != 0 {
+		t.Fatalf("got code %q and fields %+v for a malformed body", response.Code, response.Fields)
+	}
+}
@@ -0,0 +1,329 @@
+package server_test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type recordBody struct {
+	DataID      int64              `json:"data_id"`
+	KitID       int64              `json:"kit_id"`
+	Temperature float64            `json:"temperature"`
+	Metrics     map[string]float64 `json:"metrics"`
+}
+
+type sampleBody struct {
+	Metric string  `json:"metric"`
+	Value  float64 `json:"value"`
+}
+
+// postReading ingests a reading taken now and fails the test unless it is stored.
+func (api *testAPI) postReading(kitID int64, readings map[string]float64) {
+	api.t.Helper()
+
+	api.expect(http.StatusCreated, http.MethodPost, "/v1/garden/data/", "", map[string]interface{}{
+		"kit_id":  kitID,
+		"metrics": readings,
+		"time":    time.Now().Unix(),
+	})
+}
+
+func TestRegisterGardenData(t *testing.T) {
+	api := newTestAPI(t)
+	_, token := api.signUp("ada@example.com")
+	kitID := api.createKit(token, "greenhouse")
+
+	response := api.expect(http.StatusCreated, http.MethodPost, "/v1/garden/data/", "", map[string]interface{}{
+		"kit_id":          kitID,
+		"temperature":     21.5,
+		"ground_humidity": 40,
+		"time":            time.Now().Unix(),
+	})
+	var record recordBody
+	api.decode(response, &record)
+	if record.DataID == 0 || record.KitID != kitID || record.Temperature != 21.5 {
+		t.Fatalf("got record %+v", record)
+	}
+
+	for name, body := range map[string]map[string]interface{}{
+		"unknown metric": {"kit_id": kitID, "metrics": map[string]float64{"radiation": 1}, "time": time.Now().Unix()},
+		"out of range":   {"kit_id": kitID, "temperature": 500, "time": time.Now().Unix()},
+		"no readings":    {"kit_id": kitID, "time": time.Now().Unix()},
+		"missing time":   {"kit_id": kitID, "temperature": 21.5},
+	} {
+		if code, _ := api.do(http.MethodPost, "/v1/garden/data/", "", body); code != http.StatusBadRequest {
+			t.Errorf("%s: status %d, want 400", name, code)
+		}
+	}
+}
+
+func TestGetRecentGardenData(t *testing.T) {
+	api := newTestAPI(t)
+	_, token := api.signUp("ada@example.com")
+	kitID := api.createKit(token, "greenhouse")
+	otherKitID := api.createKit(token, "orchard")
+	for _, temperature := range []float64{20, 21, 22} {
+		api.postReading(kitID, map[string]float64{"temperature": temperature, "ph_level": 6.5})
+	}
+	api.postReading(otherKitID, map[string]float64{"temperature": 30})
+
+	first := api.expect(http.StatusOK, http.MethodGet, path("/v1/garden/data/kit/%d/minutes/60?limit=2", kitID), token, nil)
+	var records []recordBody
+	api.decode(first, &records)
+	if len(records) != 2 || records[0].Temperature != 22 || first.Pagination == nil || !first.Pagination.HasMore {
+		t.Fatalf("got %+v with pagination %+v, want the two newest readings and more pages", records, first.Pagination)
+	}
+
+	second := api.expect(http.StatusOK, http.MethodGet, path("/v1/garden/data/kit/%d/minutes/60?limit=2&cursor=%s", kitID, first.Pagination.NextCursor), token, nil)
+	api.decode(second, &records)
+	if len(records) != 1 || records[0].Temperature != 20 {
+		t.Fatalf("got %+v, want the oldest reading on the last page", records)
+	}
+
+	api.expect(http.StatusUnauthorized, http.MethodGet, path("/v1/garden/data/kit/%d/minutes/60", kitID), "", nil)
+	api.expect(http.StatusBadRequest, http.MethodGet, path("/v1/garden/data/kit/%d/minutes/60?sort=temperature", kitID), token, nil)
+}
+
+func TestGetRecentMetricSamples(t *testing.T) {
+	api := newTestAPI(t)
+	_, token := api.signUp("ada@example.com")
+	kitID := api.createKit(token, "greenhouse")
+	api.postReading(kitID, map[string]float64{"temperature": 20, "ph_level": 6.5})
+	api.postReading(kitID, map[string]float64{"temperature": 21, "ph_level": 6.6})
+
+	response := api.expect(http.StatusOK, http.MethodGet, path("/v1/garden/data/kit/%d/samples/minutes/60?metric=ph_level", kitID), token, nil)
+	var samples []sampleBody
+	api.decode(response, &samples)
+	if len(samples) != 2 || samples[0].Metric != "ph_level" || samples[0].Value != 6.6 {
+		t.Fatalf("got %+v, want the two ph_level samples, newest first", samples)
+	}
+}
+
+func TestIngestionRaisesSpikeAlert(t *testing.T) {
+	api := newTestAPI(t)
+	_, token := api.signUp("ada@example.com")
+	kitID := api.createKit(token, "greenhouse")
+
+	api.postReading(kitID, map[string]float64{"ph_level": 6})
+	api.postReading(kitID, map[string]float64{"ph_level": 12})
+
+	response := api.expect(http.StatusOK, http.MethodGet, path("/v1/alerts/%d?filter[alert_type]=spike", kitID), token, nil)
+	var alerts []alertBody
+	api.decode(response, &alerts)
+	if len(alerts) != 1 {
+		t.Fatalf("got %+v, want one spike alert", alerts)
+	}
+}
+
+// TestClockSkewFollowsReadings checks that the stored skew of a kit follows its
+// device clock while the drift flag stays the same.
+func TestClockSkewFollowsReadings(t *testing.T) {
+	api := newTestAPI(t)
+	_, token := api.signUp("ada@example.com")
+	kitID := api.createKit(token, "greenhouse")
+
+	for _, behind := range []time.Duration{10 * time.Minute, 20 * time.Minute} {
+		api.expect(http.StatusCreated, http.MethodPost, "/v1/garden/data/", "", map[string]interface{}{
+			"kit_id":      kitID,
+			"temperature": 21,
+			"time":        time.Now().Add(-behind).Unix(),
+		})
+	}
+
+	var kits []kitBody
+	api.decode(api.expect(http.StatusOK, http.MethodGet, "/v1/kits/", token, nil), &kits)
+	if len(kits) != 1 || !kits[0].ClockDriftFlagged || kits[0].ClockSkewSeconds > -1195 || kits[0].ClockSkewSeconds < -1205 {
+		t.Fatalf("got kits %+v, want a flagged kit 20 minutes behind", kits)
+	}
+}
+
+func TestAnomalySettings(t *testing.T) {
+	api := newTestAPI(t)
+	_, token := api.signUp("ada@example.com")
+	_, otherToken := api.signUp("grace@example.com")
+	kitID := api.createKit(token, "greenhouse")
+	settingsPath := path("/v1/garden/data/kit/%d/anomaly-settings", kitID)
+
+	api.expect(http.StatusForbidden, http.MethodGet, settingsPath, otherToken, nil)
+	api.expect(http.StatusForbidden, http.MethodPut, settingsPath, otherToken, map[string]interface{}{"enabled": false})
+	api.expect(http.StatusNotFound, http.MethodGet, "/v1/garden/data/kit/999/anomaly-settings", token, nil)
+	api.expect(http.StatusOK, http.MethodGet, settingsPath, token, nil)
+	api.expect(http.StatusOK, http.MethodPut, settingsPath, token, map[string]interface{}{"enabled": false})
+	api.expect(http.StatusBadRequest, http.MethodPut, settingsPath, token, map[string]interface{}{"spike_fraction": 2})
+
+	response := api.expect(http.StatusOK, http.MethodGet, settingsPath, token, nil)
+	var settings struct {
+		Enabled bool `json:"enabled"`
+	}
+	api.decode(response, &settings)
+	if settings.Enabled {
+		t.Fatal("want the detector disabled after the update")
+	}
+
+	// A disabled detector no longer raises alerts
+	api.postReading(kitID, map[string]float64{"ph_level": 6})
+	api.postReading(kitID, map[string]float64{"ph_level": 12})
+	alerts := api.expect(http.StatusOK, http.MethodGet, path("/v1/alerts/%d", kitID), token, nil)
+	if string(alerts.Data) != "[]" {
+		t.Fatalf("got alerts %s, want none", alerts.Data)
+	}
+}
+
+func TestStatisticsAndCompleteness(t *testing.T) {
+	api := newTestAPI(t)
+	_, token := api.signUp("ada@example.com")
+	_, otherToken := api.signUp("grace@example.com")
+	kitID := api.createKit(token, "greenhouse")
+	api.postReading(kitID, map[string]float64{"temperature": 20})
+	api.postReading(kitID, map[string]float64{"temperature": 24})
+
+	response := api.expect(http.StatusOK, http.MethodGet, path("/v1/garden/data/kit/%d/statistics?period=day", kitID), token, nil)
+	if !strings.Contains(string(response.Data), `"temperature"`) {
+		t.Fatalf("got statistics %s, want the temperature metric", response.Data)
+	}
+	api.expect(http.StatusBadRequest, http.MethodGet, path("/v1/garden/data/kit/%d/statistics?period=year", kitID), token, nil)
+
+	// New thresholds replace the cached statistics
+	api.expect(http.StatusOK, http.MethodPut, path("/v1/metrics/kit/%d/thresholds/temperature", kitID), token, map[string]float64{
+		"min_threshold": 15, "max_threshold": 22,
+	})
+	var stats struct {
+		Metrics []struct {
+			Metric                  string   `json:"metric"`
+			PercentWithinThresholds *float64 `json:"percent_within_thresholds"`
+		} `json:"metrics"`
+	}
+	api.decode(api.expect(http.StatusOK, http.MethodGet, path("/v1/garden/data/kit/%d/statistics?period=day", kitID), token, nil), &stats)
+	for _, metric := range stats.Metrics {
+		if metric.Metric == "temperature" && (metric.PercentWithinThresholds == nil || *metric.PercentWithinThresholds != 50) {
+			t.Fatalf("got temperature statistics %+v, want 50%% within the new thresholds", metric)
+		}
+	}
+
+	api.expect(http.StatusOK, http.MethodGet, path("/v1/garden/data/kit/%d/completeness", kitID), token, nil)
+	api.expect(http.StatusForbidden, http.MethodGet, path("/v1/garden/data/kit/%d/statistics", kitID), otherToken, nil)
+	api.expect(http.StatusForbidden, http.MethodGet, path("/v1/garden/data/kit/%d/completeness", kitID), otherToken, nil)
+	api.expect(http.StatusNotFound, http.MethodGet, "/v1/garden/data/kit/999/completeness", token, nil)
+}
+
+func TestExportGardenData(t *testing.T) {
+	api := newTestAPI(t)
+	_, token := api.signUp("ada@example.com")
+	_, otherToken := api.signUp("grace@example.com")
+	kitID := api.createKit(token, "greenhouse")
+	api.postReading(kitID, map[string]float64{"temperature": 20})
+	api.postReading(kitID, map[string]float64{"temperature": 21})
+
+	recorder := api.send(http.MethodGet, path("/v1/garden/data/kit/%d/export?format=ndjson", kitID), token, "", nil)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status %d, want 200: %s", recorder.Code, recorder.Body.String())
+	}
+	lines := 0
+	for scanner := bufio.NewScanner(recorder.Body); scanner.Scan(); {
+		lines++
+	}
+	if lines != 2 {
+		t.Fatalf("got %d NDJSON lines, want 2", lines)
+	}
+
+	// Metrics the kit declares besides the fixed ones are exported as extra columns
+	_, adminToken := api.signUp("admin@example.com")
+	api.expect(http.StatusCreated, http.MethodPost, "/v1/metrics/", adminToken, map[string]interface{}{
+		"name": "co2", "unit": "ppm", "min_value": 0, "max_value": 5000,
+	})
+	api.expect(http.StatusOK, http.MethodPut, path("/v1/metrics/kit/%d", kitID), token, map[string][]string{
+		"metrics": {"temperature", "co2"},
+	})
+	api.postReading(kitID, map[string]float64{"temperature": 22, "co2": 400})
+	recorder = api.send(http.MethodGet, path("/v1/garden/data/kit/%d/export?columns=temperature,co2", kitID), token, "", nil)
+	if want := "temperature,co2\n20,\n21,\n22,400\n"; recorder.Code != http.StatusOK || recorder.Body.String() != want {
+		t.Fatalf("status %d, body %q, want 200 with %q", recorder.Code, recorder.Body.String(), want)
+	}
+
+	if code, _ := api.do(http.MethodGet, path("/v1/garden/data/kit/%d/export?format=pdf", kitID), token, nil); code != http.StatusBadRequest {
+		t.Fatalf("unknown format: status %d, want 400", code)
+	}
+	if code, _ := api.do(http.MethodGet, path("/v1/garden/data/kit/%d/export", kitID), otherToken, nil); code != http.StatusForbidden {
+		t.Fatalf("export of another user's kit: status %d, want 403", code)
+	}
+}
+
+// importCSV uploads csv to the import endpoint of a kit.
+func (api *testAPI) importCSV(kitID int64, token, csv string) *httptest.ResponseRecorder {
+	api.t.Helper()
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	file, err := form.CreateFormFile("file", "history.csv")
+	if err != nil {
+		api.t.Fatal(err)
+	}
+	file.Write([]byte(csv))
+	form.Close()
+
+	return api.send(http.MethodPost, path("/v1/garden/data/kit/%d/import", kitID), token, form.FormDataContentType(), &body)
+}
+
+func TestImportGardenData(t *testing.T) {
+	api := newTestAPI(t)
+	_, token := api.signUp("ada@example.com")
+	_, otherToken := api.signUp("grace@example.com")
+	kitID := api.createKit(token, "greenhouse")
+
+	start := time.Now().Add(-2 * time.Hour).Unix()
+	csv := fmt.Sprintf("time,temperature\n%d,20\n%d,21\n%d,not-a-number\n", start, start+60, start+120)
+
+	if recorder := api.importCSV(kitID, otherToken, csv); recorder.Code != http.StatusForbidden {
+		t.Fatalf("import into another user's kit: status %d, want 403", recorder.Code)
+	}
+
+	recorder := api.importCSV(kitID, token, csv)
+	if recorder.Code != http.StatusAccepted {
+		t.Fatalf("status %d, want 202: %s", recorder.Code, recorder.Body.String())
+	}
+	var job struct {
+		ID           string `json:"id"`
+		Status       string `json:"status"`
+		InsertedRows int    `json:"inserted_rows"`
+		FailedRows   int    `json:"failed_rows"`
+	}
+	var accepted apiResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &accepted); err != nil {
+		t.Fatal(err)
+	}
+	api.decode(accepted, &job)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for job.Status != "completed" && job.Status != "failed" {
+		if time.Now().After(deadline) {
+			t.Fatalf("import job stuck in %q", job.Status)
+		}
+		time.Sleep(10 * time.Millisecond)
+		api.decode(api.expect(http.StatusOK, http.MethodGet, "/v1/garden/data/import/jobs/"+job.ID, token, nil), &job)
+	}
+	if job.Status != "completed" || job.InsertedRows != 2 || job.FailedRows != 1 {
+		t.Fatalf("got job %+v, want 2 inserted rows and 1 failed row", job)
+	}
+
+	// Imported rows are filed at the time they were taken, not at import time
+	var records []recordBody
+	api.decode(api.expect(http.StatusOK, http.MethodGet, path("/v1/garden/data/kit/%d/minutes/60", kitID), token, nil), &records)
+	if len(records) != 0 {
+		t.Fatalf("got %d records in the last hour, want the imported ones two hours back", len(records))
+	}
+	api.decode(api.expect(http.StatusOK, http.MethodGet, path("/v1/garden/data/kit/%d/minutes/180", kitID), token, nil), &records)
+	if len(records) != 2 {
+		t.Fatalf("got %d records in the last three hours, want 2", len(records))
+	}
+
+	api.expect(http.StatusNotFound, http.MethodGet, "/v1/garden/data/import/jobs/unknown", token, nil)
+	api.expect(http.StatusNotFound, http.MethodGet, "/v1/garden/data/import/jobs/"+job.ID, otherToken, nil)
+}
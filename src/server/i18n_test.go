@@ -0,0 +1,145 @@
+package server_test
+
+import (
+	"api-order/src/shared/i18n"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// doIn sends a JSON request with an Accept-Language header and returns the
+// recorder along with the decoded envelope.
+func (api *testAPI) doIn(acceptLanguage, method, path, token string, body interface{}) (*httptest.ResponseRecorder, apiResponse) {
+	api.t.Helper()
+
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			api.t.Fatalf("failed to encode request body: %v", err)
+		}
+	}
+	req := httptest.NewRequest(method, path, &payload)
+	req.Header.Set("Content-Type", "application/json")
+	if acceptLanguage != "" {
+		req.Header.Set("Accept-Language", acceptLanguage)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	recorder := httptest.NewRecorder()
+	api.handler.ServeHTTP(recorder, req)
+
+	var response apiResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		api.t.Fatalf("%s %s: invalid JSON response %q: %v", method, path, recorder.Body.String(), err)
+	}
+	return recorder, response
+}
+
+func TestCatalogsHaveTheSameKeys(t *testing.T) {
+	spanish, english := i18n.Keys(i18n.Spanish), i18n.Keys(i18n.English)
+	if len(spanish) == 0 || !reflect.DeepEqual(spanish, english) {
+		t.Fatalf("catalogs differ:\nes: %v\nen: %v", spanish, english)
+	}
+}
+
+// TestAcceptLanguage checks that messages follow the Accept-Language header
+// and fall back to Spanish.
+func TestAcceptLanguage(t *testing.T) {
+	api := newTestAPI(t)
+
+	cases := []struct {
+		header string
+		locale string
+	}{
+		{"", i18n.Spanish},
+		{"en", i18n.English},
+		{"en-GB,en;q=0.9,es;q=0.5", i18n.English},
+		{"es-MX", i18n.Spanish},
+		{"fr", i18n.Spanish},
+	}
+	for _, c := range cases {
+		recorder, response := api.doIn(c.header, http.MethodGet, "/healthz", "", nil)
+		if want := i18n.Translate(c.locale, "health.alive"); response.Message != want {
+			t.Errorf("Accept-Language %q: got message %q, want %q", c.header, response.Message, want)
+		}
+		if got := recorder.Header().Get("Content-Language"); got != c.locale {
+			t.Errorf("Accept-Language %q: got Content-Language %q, want %q", c.header, got, c.locale)
+		}
+	}
+
+	// Errors and rejected fields are translated too
+	_, response := api.doIn("en", http.MethodPost, "/v1/users/login", "", map[string]string{"email": "ada@example.com"})
+	if want := i18n.Translate(i18n.English, "error.validation_failed"); response.Message != want {
+		t.Fatalf("got error message %q, want %q", response.Message, want)
+	}
+	if len(response.Fields) != 1 || response.Fields[0].Message != i18n.Translate(i18n.English, "validation.required") {
+		t.Fatalf("got fields %+v, want password required in English", response.Fields)
+	}
+}
+
+// TestUserLocale checks that the stored preference of a user wins over
+// Accept-Language and that alerts raised for their kits use it.
+func TestUserLocale(t *testing.T) {
+	api := newTestAPI(t)
+
+	response := api.expect(http.StatusCreated, http.MethodPost, "/v1/users/", "", map[string]string{
+		"first_name": "Ada", "last_name": "Lovelace", "email": "ada@example.com", "password": "secret123", "kit_code": "code", "locale": "en",
+	})
+	var user struct {
+		ID     int64  `json:"id"`
+		Locale string `json:"locale"`
+	}
+	api.decode(response, &user)
+	if user.Locale != i18n.English || response.Message != i18n.Translate(i18n.English, "user.registered") {
+		t.Fatalf("got locale %q and message %q, want English", user.Locale, response.Message)
+	}
+
+	_, response = api.doIn("es", http.MethodPost, "/v1/users/login", "", map[string]string{
+		"email": "ada@example.com", "password": "secret123",
+	})
+	var session struct {
+		Token string `json:"token"`
+	}
+	api.decode(response, &session)
+	if response.Message != i18n.Translate(i18n.English, "user.logged_in") {
+		t.Fatalf("got login message %q, want English", response.Message)
+	}
+
+	// The token carries the preference to the following requests
+	_, response = api.doIn("es", http.MethodGet, path("/v1/users/%d", user.ID), session.Token, nil)
+	if response.Message != i18n.Translate(i18n.English, "user.retrieved") {
+		t.Fatalf("got message %q, want English", response.Message)
+	}
+
+	kitID := api.createKit(session.Token, "greenhouse")
+	api.postReading(kitID, map[string]float64{"ph_level": 6})
+	api.postReading(kitID, map[string]float64{"ph_level": 12})
+	var alerts []alertBody
+	api.decode(api.expect(http.StatusOK, http.MethodGet, path("/v1/alerts/%d?filter[alert_type]=spike", kitID), session.Token, nil), &alerts)
+	if len(alerts) != 1 || !strings.Contains(alerts[0].Message, "jumped from") {
+		t.Fatalf("got alerts %+v, want one spike alert in English", alerts)
+	}
+
+	// Omitting the locale keeps it; choosing another one answers in it
+	response = api.expect(http.StatusOK, http.MethodPut, path("/v1/users/%d", user.ID), session.Token, map[string]string{
+		"first_name": "Augusta", "last_name": "King",
+	})
+	api.decode(response, &user)
+	if user.Locale != i18n.English {
+		t.Fatalf("got locale %q after an update without one, want en", user.Locale)
+	}
+	recorder, response := api.doIn("en", http.MethodPut, path("/v1/users/%d", user.ID), session.Token, map[string]string{
+		"first_name": "Augusta", "last_name": "King", "locale": "es",
+	})
+	if response.Message != i18n.Translate(i18n.Spanish, "user.updated") || recorder.Header().Get("Content-Language") != i18n.Spanish {
+		t.Fatalf("got message %q, want Spanish", response.Message)
+	}
+	api.expect(http.StatusBadRequest, http.MethodPut, path("/v1/users/%d", user.ID), session.Token, map[string]string{
+		"first_name": "Augusta", "last_name": "King", "locale": "fr",
+	})
+}
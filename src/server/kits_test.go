@@ -0,0 +1,77 @@
+package server_test
+
+import (
+	"net/http"
+	"testing"
+)
+
+type kitBody struct {
+	ID                      int64  `json:"id"`
+	UserID                  int64  `json:"user_id"`
+	Name                    string `json:"name"`
+	SamplingIntervalSeconds int    `json:"sampling_interval_seconds"`
+	ClockDriftFlagged       bool   `json:"clock_drift_flagged"`
+	ClockSkewSeconds        int64  `json:"clock_skew_seconds"`
+}
+
+func TestCreateKit(t *testing.T) {
+	api := newTestAPI(t)
+	userID, token := api.signUp("ada@example.com")
+
+	response := api.expect(http.StatusCreated, http.MethodPost, "/v1/kits/", token, map[string]interface{}{
+		"name": "greenhouse", "description": "Back yard", "sampling_interval_seconds": 60,
+	})
+	var kit kitBody
+	api.decode(response, &kit)
+	if kit.ID == 0 || kit.UserID != userID || kit.SamplingIntervalSeconds != 60 {
+		t.Fatalf("got kit %+v, want an id, user %d and a 60s interval", kit, userID)
+	}
+
+	api.expect(http.StatusUnauthorized, http.MethodPost, "/v1/kits/", "", map[string]interface{}{
+		"name": "greenhouse", "description": "Back yard",
+	})
+	api.expect(http.StatusBadRequest, http.MethodPost, "/v1/kits/", token, map[string]interface{}{
+		"name": "gh", "description": "Back yard",
+	})
+}
+
+func TestGetKitsListsOnlyOwnKits(t *testing.T) {
+	api := newTestAPI(t)
+	_, token := api.signUp("ada@example.com")
+	_, otherToken := api.signUp("grace@example.com")
+	api.createKit(token, "tomatoes")
+	api.createKit(token, "basil")
+	api.createKit(otherToken, "orchids")
+
+	response := api.expect(http.StatusOK, http.MethodGet, "/v1/kits/?limit=1", token, nil)
+	var kits []kitBody
+	api.decode(response, &kits)
+	if len(kits) != 1 || kits[0].Name != "basil" {
+		t.Fatalf("got %+v, want only basil on the first page sorted by name", kits)
+	}
+	if response.Pagination == nil || response.Pagination.Total == nil || *response.Pagination.Total != 2 || !response.Pagination.HasMore {
+		t.Fatalf("got pagination %+v, want 2 kits in total and more pages", response.Pagination)
+	}
+
+	response = api.expect(http.StatusOK, http.MethodGet, "/v1/kits/?filter[name]=tom", token, nil)
+	api.decode(response, &kits)
+	if len(kits) != 1 || kits[0].Name != "tomatoes" {
+		t.Fatalf("got %+v, want the filtered kit", kits)
+	}
+
+	api.expect(http.StatusBadRequest, http.MethodGet, "/v1/kits/?sort=owner", token, nil)
+	api.expect(http.StatusUnauthorized, http.MethodGet, "/v1/kits/", "", nil)
+}
+
+func TestUpdateSamplingInterval(t *testing.T) {
+	api := newTestAPI(t)
+	_, token := api.signUp("ada@example.com")
+	_, otherToken := api.signUp("grace@example.com")
+	kitID := api.createKit(token, "greenhouse")
+
+	body := map[string]int{"sampling_interval_seconds": 120}
+	api.expect(http.StatusOK, http.MethodPut, path("/v1/kits/%d/sampling-interval", kitID), token, body)
+	api.expect(http.StatusForbidden, http.MethodPut, path("/v1/kits/%d/sampling-interval", kitID), otherToken, body)
+	api.expect(http.StatusNotFound, http.MethodPut, "/v1/kits/999/sampling-interval", token, body)
+	api.expect(http.StatusBadRequest, http.MethodPut, path("/v1/kits/%d/sampling-interval", kitID), token, map[string]int{"sampling_interval_seconds": 1})
+}
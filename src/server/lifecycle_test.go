@@ -0,0 +1,58 @@
+package server_test
+
+import (
+	database "api-order/src/Database"
+	"api-order/src/config"
+	"api-order/src/server"
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRunShutsDownGracefully(t *testing.T) {
+	cfg := config.Config{
+		Server: config.ServerConfig{Host: "127.0.0.1", Port: "0", ShutdownTimeout: 5 * time.Second},
+		Cors:   config.CorsConfig{MaxAge: time.Hour},
+		JWT:    config.JWTConfig{SecretKey: "test-secret-key-of-at-least-32-bytes", TTL: time.Hour},
+		Database: config.DatabaseConfig{
+			Driver:       config.DriverSQLite,
+			Path:         filepath.Join(t.TempDir(), "api.db"),
+			MaxOpenConns: 1,
+			MaxIdleConns: 1,
+			AutoMigrate:  true,
+		},
+		GardenData: config.GardenDataConfig{
+			StatisticsCacheTTL: 5 * time.Minute,
+			ClockSkewTolerance: 5 * time.Minute,
+			GapAlertAfter:      time.Hour,
+			GapCheckInterval:   time.Minute,
+		},
+	}
+	db, err := database.Open(cfg.Database)
+	if err != nil {
+		t.Fatalf("failed to open SQLite database: %v", err)
+	}
+	srv, err := server.NewServerWithContainer(server.NewContainer(cfg, db))
+	if err != nil {
+		t.Fatalf("failed to build server: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error, 1)
+	go func() { stopped <- srv.Run(ctx) }()
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-stopped:
+		if err != nil {
+			t.Fatalf("run: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("server did not shut down")
+	}
+	if err := db.Ping(); err == nil {
+		t.Fatal("database still open after shutdown")
+	}
+}
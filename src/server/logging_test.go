@@ -0,0 +1,72 @@
+package server_test
+
+import (
+	"api-order/src/shared/logging"
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// captureLogs sends the JSON logs of the test to a buffer.
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+
+	previous := slog.Default()
+	t.Cleanup(func() { slog.SetDefault(previous) })
+	var buf bytes.Buffer
+	if err := logging.Setup(&buf, logging.FormatJSON, "debug"); err != nil {
+		t.Fatalf("failed to set up logging: %v", err)
+	}
+	return &buf
+}
+
+func TestRequestIDs(t *testing.T) {
+	api := newTestAPI(t)
+	userID, token := api.signUp("logs@example.com")
+	kitID := api.createKit(token, "greenhouse")
+	logs := captureLogs(t)
+
+	req := httptest.NewRequest(http.MethodGet, path("/v1/garden/data/kit/%d/statistics", kitID), nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set(logging.RequestIDHeader, "trace-42")
+	recorder := httptest.NewRecorder()
+	api.handler.ServeHTTP(recorder, req)
+	if got := recorder.Header().Get(logging.RequestIDHeader); got != "trace-42" {
+		t.Fatalf("echoed request ID %q, want trace-42", got)
+	}
+
+	var served map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		var record map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		if record["msg"] == "Request served" {
+			served = record
+		}
+	}
+	if served == nil {
+		t.Fatalf("no access log in %s", logs)
+	}
+	if served["request_id"] != "trace-42" || served["user_id"] != float64(userID) || served["kit_id"] != strconv.FormatInt(kitID, 10) {
+		t.Fatalf("access log is not correlated: %v", served)
+	}
+
+	// Missing or unsafe IDs are replaced by a generated one.
+	for _, header := range []string{"", "bad id\nwith newline"} {
+		req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+		if header != "" {
+			req.Header.Set(logging.RequestIDHeader, header)
+		}
+		recorder := httptest.NewRecorder()
+		api.handler.ServeHTTP(recorder, req)
+		if got := recorder.Header().Get(logging.RequestIDHeader); len(got) != 32 {
+			t.Fatalf("header %q: generated request ID %q", header, got)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package server_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+type sensorBody struct {
+	MetricName   string   `json:"metric_name"`
+	MinThreshold *float64 `json:"min_threshold"`
+	MaxThreshold *float64 `json:"max_threshold"`
+}
+
+func TestGetMetricsListsBuiltins(t *testing.T) {
+	api := newTestAPI(t)
+	_, token := api.signUp("ada@example.com")
+
+	response := api.expect(http.StatusOK, http.MethodGet, "/v1/metrics/", token, nil)
+	var metrics []struct {
+		Name string `json:"name"`
+	}
+	api.decode(response, &metrics)
+	if len(metrics) != 4 || metrics[0].Name != "environment_humidity" {
+		t.Fatalf("got %+v, want the four built-in metrics sorted by name", metrics)
+	}
+
+	api.expect(http.StatusUnauthorized, http.MethodGet, "/v1/metrics/", "", nil)
+}
+
+func TestRegisterMetric(t *testing.T) {
+	api := newTestAPI(t)
+	_, token := api.signUp("admin@example.com")
+	_, userToken := api.signUp("ada@example.com")
+	kitID := api.createKit(userToken, "greenhouse")
+	reading := map[string]interface{}{"kit_id": kitID, "metrics": map[string]float64{"co2": 400}, "time": time.Now().Unix()}
+
+	// co2 is cached as unknown until it is registered
+	api.expect(http.StatusBadRequest, http.MethodPost, "/v1/garden/data/", "", reading)
+
+	body := map[string]interface{}{"name": "co2", "unit": "ppm", "min_value": 0, "max_value": 5000}
+	if response := api.expect(http.StatusForbidden, http.MethodPost, "/v1/metrics/", userToken, body); response.Code != "metric_admin_required" {
+		t.Fatalf("got code %q, want metric_admin_required", response.Code)
+	}
+	api.expect(http.StatusCreated, http.MethodPost, "/v1/metrics/", token, body)
+	api.expect(http.StatusConflict, http.MethodPost, "/v1/metrics/", token, body)
+	api.expect(http.StatusBadRequest, http.MethodPost, "/v1/metrics/", token, map[string]interface{}{
+		"name": "lux", "unit": "lx", "min_value": 10, "max_value": 1,
+	})
+	api.expect(http.StatusCreated, http.MethodPost, "/v1/garden/data/", "", reading)
+
+	response := api.expect(http.StatusOK, http.MethodGet, "/v1/metrics/?filter[name]=co", token, nil)
+	var metrics []struct {
+		Name string `json:"name"`
+	}
+	api.decode(response, &metrics)
+	if len(metrics) != 1 || metrics[0].Name != "co2" {
+		t.Fatalf("got %+v, want the registered metric", metrics)
+	}
+}
+
+func TestKitSensors(t *testing.T) {
+	api := newTestAPI(t)
+	_, token := api.signUp("ada@example.com")
+	_, otherToken := api.signUp("grace@example.com")
+	kitID := api.createKit(token, "greenhouse")
+
+	// Only the owner of the kit may see or change its sensors
+	api.expect(http.StatusForbidden, http.MethodGet, path("/v1/metrics/kit/%d", kitID), otherToken, nil)
+	api.expect(http.StatusForbidden, http.MethodPut, path("/v1/metrics/kit/%d", kitID), otherToken, map[string][]string{
+		"metrics": {"temperature"},
+	})
+	api.expect(http.StatusForbidden, http.MethodPut, path("/v1/metrics/kit/%d/thresholds/ph_level", kitID), otherToken, map[string]float64{
+		"min_threshold": 5.5,
+	})
+	api.expect(http.StatusNotFound, http.MethodPut, path("/v1/metrics/kit/%d", kitID+100), token, map[string][]string{
+		"metrics": {"temperature"},
+	})
+
+	response := api.expect(http.StatusOK, http.MethodPut, path("/v1/metrics/kit/%d", kitID), token, map[string][]string{
+		"metrics": {"temperature", "ph_level"},
+	})
+	var sensors []sensorBody
+	api.decode(response, &sensors)
+	if len(sensors) != 2 || sensors[0].MetricName != "ph_level" {
+		t.Fatalf("got %+v, want ph_level and temperature", sensors)
+	}
+
+	api.expect(http.StatusBadRequest, http.MethodPut, path("/v1/metrics/kit/%d", kitID), token, map[string][]string{
+		"metrics": {"radiation"},
+	})
+
+	response = api.expect(http.StatusOK, http.MethodPut, path("/v1/metrics/kit/%d/thresholds/ph_level", kitID), token, map[string]float64{
+		"min_threshold": 5.5, "max_threshold": 7,
+	})
+	var sensor sensorBody
+	api.decode(response, &sensor)
+	if sensor.MinThreshold == nil || *sensor.MinThreshold != 5.5 || sensor.MaxThreshold == nil || *sensor.MaxThreshold != 7 {
+		t.Fatalf("got %+v, want thresholds 5.5..7", sensor)
+	}
+	api.expect(http.StatusBadRequest, http.MethodPut, path("/v1/metrics/kit/%d/thresholds/ph_level", kitID), token, map[string]float64{
+		"min_threshold": 8, "max_threshold": 7,
+	})
+
+	response = api.expect(http.StatusOK, http.MethodGet, path("/v1/metrics/kit/%d", kitID), token, nil)
+	api.decode(response, &sensors)
+	if len(sensors) != 2 || sensors[0].MinThreshold == nil {
+		t.Fatalf("got %+v, want both sensors with the ph_level thresholds kept", sensors)
+	}
+}
@@ -0,0 +1,75 @@
+package server_test
+
+import (
+	database "api-order/src/Database"
+	"api-order/src/config"
+	"bytes"
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMigrateUpDownStatus(t *testing.T) {
+	cfg := config.DatabaseConfig{
+		Driver:       config.DriverSQLite,
+		Path:         filepath.Join(t.TempDir(), "api.db"),
+		MaxOpenConns: 1,
+		MaxIdleConns: 1,
+	}
+	db, err := database.Open(cfg)
+	if err != nil {
+		t.Fatalf("failed to open SQLite database: %v", err)
+	}
+	defer db.Close()
+
+	migrator, err := database.NewMigrator(db, cfg)
+	if err != nil {
+		t.Fatalf("failed to load migrations: %v", err)
+	}
+	ctx := context.Background()
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil || len(statuses) == 0 {
+		t.Fatalf("status: got %v, %v; want pending migrations", statuses, err)
+	}
+	for _, status := range statuses {
+		if status.Applied {
+			t.Fatalf("%s applied before migrate up", status.Version)
+		}
+	}
+
+	applied, err := migrator.Up(ctx)
+	if err != nil || len(applied) != len(statuses) {
+		t.Fatalf("up: applied %v, %v; want all %d migrations", applied, err, len(statuses))
+	}
+	if _, err := db.Exec("INSERT INTO users (first_name, last_name, email, password) VALUES ('Ada', 'Lovelace', 'ada@example.com', 'x')"); err != nil {
+		t.Fatalf("schema not usable after migrate up: %v", err)
+	}
+	if again, err := migrator.Up(ctx); err != nil || len(again) != 0 {
+		t.Fatalf("second up: applied %v, %v; want nothing", again, err)
+	}
+
+	var out bytes.Buffer
+	if err := database.RunMigrateCommand(migrator, []string{"status"}, &out); err != nil {
+		t.Fatalf("migrate status: %v", err)
+	}
+	if strings.Contains(out.String(), "pending") {
+		t.Fatalf("migrate status after up:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := database.RunMigrateCommand(migrator, []string{"down", "-steps", "100"}, &out); err != nil {
+		t.Fatalf("migrate down: %v", err)
+	}
+	if _, err := db.Exec("SELECT 1 FROM users"); err == nil {
+		t.Fatal("users still exists after migrate down")
+	}
+	if err := database.RunMigrateCommand(migrator, []string{"sideways"}, &out); err == nil {
+		t.Fatal("unknown migrate command: want an error")
+	}
+
+	if applied, err := migrator.Up(ctx); err != nil || len(applied) != len(statuses) {
+		t.Fatalf("up after down: applied %v, %v", applied, err)
+	}
+}
@@ -0,0 +1,81 @@
+package server_test
+
+import (
+	database "api-order/src/Database"
+	"api-order/src/config"
+	"api-order/src/server"
+	"database/sql"
+	"fmt"
+	"net"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// TestMySQLBackend reruns the HTTP suite against the MySQL repositories. It
+// needs a server the tests may create databases on, given as a driver DSN
+// without a database name, e.g.
+//
+//	TEST_MYSQL_URL=root:secret@tcp(localhost:3306)/
+func TestMySQLBackend(t *testing.T) {
+	rawURL := os.Getenv("TEST_MYSQL_URL")
+	if rawURL == "" {
+		t.Skip("TEST_MYSQL_URL is not set")
+	}
+	admin, err := sql.Open("mysql", rawURL)
+	if err != nil {
+		t.Fatalf("failed to connect to MySQL: %v", err)
+	}
+	defer admin.Close()
+
+	base, err := mysqlTestConfig(rawURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var databases atomic.Int64
+	runContractSuite(t, func(t *testing.T, cfg config.Config) *server.Container {
+		t.Helper()
+
+		name := fmt.Sprintf("api_order_test_%d_%d", time.Now().UnixNano(), databases.Add(1))
+		if _, err := admin.Exec("CREATE DATABASE " + name); err != nil {
+			t.Fatalf("failed to create database %s: %v", name, err)
+		}
+		t.Cleanup(func() { admin.Exec("DROP DATABASE IF EXISTS " + name) })
+
+		cfg.Database = base
+		cfg.Database.Name = name
+		db, err := database.Open(cfg.Database)
+		if err != nil {
+			t.Fatalf("failed to open MySQL database: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+		return server.NewContainer(cfg, db)
+	})
+}
+
+// mysqlTestConfig turns TEST_MYSQL_URL into a DatabaseConfig without a
+// database name.
+func mysqlTestConfig(rawURL string) (config.DatabaseConfig, error) {
+	dsn, err := mysql.ParseDSN(rawURL)
+	if err != nil {
+		return config.DatabaseConfig{}, fmt.Errorf("invalid TEST_MYSQL_URL: %w", err)
+	}
+	host, port, err := net.SplitHostPort(dsn.Addr)
+	if err != nil {
+		return config.DatabaseConfig{}, fmt.Errorf("invalid TEST_MYSQL_URL address %q: %w", dsn.Addr, err)
+	}
+	return config.DatabaseConfig{
+		Driver:       config.DriverMySQL,
+		User:         dsn.User,
+		Password:     dsn.Passwd,
+		Host:         host,
+		Port:         port,
+		MaxOpenConns: 5,
+		MaxIdleConns: 5,
+		AutoMigrate:  true,
+	}, nil
+}
@@ -0,0 +1,133 @@
+package server_test
+
+import (
+	"api-order/src/shared/i18n"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+)
+
+type preferencesBody struct {
+	TemperatureUnit       string `json:"temperature_unit"`
+	Timezone              string `json:"timezone"`
+	Locale                string `json:"locale"`
+	DashboardRangeMinutes int    `json:"dashboard_range_minutes"`
+}
+
+func TestUserPreferences(t *testing.T) {
+	api := newTestAPI(t)
+	id, token := api.signUp("ada@example.com")
+	otherID, _ := api.signUp("grace@example.com")
+	preferencesPath := path("/v1/users/%d/preferences", id)
+
+	var prefs preferencesBody
+	api.decode(api.expect(http.StatusOK, http.MethodGet, preferencesPath, token, nil), &prefs)
+	if prefs != (preferencesBody{TemperatureUnit: "C", Timezone: "UTC", DashboardRangeMinutes: 60}) {
+		t.Fatalf("got defaults %+v", prefs)
+	}
+	api.expect(http.StatusForbidden, http.MethodGet, path("/v1/users/%d/preferences", otherID), token, nil)
+
+	if response := api.expect(http.StatusBadRequest, http.MethodPut, preferencesPath, token, preferencesBody{
+		TemperatureUnit: "F", Timezone: "Mars/Olympus_Mons", DashboardRangeMinutes: 30,
+	}); response.Code != "invalid_timezone" {
+		t.Fatalf("got code %q, want invalid_timezone", response.Code)
+	}
+	if response := api.expect(http.StatusBadRequest, http.MethodPut, preferencesPath, token, preferencesBody{
+		TemperatureUnit: "K", Timezone: "UTC", DashboardRangeMinutes: 30,
+	}); len(response.Fields) != 1 || response.Fields[0].Field != "temperature_unit" {
+		t.Fatalf("got fields %+v, want temperature_unit rejected", response.Fields)
+	}
+
+	want := preferencesBody{TemperatureUnit: "F", Timezone: "America/New_York", Locale: "en", DashboardRangeMinutes: 30}
+	response := api.expect(http.StatusOK, http.MethodPut, preferencesPath, token, want)
+	api.decode(response, &prefs)
+	if prefs != want || response.Message != i18n.Translate(i18n.English, "user.preferences_updated") {
+		t.Fatalf("got %+v with message %q, want %+v in English", prefs, response.Message, want)
+	}
+	api.decode(api.expect(http.StatusOK, http.MethodGet, preferencesPath, token, nil), &prefs)
+	if prefs != want {
+		t.Fatalf("got stored %+v, want %+v", prefs, want)
+	}
+}
+
+// TestGardenDataFollowsPreferences checks that garden data and statistics are
+// rendered in the unit and timezone the user chose, without logging in again.
+func TestGardenDataFollowsPreferences(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("timezone database unavailable: %v", err)
+	}
+	_, wantOffset := time.Now().In(newYork).Zone()
+
+	api := newTestAPI(t)
+	id, token := api.signUp("ada@example.com")
+	kitID := api.createKit(token, "greenhouse")
+	api.postReading(kitID, map[string]float64{"temperature": 20, "ph_level": 6.5})
+
+	api.expect(http.StatusOK, http.MethodPut, path("/v1/users/%d/preferences", id), token, preferencesBody{
+		TemperatureUnit: "F", Timezone: "America/New_York", DashboardRangeMinutes: 30,
+	})
+
+	// Without minutes the dashboard range applies
+	response := api.expect(http.StatusOK, http.MethodGet, path("/v1/garden/data/kit/%d/minutes", kitID), token, nil)
+	var records []struct {
+		recordBody
+		TemperatureUnit string    `json:"temperature_unit"`
+		Timestamp       time.Time `json:"timestamp"`
+	}
+	api.decode(response, &records)
+	if len(records) != 1 || records[0].Temperature != 68 || records[0].TemperatureUnit != "F" {
+		t.Fatalf("got %+v, want 68 °F", records)
+	}
+	if _, offset := records[0].Timestamp.Zone(); offset != wantOffset {
+		t.Fatalf("got timestamp %v, want New York time", records[0].Timestamp)
+	}
+
+	var samples []sampleBody
+	api.decode(api.expect(http.StatusOK, http.MethodGet, path("/v1/garden/data/kit/%d/samples/minutes?metric=temperature", kitID), token, nil), &samples)
+	if len(samples) != 1 || samples[0].Value != 68 {
+		t.Fatalf("got samples %+v, want 68 °F", samples)
+	}
+
+	var stats struct {
+		TemperatureUnit string `json:"temperature_unit"`
+		Metrics         []struct {
+			Metric   string  `json:"metric"`
+			AvgValue float64 `json:"avg_value"`
+		} `json:"metrics"`
+	}
+	api.decode(api.expect(http.StatusOK, http.MethodGet, path("/v1/garden/data/kit/%d/statistics", kitID), token, nil), &stats)
+	found := false
+	for _, metric := range stats.Metrics {
+		found = found || metric.Metric == "temperature" && math.Abs(metric.AvgValue-68) < 1e-9
+	}
+	if !found || stats.TemperatureUnit != "F" {
+		t.Fatalf("got statistics %+v, want an average temperature of 68 °F", stats)
+	}
+
+	recorder := api.send(http.MethodGet, path("/v1/garden/data/kit/%d/export?columns=temperature", kitID), token, "", nil)
+	if recorder.Code != http.StatusOK || recorder.Body.String() != "temperature\n68\n" {
+		t.Fatalf("export: status %d, body %q, want 68 °F", recorder.Code, recorder.Body.String())
+	}
+
+	api.expect(http.StatusBadRequest, http.MethodGet, path("/v1/garden/data/kit/%d/minutes/0", kitID), token, nil)
+}
+
+// TestLocaleFollowsPreferences checks that a new locale applies to every
+// authenticated route, not only the garden data ones, while the token still
+// carries the locale it was issued with.
+func TestLocaleFollowsPreferences(t *testing.T) {
+	api := newTestAPI(t)
+	id, token := api.signUp("ada@example.com")
+
+	if response := api.expect(http.StatusOK, http.MethodGet, "/v1/kits/", token, nil); response.Message != i18n.Translate(i18n.Spanish, "kit.list_retrieved") {
+		t.Fatalf("got message %q, want it in Spanish", response.Message)
+	}
+	api.expect(http.StatusOK, http.MethodPut, path("/v1/users/%d/preferences", id), token, preferencesBody{
+		TemperatureUnit: "C", Timezone: "UTC", Locale: "en", DashboardRangeMinutes: 60,
+	})
+	if response := api.expect(http.StatusOK, http.MethodGet, "/v1/kits/", token, nil); response.Message != i18n.Translate(i18n.English, "kit.list_retrieved") {
+		t.Fatalf("got message %q, want it in English", response.Message)
+	}
+}
@@ -0,0 +1,129 @@
+package server_test
+
+import (
+	database "api-order/src/Database"
+	"api-order/src/config"
+	"api-order/src/server"
+	"api-order/src/shared/lifecycle"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProbes(t *testing.T) {
+	api := newTestAPI(t)
+
+	api.expect(http.StatusOK, http.MethodGet, "/healthz", "", nil)
+
+	var ready server.Readiness
+	api.decode(api.expect(http.StatusOK, http.MethodGet, "/readyz", "", nil), &ready)
+	if ready.Draining {
+		t.Fatal("ready server reported as draining")
+	}
+	for name, status := range ready.Dependencies {
+		if status.Status != "up" {
+			t.Fatalf("%s is %s", name, status.Status)
+		}
+	}
+
+	var version struct {
+		Version   string `json:"version"`
+		GoVersion string `json:"go_version"`
+	}
+	api.decode(api.expect(http.StatusOK, http.MethodGet, "/version", "", nil), &version)
+	if version.Version == "" || version.GoVersion == "" {
+		t.Fatalf("incomplete build information: %+v", version)
+	}
+}
+
+func TestReadinessHidesDependencyErrors(t *testing.T) {
+	cfg := config.Config{
+		JWT:        config.JWTConfig{SecretKey: "test-secret-key-of-at-least-32-bytes", TTL: time.Hour},
+		GardenData: config.GardenDataConfig{StatisticsCacheTTL: 5 * time.Minute, ClockSkewTolerance: 5 * time.Minute},
+	}
+	srv, err := server.NewServerWithContainer(server.NewMemoryContainer(cfg))
+	if err != nil {
+		t.Fatalf("failed to build server: %v", err)
+	}
+
+	// The workers only start with Run, so they are all down.
+	recorder := httptest.NewRecorder()
+	srv.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if recorder.Code != http.StatusServiceUnavailable {
+		t.Fatalf("GET /readyz: status %d", recorder.Code)
+	}
+	if strings.Contains(recorder.Body.String(), lifecycle.ErrNotRunning.Error()) {
+		t.Fatalf("/readyz exposes the dependency errors: %s", recorder.Body.String())
+	}
+	var response struct {
+		Data server.Readiness `json:"data"`
+	}
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		t.Fatalf("invalid /readyz response %q: %v", recorder.Body.String(), err)
+	}
+	if len(response.Data.Dependencies) == 0 {
+		t.Fatal("/readyz lists no dependencies")
+	}
+	for name, status := range response.Data.Dependencies {
+		if status.Status != "down" {
+			t.Fatalf("%s is %s before the workers start", name, status.Status)
+		}
+	}
+}
+
+func TestReadinessFailsWhileDraining(t *testing.T) {
+	cfg := config.Config{
+		Server: config.ServerConfig{Host: "127.0.0.1", Port: "0", DrainDelay: 300 * time.Millisecond, ShutdownTimeout: 5 * time.Second},
+		Cors:   config.CorsConfig{MaxAge: time.Hour},
+		JWT:    config.JWTConfig{SecretKey: "test-secret-key-of-at-least-32-bytes", TTL: time.Hour},
+		Database: config.DatabaseConfig{
+			Driver:       config.DriverSQLite,
+			Path:         filepath.Join(t.TempDir(), "api.db"),
+			MaxOpenConns: 1,
+			MaxIdleConns: 1,
+			AutoMigrate:  true,
+		},
+		GardenData: config.GardenDataConfig{StatisticsCacheTTL: 5 * time.Minute, ClockSkewTolerance: 5 * time.Minute},
+	}
+	db, err := database.Open(cfg.Database)
+	if err != nil {
+		t.Fatalf("failed to open SQLite database: %v", err)
+	}
+	srv, err := server.NewServerWithContainer(server.NewContainer(cfg, db))
+	if err != nil {
+		t.Fatalf("failed to build server: %v", err)
+	}
+	readyz := func() (int, server.Readiness) {
+		recorder := httptest.NewRecorder()
+		srv.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+		var response struct {
+			Data server.Readiness `json:"data"`
+		}
+		if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+			t.Fatalf("invalid /readyz response %q: %v", recorder.Body.String(), err)
+		}
+		return recorder.Code, response.Data
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error, 1)
+	go func() { stopped <- srv.Run(ctx) }()
+	time.Sleep(100 * time.Millisecond)
+
+	if code, ready := readyz(); code != http.StatusOK || ready.Dependencies["database"].Status != "up" {
+		t.Fatalf("before shutdown: status %d, %+v", code, ready)
+	}
+	cancel()
+	time.Sleep(100 * time.Millisecond)
+	if code, ready := readyz(); code != http.StatusServiceUnavailable || !ready.Draining {
+		t.Fatalf("while draining: status %d, %+v", code, ready)
+	}
+	if err := <-stopped; err != nil {
+		t.Fatalf("run: %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package server_test
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPrometheusMetrics(t *testing.T) {
+	api := newTestAPI(t)
+	_, token := api.signUp("prometheus@example.com")
+	kitID := api.createKit(token, "greenhouse")
+
+	api.postReading(kitID, map[string]float64{"temperature": 21.5})
+	api.do(http.MethodPost, "/v1/garden/data/", "", map[string]interface{}{"kit_id": kitID, "temperature": 500, "time": time.Now().Unix()})
+	api.do(http.MethodPost, "/v1/users/login", "", map[string]string{"email": "prometheus@example.com", "password": "wrong-password"})
+	api.expect(http.StatusCreated, http.MethodPost, "/v1/alerts/", token, map[string]interface{}{
+		"kit_id": kitID, "alert_type": "under_min", "message": "Too cold",
+	})
+
+	recorder := api.send(http.MethodGet, "/metrics", "", "", nil)
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("GET /metrics: status %d", recorder.Code)
+	}
+	body := recorder.Body.String()
+	for _, want := range []string{
+		`http_requests_total{method="POST",route="/v1/garden/data/",status="201"}`,
+		`http_request_duration_seconds_bucket{method="POST",route="/v1/garden/data/",le="+Inf"}`,
+		`garden_readings_ingested_total{kit_id="` + strconv.FormatInt(kitID, 10) + `"}`,
+		`garden_ingestion_rejections_total{reason="invalid_readings"}`,
+		`login_failures_total{reason="invalid_credentials"}`,
+		`alerts_raised_total{type="under_min"}`,
+		`go_goroutines`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("metrics do not include %s", want)
+		}
+	}
+}
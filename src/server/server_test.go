@@ -0,0 +1,214 @@
+package server_test
+
+import (
+	"api-order/src/config"
+	"api-order/src/server"
+	"api-order/src/shared/pagination"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// apiResponse mirrors responses.Response with the payload left raw.
+type apiResponse struct {
+	Success    bool             `json:"success"`
+	Message    string           `json:"message"`
+	Code       string           `json:"code"`
+	Error      string           `json:"error"`
+	Fields     []fieldError     `json:"fields"`
+	Data       json.RawMessage  `json:"data"`
+	Pagination *pagination.Page `json:"pagination"`
+}
+
+type fieldError struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Param   string `json:"param"`
+	Message string `json:"message"`
+}
+
+// newContainer builds the repositories behind each testAPI. It defaults to the
+// in-memory ones; TestSQLiteBackend swaps it to rerun the suite on SQLite.
+var newContainer = func(t *testing.T, cfg config.Config) *server.Container {
+	return server.NewMemoryContainer(cfg)
+}
+
+// testAPI drives the full Gin engine backed by in-memory repositories.
+type testAPI struct {
+	t       *testing.T
+	handler http.Handler
+}
+
+func newTestAPI(t *testing.T) *testAPI {
+	t.Helper()
+
+	cfg := config.Config{
+		Server: config.ServerConfig{Host: "127.0.0.1", Port: "0"},
+		Cors:   config.CorsConfig{MaxAge: time.Hour},
+		JWT: config.JWTConfig{
+			SecretKey:    "test-secret-key-of-at-least-32-bytes",
+			TTL:          time.Hour,
+			Issuer:       "api-order-test",
+			UserCacheTTL: time.Hour,
+		},
+		Metric: config.MetricConfig{
+			AdminEmails:      []string{"admin@example.com"},
+			RegistryCacheTTL: time.Hour,
+		},
+		GardenData: config.GardenDataConfig{
+			StatisticsCacheTTL: 5 * time.Minute,
+			ClockSkewTolerance: 5 * time.Minute,
+			ReadingsByKit:      true,
+		},
+	}
+	srv, err := server.NewServerWithContainer(newContainer(t, cfg))
+	if err != nil {
+		t.Fatalf("failed to build server: %v", err)
+	}
+
+	// Run starts the background workers, e.g. the import runner, as in
+	// production; the requests still go straight to the handler.
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan error, 1)
+	go func() { stopped <- srv.Run(ctx) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-stopped; err != nil {
+			t.Errorf("run: %v", err)
+		}
+	})
+
+	api := &testAPI{t: t, handler: srv.Handler()}
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		if api.send(http.MethodGet, "/readyz", "", "", nil).Code == http.StatusOK {
+			return api
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("server did not become ready")
+		}
+	}
+}
+
+// send performs a request with a bearer token when token is not empty.
+func (api *testAPI) send(method, path, token, contentType string, body io.Reader) *httptest.ResponseRecorder {
+	api.t.Helper()
+
+	req := httptest.NewRequest(method, path, body)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	recorder := httptest.NewRecorder()
+	api.handler.ServeHTTP(recorder, req)
+	return recorder
+}
+
+// do sends a JSON request and decodes the response envelope.
+func (api *testAPI) do(method, path, token string, body interface{}) (int, apiResponse) {
+	api.t.Helper()
+
+	var payload bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&payload).Encode(body); err != nil {
+			api.t.Fatalf("failed to encode request body: %v", err)
+		}
+	}
+	recorder := api.send(method, path, token, "application/json", &payload)
+
+	var response apiResponse
+	if err := json.Unmarshal(recorder.Body.Bytes(), &response); err != nil {
+		api.t.Fatalf("%s %s: invalid JSON response %q: %v", method, path, recorder.Body.String(), err)
+	}
+	return recorder.Code, response
+}
+
+// expect fails the test unless the request answers status, and returns the envelope.
+func (api *testAPI) expect(status int, method, path, token string, body interface{}) apiResponse {
+	api.t.Helper()
+
+	code, response := api.do(method, path, token, body)
+	if code != status {
+		api.t.Fatalf("%s %s: status %d, want %d (message %q, error %q)", method, path, code, status, response.Message, response.Error)
+	}
+	return response
+}
+
+// decode unmarshals the data of a response into target.
+func (api *testAPI) decode(response apiResponse, target interface{}) {
+	api.t.Helper()
+
+	if err := json.Unmarshal(response.Data, target); err != nil {
+		api.t.Fatalf("failed to decode response data %s: %v", response.Data, err)
+	}
+}
+
+// registerUser creates a user and returns its id.
+func (api *testAPI) registerUser(email, password string) int64 {
+	api.t.Helper()
+
+	response := api.expect(http.StatusCreated, http.MethodPost, "/v1/users/", "", map[string]string{
+		"first_name": "Ada",
+		"last_name":  "Lovelace",
+		"email":      email,
+		"password":   password,
+		"kit_code":   "code-" + email,
+	})
+	var user struct {
+		ID int64 `json:"id"`
+	}
+	api.decode(response, &user)
+	return user.ID
+}
+
+// login returns a token for the given credentials.
+func (api *testAPI) login(email, password string) string {
+	api.t.Helper()
+
+	response := api.expect(http.StatusOK, http.MethodPost, "/v1/users/login", "", map[string]string{
+		"email":    email,
+		"password": password,
+	})
+	var session struct {
+		Token string `json:"token"`
+	}
+	api.decode(response, &session)
+	if session.Token == "" {
+		api.t.Fatal("login returned an empty token")
+	}
+	return session.Token
+}
+
+// signUp registers a user and logs it in.
+func (api *testAPI) signUp(email string) (int64, string) {
+	api.t.Helper()
+
+	id := api.registerUser(email, "secret123")
+	return id, api.login(email, "secret123")
+}
+
+// createKit creates a kit owned by the token's user and returns its id.
+func (api *testAPI) createKit(token, name string) int64 {
+	api.t.Helper()
+
+	response := api.expect(http.StatusCreated, http.MethodPost, "/v1/kits/", token, map[string]interface{}{
+		"name":        name,
+		"description": "Greenhouse " + name,
+	})
+	var kit struct {
+		ID int64 `json:"id"`
+	}
+	api.decode(response, &kit)
+	return kit.ID
+}
+
+func path(format string, args ...interface{}) string {
+	return fmt.Sprintf(format, args...)
+}
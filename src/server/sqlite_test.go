@@ -0,0 +1,269 @@
+package server_test
+
+import (
+	database "api-order/src/Database"
+	"api-order/src/config"
+	gardenDataApp "api-order/src/gardendata/application"
+	gardenDataEntities "api-order/src/gardendata/domain/entities"
+	gardenDataAdapters "api-order/src/gardendata/infrastructure/adapters"
+	metricEntities "api-order/src/metric/domain/entities"
+	"api-order/src/server"
+	"api-order/src/shared/pagination"
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// TestSQLiteBackend reruns the HTTP suite against the SQLite repositories, each
+// test on a freshly migrated database file.
+func TestSQLiteBackend(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping SQLite backend in short mode")
+	}
+
+	runContractSuite(t, openSQLite)
+}
+
+// openSQLite returns a container on a freshly migrated SQLite database file.
+func openSQLite(t *testing.T, cfg config.Config) *server.Container {
+	t.Helper()
+
+	cfg.Database = config.DatabaseConfig{
+		Driver:       config.DriverSQLite,
+		Path:         filepath.Join(t.TempDir(), "api.db"),
+		MaxOpenConns: 1,
+		MaxIdleConns: 1,
+		AutoMigrate:  true,
+	}
+	db, err := database.Open(cfg.Database)
+	if err != nil {
+		t.Fatalf("failed to open SQLite database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return server.NewContainer(cfg, db)
+}
+
+// TestSQLiteUnknownKit checks that writes for a kit that does not exist fail
+// on the foreign key and are answered as kit_not_found.
+func TestSQLiteUnknownKit(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping SQLite backend in short mode")
+	}
+
+	memory := newContainer
+	newContainer = openSQLite
+	defer func() { newContainer = memory }()
+
+	api := newTestAPI(t)
+	_, token := api.signUp("ada@example.com")
+
+	response := api.expect(http.StatusNotFound, http.MethodPost, "/v1/garden/data/", "", map[string]interface{}{
+		"kit_id": 999, "temperature": 21.5, "time": time.Now().Unix(),
+	})
+	if response.Code != "kit_not_found" {
+		t.Fatalf("garden data for an unknown kit: got code %q, want kit_not_found", response.Code)
+	}
+	response = api.expect(http.StatusNotFound, http.MethodPost, "/v1/alerts/", token, map[string]interface{}{
+		"kit_id": 999, "alert_type": "under_min", "message": "Soil too dry",
+	})
+	if response.Code != "kit_not_found" {
+		t.Fatalf("alert for an unknown kit: got code %q, want kit_not_found", response.Code)
+	}
+}
+
+// seedKit stores kit 1 of user 1 directly in the database.
+func seedKit(t *testing.T, c *server.Container) {
+	t.Helper()
+
+	for _, query := range []string{
+		"INSERT INTO users (id, first_name, last_name, email, password) VALUES (1, 'Ada', 'Lovelace', 'ada@example.com', 'x')",
+		"INSERT INTO kits (kit_id, user_id, name) VALUES (1, 1, 'greenhouse')",
+	} {
+		if _, err := c.DB.Exec(query); err != nil {
+			t.Fatalf("failed to seed kit: %v", err)
+		}
+	}
+}
+
+// TestSQLiteCompactsLegacyReadings checks that readings stored before metric
+// samples existed, which only have the fixed columns, are rolled up before the
+// retention job prunes them.
+func TestSQLiteCompactsLegacyReadings(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping SQLite backend in short mode")
+	}
+
+	c := openSQLite(t, config.Config{})
+	now := time.Now().UTC()
+	hour := now.Add(-72 * time.Hour).Truncate(time.Hour)
+	seedKit(t, c)
+	for _, statement := range []struct {
+		query string
+		args  []interface{}
+	}{
+		{"INSERT INTO garden_data (kit_id, temperature, ground_humidity, environment_humidity, ph_level, time, timestamp, event_time) VALUES (1, 20, 40, 60, 6, 0, ?, ?)", []interface{}{hour.Add(10 * time.Minute), hour.Add(10 * time.Minute)}},
+		{"INSERT INTO garden_data (kit_id, temperature, ground_humidity, environment_humidity, ph_level, time, timestamp, event_time) VALUES (1, 22, 50, 70, 7, 0, ?, ?)", []interface{}{hour.Add(20 * time.Minute), hour.Add(20 * time.Minute)}},
+	} {
+		if _, err := c.DB.Exec(statement.query, database.SQLiteArgs(statement.args...)...); err != nil {
+			t.Fatalf("failed to seed legacy readings: %v", err)
+		}
+	}
+
+	result, err := gardenDataApp.NewCompactGardenDataUseCase(c.Rollups, gardenDataEntities.RetentionPolicy{RawDays: 1}).Run(context.Background(), now)
+	if err != nil {
+		t.Fatalf("compaction failed: %v", err)
+	}
+	if result.PrunedRaw != 2 {
+		t.Fatalf("got %d raw rows pruned, want the 2 legacy readings", result.PrunedRaw)
+	}
+
+	rollups, err := c.Rollups.GetRollups(context.Background(), 1, gardenDataEntities.GranularityHour, hour, hour.Add(time.Hour), nil)
+	if err != nil {
+		t.Fatalf("failed to read rollups: %v", err)
+	}
+	want := map[string]float64{"temperature": 21, "ground_humidity": 45, "environment_humidity": 65, "ph_level": 6.5}
+	if len(rollups) != len(want) {
+		t.Fatalf("got rollups %+v, want one per fixed metric", rollups)
+	}
+	for _, rollup := range rollups {
+		if rollup.SampleCount != 2 || rollup.AvgValue != want[rollup.Metric] {
+			t.Errorf("got %s rollup %+v, want 2 samples averaging %v", rollup.Metric, rollup, want[rollup.Metric])
+		}
+	}
+}
+
+// TestSQLiteCompactsLateImports checks that rows imported into hours and days
+// that were already rolled up are added to those rollups before the retention
+// job prunes them.
+func TestSQLiteCompactsLateImports(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping SQLite backend in short mode")
+	}
+
+	c := openSQLite(t, config.Config{})
+	seedKit(t, c)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	hour := now.Add(-72 * time.Hour).Truncate(time.Hour)
+	day := time.Date(hour.Year(), hour.Month(), hour.Day(), 0, 0, 0, 0, time.UTC)
+	if err := c.Metrics.EnsureMetrics(ctx, metricEntities.BuiltinMetrics()); err != nil {
+		t.Fatalf("failed to seed metrics: %v", err)
+	}
+	compact := gardenDataApp.NewCompactGardenDataUseCase(c.Rollups, gardenDataEntities.RetentionPolicy{RawDays: 1})
+	importer := gardenDataApp.NewImportGardenDataUseCase(c.GardenData, nil, gardenDataAdapters.NewImportJobStoreMemory(0), nil,
+		gardenDataApp.NewRegisterGardenDataUseCase(c.GardenData, c.Metrics, nil, nil, nil))
+
+	importRows := func(at time.Time, ph float64) {
+		t.Helper()
+		rows := []gardenDataEntities.ImportRow{{Line: 2, Time: at.Unix(), Readings: map[string]float64{"ph_level": ph}}}
+		if job, err := importer.RunSync(ctx, 1, rows, nil); err != nil || job.InsertedRows != 1 {
+			t.Fatalf("failed to import row: job %+v, error %v", job, err)
+		}
+	}
+
+	importRows(hour.Add(30*time.Minute), 6)
+	if _, err := compact.Run(ctx, now); err != nil {
+		t.Fatalf("compaction failed: %v", err)
+	}
+
+	// The second file reaches further back than the first, into the same hour.
+	importRows(hour.Add(10*time.Minute), 7)
+	result, err := compact.Run(ctx, now)
+	if err != nil {
+		t.Fatalf("compaction failed: %v", err)
+	}
+	if result.PrunedRaw != 2 {
+		t.Fatalf("got %d raw rows pruned, want the late reading and its sample", result.PrunedRaw)
+	}
+
+	for granularity, from := range map[string]time.Time{gardenDataEntities.GranularityHour: hour, gardenDataEntities.GranularityDay: day} {
+		rollups, err := c.Rollups.GetRollups(ctx, 1, granularity, from, from.Add(time.Hour), []string{"ph_level"})
+		if err != nil {
+			t.Fatalf("failed to read %s rollups: %v", granularity, err)
+		}
+		if len(rollups) != 1 || rollups[0].SampleCount != 2 || rollups[0].AvgValue != 6.5 || rollups[0].MinValue != 6 || rollups[0].MaxValue != 7 {
+			t.Errorf("got %s rollups %+v, want one of 2 samples between 6 and 7", granularity, rollups)
+		}
+	}
+}
+
+// TestSQLiteRolledUpReadingsCountOnce checks that readings past the raw
+// retention which are rolled up but not pruned yet are only served from the
+// rollups.
+func TestSQLiteRolledUpReadingsCountOnce(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping SQLite backend in short mode")
+	}
+
+	c := openSQLite(t, config.Config{})
+	seedKit(t, c)
+	ctx := context.Background()
+	now := time.Now().UTC()
+	hour := now.Add(-72 * time.Hour).Truncate(time.Hour)
+	for _, stored := range []time.Time{hour.Add(10 * time.Minute), now.Add(-time.Minute)} {
+		if _, err := c.GardenData.Create(ctx, gardenDataEntities.GardenData{
+			KitID:     1,
+			PhLevel:   6.5,
+			Timestamp: stored,
+			EventTime: stored,
+			Samples:   []gardenDataEntities.MetricSample{{Metric: "ph_level", Value: 6.5}},
+		}); err != nil {
+			t.Fatalf("failed to store reading: %v", err)
+		}
+	}
+	if _, _, err := c.Rollups.RollupPending(ctx, hour.Add(time.Hour)); err != nil {
+		t.Fatalf("failed to roll up: %v", err)
+	}
+
+	policy := gardenDataEntities.RetentionPolicy{RawDays: 1}
+	params := pagination.CursorParams{Limit: 10, Sort: pagination.Sort{Field: gardenDataEntities.OrderByTimestamp, Desc: true}}
+	minutes := 7 * 24 * 60
+
+	records, page, err := gardenDataApp.NewGetMinutesGardenDataUseCase(c.GardenData, c.Rollups, policy).Run(ctx, 1, minutes, params)
+	if err != nil {
+		t.Fatalf("failed to read records: %v", err)
+	}
+	if len(records) != 2 || records[0].Aggregation != "" || records[1].Aggregation != gardenDataEntities.GranularityHour || page.HasMore {
+		t.Fatalf("got records %+v, want the recent raw reading and one hourly rollup", records)
+	}
+
+	samples, _, err := gardenDataApp.NewGetMinutesMetricSamplesUseCase(c.GardenData, c.Rollups, policy).Run(ctx, 1, minutes, []string{"ph_level"}, params)
+	if err != nil {
+		t.Fatalf("failed to read samples: %v", err)
+	}
+	if len(samples) != 2 || samples[0].Aggregation != "" || samples[1].Aggregation != gardenDataEntities.GranularityHour {
+		t.Fatalf("got samples %+v, want the recent raw sample and one hourly rollup", samples)
+	}
+}
+
+// TestSQLiteAlertsDataGaps checks that the data gap job raises one alert for a
+// silent kit and skips kits that never sent data.
+func TestSQLiteAlertsDataGaps(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping SQLite backend in short mode")
+	}
+
+	c := openSQLite(t, config.Config{})
+	seedKit(t, c)
+	if _, err := c.DB.Exec("INSERT INTO kits (kit_id, user_id, name) VALUES (2, 1, 'balcony')"); err != nil {
+		t.Fatalf("failed to seed kit: %v", err)
+	}
+	ctx := context.Background()
+	now := time.Now().UTC()
+	if _, err := c.GardenData.Create(ctx, gardenDataEntities.GardenData{KitID: 1, Timestamp: now.Add(-2 * time.Hour)}); err != nil {
+		t.Fatalf("failed to store reading: %v", err)
+	}
+
+	gaps := gardenDataApp.NewAlertDataGapsUseCase(c.GardenData, c.Alerts, gardenDataApp.NewAlertLocales(c.Kits, c.Users), nil, time.Hour)
+	for run, want := range []int{1, 0} {
+		raised, err := gaps.Run(ctx, now)
+		if err != nil {
+			t.Fatalf("run %d failed: %v", run, err)
+		}
+		if raised != want {
+			t.Fatalf("run %d raised %d alerts, want %d", run, raised, want)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package server_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// recordSpans installs a tracer provider that keeps every span in memory.
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+	return recorder
+}
+
+func TestTracing(t *testing.T) {
+	recorder := recordSpans(t)
+	api := newTestAPI(t)
+	_, token := api.signUp("tracing@example.com")
+	kitID := api.createKit(token, "greenhouse")
+	api.postReading(kitID, map[string]float64{"temperature": 21.5})
+	api.expect(http.StatusOK, http.MethodGet, "/healthz", "", nil)
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	if _, ok := spans["/healthz"]; ok {
+		t.Errorf("probe requests are traced")
+	}
+	request, ok := spans["/v1/garden/data/"]
+	if !ok {
+		t.Fatalf("no server span for POST /v1/garden/data/, got %v", spanNames(recorder.Ended()))
+	}
+	useCase, ok := spans["RegisterGardenDataUseCase.Run"]
+	if !ok {
+		t.Fatalf("no span for RegisterGardenDataUseCase.Run, got %v", spanNames(recorder.Ended()))
+	}
+	if useCase.Parent().SpanID() != request.SpanContext().SpanID() {
+		t.Errorf("use case span is not a child of the request span")
+	}
+
+	// SQL spans only exist when the repositories run on database/sql.
+	for name, span := range spans {
+		if !strings.HasPrefix(name, "sql.") || !strings.HasSuffix(name, "INSERT garden_data") {
+			continue
+		}
+		if span.SpanContext().TraceID() != request.SpanContext().TraceID() {
+			t.Errorf("%s is not part of the request trace", name)
+		}
+		return
+	}
+	for name := range spans {
+		if strings.HasPrefix(name, "sql.") {
+			t.Errorf("no span for the garden data insert, got %v", spanNames(recorder.Ended()))
+			return
+		}
+	}
+}
+
+func spanNames(spans []sdktrace.ReadOnlySpan) []string {
+	names := make([]string, 0, len(spans))
+	for _, span := range spans {
+		names = append(names, span.Name())
+	}
+	return names
+}
@@ -0,0 +1,102 @@
+package server_test
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestRegisterUser(t *testing.T) {
+	api := newTestAPI(t)
+
+	api.registerUser("ada@example.com", "secret123")
+
+	code, response := api.do(http.MethodPost, "/v1/users/", "", map[string]string{
+		"first_name": "Ada",
+		"last_name":  "Lovelace",
+		"email":      "ada@example.com",
+		"password":   "secret123",
+		"kit_code":   "another-code",
+	})
+	if code != http.StatusConflict || response.Success {
+		t.Fatalf("duplicate email: status %d, success %v, want 409", code, response.Success)
+	}
+
+	api.expect(http.StatusBadRequest, http.MethodPost, "/v1/users/", "", map[string]string{
+		"first_name": "Ada",
+		"email":      "not-an-email",
+		"password":   "123",
+	})
+}
+
+func TestRegisterUserRejectsTakenKitCode(t *testing.T) {
+	api := newTestAPI(t)
+	_, token := api.signUp("owner@example.com")
+	api.createKit(token, "greenhouse")
+
+	api.expect(http.StatusConflict, http.MethodPost, "/v1/users/", "", map[string]string{
+		"first_name": "Grace",
+		"last_name":  "Hopper",
+		"email":      "grace@example.com",
+		"password":   "secret123",
+		"kit_code":   "greenhouse",
+	})
+}
+
+func TestLogin(t *testing.T) {
+	api := newTestAPI(t)
+	api.registerUser("ada@example.com", "secret123")
+
+	if token := api.login("ada@example.com", "secret123"); token == "" {
+		t.Fatal("expected a token")
+	}
+	api.expect(http.StatusUnauthorized, http.MethodPost, "/v1/users/login", "", map[string]string{
+		"email": "ada@example.com", "password": "wrong-password",
+	})
+	api.expect(http.StatusNotFound, http.MethodPost, "/v1/users/login", "", map[string]string{
+		"email": "nobody@example.com", "password": "secret123",
+	})
+	api.expect(http.StatusBadRequest, http.MethodPost, "/v1/users/login", "", map[string]string{
+		"email": "ada@example.com",
+	})
+}
+
+func TestGetUserRequiresToken(t *testing.T) {
+	api := newTestAPI(t)
+	id, token := api.signUp("ada@example.com")
+
+	api.expect(http.StatusUnauthorized, http.MethodGet, path("/v1/users/%d", id), "", nil)
+	api.expect(http.StatusUnauthorized, http.MethodGet, path("/v1/users/%d", id), "not-a-token", nil)
+
+	response := api.expect(http.StatusOK, http.MethodGet, path("/v1/users/%d", id), token, nil)
+	var user struct {
+		ID    int64  `json:"id"`
+		Email string `json:"email"`
+	}
+	api.decode(response, &user)
+	if user.ID != id || user.Email != "ada@example.com" {
+		t.Fatalf("got user %+v, want id %d", user, id)
+	}
+
+	api.expect(http.StatusNotFound, http.MethodGet, "/v1/users/999", token, nil)
+	api.expect(http.StatusBadRequest, http.MethodGet, "/v1/users/abc", token, nil)
+}
+
+func TestUpdateUser(t *testing.T) {
+	api := newTestAPI(t)
+	id, token := api.signUp("ada@example.com")
+	otherID, _ := api.signUp("grace@example.com")
+
+	body := map[string]string{"first_name": "Augusta", "last_name": "King"}
+	response := api.expect(http.StatusOK, http.MethodPut, path("/v1/users/%d", id), token, body)
+	var user struct {
+		FirstName string `json:"first_name"`
+		LastName  string `json:"last_name"`
+	}
+	api.decode(response, &user)
+	if user.FirstName != "Augusta" || user.LastName != "King" {
+		t.Fatalf("got %+v, want the updated names", user)
+	}
+
+	api.expect(http.StatusForbidden, http.MethodPut, path("/v1/users/%d", otherID), token, body)
+	api.expect(http.StatusUnauthorized, http.MethodPut, path("/v1/users/%d", id), "", body)
+}
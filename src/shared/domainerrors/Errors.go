@@ -0,0 +1,112 @@
+// Package domainerrors classifies the errors of every module so the HTTP layer
+// can answer them consistently: each error has a Kind, which decides the
+// status code, and a stable Code that clients can match on.
+package domainerrors
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Kind tells how a client should react to an error.
+type Kind string
+
+const (
+	KindNotFound     Kind = "not_found"
+	KindConflict     Kind = "conflict"
+	KindValidation   Kind = "validation"
+	KindForbidden    Kind = "forbidden"
+	KindUnauthorized Kind = "unauthorized"
+)
+
+// Error is a domain error. Code is machine-readable and never changes once
+// published, e.g. "kit_not_found"; Message explains it to a developer.
+//
+// Errors are declared once as package variables and returned as is, wrapped
+// with fmt.Errorf("%w") or with Wrap. Two errors with the same code match
+// under errors.Is.
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	cause   error
+}
+
+// NotFound declares an error for a resource that does not exist.
+func NotFound(code, message string) *Error {
+	return &Error{Kind: KindNotFound, Code: code, Message: message}
+}
+
+// Conflict declares an error for a request that clashes with the stored state,
+// such as a duplicate name.
+func Conflict(code, message string) *Error {
+	return &Error{Kind: KindConflict, Code: code, Message: message}
+}
+
+// Validation declares an error for input that can never succeed as sent.
+func Validation(code, message string) *Error {
+	return &Error{Kind: KindValidation, Code: code, Message: message}
+}
+
+// Forbidden declares an error for an authenticated user acting on something
+// that is not theirs.
+func Forbidden(code, message string) *Error {
+	return &Error{Kind: KindForbidden, Code: code, Message: message}
+}
+
+// Unauthorized declares an error for a caller that could not be authenticated.
+func Unauthorized(code, message string) *Error {
+	return &Error{Kind: KindUnauthorized, Code: code, Message: message}
+}
+
+func (e *Error) Error() string {
+	if e.cause != nil {
+		return e.Message + ": " + e.cause.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Is matches any error with the same code, so a wrapped copy still matches the
+// declared variable.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code
+}
+
+// Wrap returns a copy of e caused by err.
+func (e *Error) Wrap(err error) *Error {
+	wrapped := *e
+	wrapped.cause = err
+	return &wrapped
+}
+
+// As returns the first domain error in the chain of err.
+func As(err error) (*Error, bool) {
+	var target *Error
+	if errors.As(err, &target) {
+		return target, true
+	}
+	return nil, false
+}
+
+// Errors of the storage adapters for constraints that no repository maps to a
+// more specific error.
+var (
+	ErrDuplicate        = Conflict("duplicate", "resource already exists")
+	ErrMissingReference = NotFound("reference_not_found", "referenced resource does not exist")
+)
+
+// Errors of the HTTP layer, for requests rejected before any use case runs.
+var (
+	ErrInvalidRequest   = Validation("invalid_request", "request could not be read")
+	ErrValidationFailed = Validation("validation_failed", "request validation failed")
+)
+
+// InvalidParameter reports a path or query parameter that cannot be used.
+func InvalidParameter(name, reason string) *Error {
+	return Validation("invalid_parameter", fmt.Sprintf("invalid %s: %s", name, reason))
+}
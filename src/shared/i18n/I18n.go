@@ -0,0 +1,169 @@
+// Package i18n translates the messages of the API. Every message is looked up
+// by a key, such as "kit.created" or "error.kit_not_found", in the catalog of
+// the request's locale, found under locales/<locale>.json.
+//
+// The locale of a request is the stored preference of the authenticated user
+// when there is one, otherwise the best match for its Accept-Language header,
+// otherwise Default.
+package i18n
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
+)
+
+// Supported locales.
+const (
+	Spanish = "es"
+	English = "en"
+	// Default is the language the API answered in before it was translated.
+	Default = Spanish
+)
+
+//go:embed locales/*.json
+var localeFiles embed.FS
+
+// catalogs maps each supported locale to its messages by key.
+var catalogs = loadCatalogs()
+
+// matcher picks the supported locale closest to an Accept-Language header.
+// Default comes first, so it wins when nothing matches.
+var matcher = language.NewMatcher([]language.Tag{language.Spanish, language.English})
+
+func loadCatalogs() map[string]map[string]string {
+	files, err := localeFiles.ReadDir("locales")
+	if err != nil {
+		panic(fmt.Sprintf("i18n: cannot read catalogs: %v", err))
+	}
+	loaded := make(map[string]map[string]string, len(files))
+	for _, file := range files {
+		data, err := localeFiles.ReadFile(path.Join("locales", file.Name()))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: cannot read catalog %s: %v", file.Name(), err))
+		}
+		var messages map[string]string
+		if err := json.Unmarshal(data, &messages); err != nil {
+			panic(fmt.Sprintf("i18n: invalid catalog %s: %v", file.Name(), err))
+		}
+		loaded[strings.TrimSuffix(file.Name(), ".json")] = messages
+	}
+	return loaded
+}
+
+// Supported tells whether there is a catalog for locale.
+func Supported(locale string) bool {
+	_, ok := catalogs[locale]
+	return ok
+}
+
+// Keys returns the sorted keys of the catalog of locale.
+func Keys(locale string) []string {
+	keys := make([]string, 0, len(catalogs[locale]))
+	for key := range catalogs[locale] {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Negotiate returns the supported locale that best matches an Accept-Language
+// header, or Default.
+func Negotiate(acceptLanguage string) string {
+	tag, _ := language.MatchStrings(matcher, acceptLanguage)
+	base, _ := tag.Base()
+	if Supported(base.String()) {
+		return base.String()
+	}
+	return Default
+}
+
+// Lookup returns the message for key in locale, formatted with args, and
+// whether the catalog has it.
+func Lookup(locale, key string, args ...interface{}) (string, bool) {
+	format, ok := catalogs[locale][key]
+	if !ok {
+		return "", false
+	}
+	if len(args) == 0 {
+		return format, true
+	}
+	return fmt.Sprintf(format, args...), true
+}
+
+// Translate returns the message for key in locale, falling back to the
+// Default catalog and then to the key itself, so a missing translation shows
+// up without breaking the response.
+func Translate(locale, key string, args ...interface{}) string {
+	if message, ok := Lookup(locale, key, args...); ok {
+		return message
+	}
+	if message, ok := Lookup(Default, key, args...); ok {
+		return message
+	}
+	return key
+}
+
+// T translates key into the locale of the request running in ctx.
+func T(ctx context.Context, key string, args ...interface{}) string {
+	return Translate(FromContext(ctx), key, args...)
+}
+
+// Message is a message to be rendered later, once the locale of its reader is
+// known, such as the text of an alert raised by the server.
+type Message struct {
+	Key  string
+	Args []interface{}
+}
+
+// NewMessage returns the message for key formatted with args.
+func NewMessage(key string, args ...interface{}) Message {
+	return Message{Key: key, Args: args}
+}
+
+// In renders the message in locale.
+func (m Message) In(locale string) string {
+	return Translate(locale, m.Key, m.Args...)
+}
+
+type localeKey struct{}
+
+// WithLocale returns a copy of ctx whose messages are rendered in locale.
+func WithLocale(ctx context.Context, locale string) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// FromContext returns the locale stored in ctx, or Default.
+func FromContext(ctx context.Context) string {
+	if locale, ok := ctx.Value(localeKey{}).(string); ok {
+		return locale
+	}
+	return Default
+}
+
+// SetLocale renders the rest of the request in locale and reports it in the
+// Content-Language header. Unsupported locales are ignored.
+func SetLocale(c *gin.Context, locale string) {
+	if !Supported(locale) {
+		return
+	}
+	c.Request = c.Request.WithContext(WithLocale(c.Request.Context(), locale))
+	c.Header("Content-Language", locale)
+}
+
+// Middleware negotiates the locale of every request from its Accept-Language
+// header. The JWT middleware later replaces it with the preference of the
+// authenticated user, if any.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		SetLocale(c, Negotiate(c.GetHeader("Accept-Language")))
+		c.Next()
+	}
+}
@@ -0,0 +1,111 @@
+package lifecycle
+
+import (
+	"api-order/src/shared/logging"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// Worker is a background component with its own goroutines, e.g. a scheduler,
+// a notification dispatcher or a message consumer. Start must not block;
+// Stop must return once the worker has exited or ctx is done, whichever
+// happens first.
+type Worker interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+// HealthChecker is implemented by workers that can tell whether they are
+// still doing their job, e.g. a consumer that lost its broker connection.
+type HealthChecker interface {
+	Health(ctx context.Context) error
+}
+
+// ErrNotRunning is reported for workers that are not started, or stopped.
+var ErrNotRunning = errors.New("not running")
+
+type registration struct {
+	name   string
+	worker Worker
+}
+
+// Manager starts the registered workers in registration order and stops them
+// in reverse order, so a worker can depend on the ones registered before it.
+type Manager struct {
+	mu      sync.Mutex
+	workers []registration
+	started int
+}
+
+func NewManager() *Manager {
+	return &Manager{}
+}
+
+// Register adds a worker. Workers registered after Start are not started.
+func (m *Manager) Register(name string, worker Worker) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.workers = append(m.workers, registration{name: name, worker: worker})
+}
+
+// Start starts every worker. If one fails, the ones already started are
+// stopped again and the error is returned.
+func (m *Manager) Start(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for m.started < len(m.workers) {
+		w := m.workers[m.started]
+		// The worker logs with its name, e.g. worker="data gap alerts".
+		if err := w.worker.Start(logging.With(ctx, "worker", w.name)); err != nil {
+			err = fmt.Errorf("failed to start %s: %w", w.name, err)
+			return errors.Join(err, m.stopLocked(ctx))
+		}
+		slog.Info("Started worker", "worker", w.name)
+		m.started++
+	}
+	return nil
+}
+
+// Stop stops the started workers in reverse order. Every worker is asked to
+// stop even when a previous one failed or ctx expired; the errors are joined.
+func (m *Manager) Stop(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.stopLocked(ctx)
+}
+
+func (m *Manager) stopLocked(ctx context.Context) error {
+	var errs []error
+	for ; m.started > 0; m.started-- {
+		w := m.workers[m.started-1]
+		if err := w.worker.Stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop %s: %w", w.name, err))
+			continue
+		}
+		slog.Info("Stopped worker", "worker", w.name)
+	}
+	return errors.Join(errs...)
+}
+
+// Health reports every registered worker by name: nil when it runs and is
+// healthy, ErrNotRunning or the error of its health check otherwise.
+func (m *Manager) Health(ctx context.Context) map[string]error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	health := make(map[string]error, len(m.workers))
+	for i, w := range m.workers {
+		if i >= m.started {
+			health[w.name] = ErrNotRunning
+			continue
+		}
+		if checker, ok := w.worker.(HealthChecker); ok {
+			health[w.name] = checker.Health(ctx)
+			continue
+		}
+		health[w.name] = nil
+	}
+	return health
+}
@@ -0,0 +1,62 @@
+package lifecycle_test
+
+import (
+	"api-order/src/shared/lifecycle"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+type recordingWorker struct {
+	name     string
+	events   *[]string
+	startErr error
+}
+
+func (w recordingWorker) Start(ctx context.Context) error {
+	if w.startErr != nil {
+		return w.startErr
+	}
+	*w.events = append(*w.events, "start "+w.name)
+	return nil
+}
+
+func (w recordingWorker) Stop(ctx context.Context) error {
+	*w.events = append(*w.events, "stop "+w.name)
+	return nil
+}
+
+func TestLifecycleStartsInOrderAndStopsInReverse(t *testing.T) {
+	var events []string
+	manager := lifecycle.NewManager()
+	manager.Register("scheduler", recordingWorker{name: "scheduler", events: &events})
+	manager.Register("dispatcher", recordingWorker{name: "dispatcher", events: &events})
+
+	ctx := context.Background()
+	if err := manager.Start(ctx); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	if err := manager.Stop(ctx); err != nil {
+		t.Fatalf("stop: %v", err)
+	}
+	if got := strings.Join(events, ", "); got != "start scheduler, start dispatcher, stop dispatcher, stop scheduler" {
+		t.Fatalf("got %s", got)
+	}
+}
+
+func TestLifecycleStopsStartedWorkersWhenOneFails(t *testing.T) {
+	var events []string
+	manager := lifecycle.NewManager()
+	manager.Register("scheduler", recordingWorker{name: "scheduler", events: &events})
+	manager.Register("consumer", recordingWorker{name: "consumer", events: &events, startErr: errors.New("broker unreachable")})
+	manager.Register("dispatcher", recordingWorker{name: "dispatcher", events: &events})
+
+	err := manager.Start(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "consumer") {
+		t.Fatalf("got %v, want the consumer start error", err)
+	}
+	if got := strings.Join(events, ", "); got != "start scheduler, stop scheduler" {
+		t.Fatalf("got %s", got)
+	}
+}
@@ -0,0 +1,85 @@
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDHeader carries the request ID in both directions.
+const RequestIDHeader = "X-Request-ID"
+
+type requestIDKey struct{}
+
+// RequestID returns the ID of the request running in ctx, or "".
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// Middleware reuses the X-Request-ID of the caller, e.g. a proxy, or generates
+// one, echoes it in the response and stores a logger tagged with it in the
+// request context. Once the request is served it logs its outcome.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		started := time.Now()
+		id := c.GetHeader(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		c.Header(RequestIDHeader, id)
+
+		ctx := context.WithValue(c.Request.Context(), requestIDKey{}, id)
+		ctx = With(ctx, "request_id", id)
+		// Links the logs to the trace of the request, when one is recorded.
+		if span := trace.SpanContextFromContext(ctx); span.IsSampled() {
+			ctx = With(ctx, "trace_id", span.TraceID().String())
+		}
+		// Every kit scoped route names its parameter kit_id.
+		if kitID := c.Param("kit_id"); kitID != "" {
+			ctx = With(ctx, "kit_id", kitID)
+		}
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		// Handlers may have added user_id or kit_id to the request logger.
+		logger := FromContext(c.Request.Context())
+		level := slog.LevelInfo
+		if c.Writer.Status() >= 500 {
+			level = slog.LevelError
+		}
+		logger.LogAttrs(c.Request.Context(), level, "Request served",
+			slog.String("method", c.Request.Method),
+			slog.String("route", c.FullPath()),
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", c.Writer.Status()),
+			slog.Duration("latency", time.Since(started)),
+			slog.String("client_ip", c.ClientIP()),
+		)
+	}
+}
+
+// validRequestID accepts IDs of up to 128 printable ASCII characters, so a
+// caller cannot inject arbitrary content into the logs.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for _, r := range id {
+		if r < '!' || r > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	var b [16]byte
+	rand.Read(b[:])
+	return hex.EncodeToString(b[:])
+}
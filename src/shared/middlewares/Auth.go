@@ -6,15 +6,18 @@ import (
 )
 
 
-func GenerateJWT(clientID int64, email string) (string, error) {
+// GenerateJWT issues a token for the user. locale is the stored language
+// preference of the user, applied to every request made with the token.
+func GenerateJWT(clientID int64, email, locale string) (string, error) {
 	claims := CustomClaims{
 		ClientID: clientID,
 		Email:  email,
+		Locale: locale,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(72 * time.Hour)), // Expira en 72 horas
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(jwtTTL)),         // Expira tras el TTL configurado
 			IssuedAt:  jwt.NewNumericDate(time.Now()),                     // Fecha de emisión
 			NotBefore: jwt.NewNumericDate(time.Now()),                     // No válido antes de
-			Issuer:    "myapp",                                            // Emisor del token
+			Issuer:    jwtIssuer,                                          // Emisor del token
 		},
 	}
 
@@ -0,0 +1,23 @@
+package middlewares
+
+import "github.com/gin-gonic/gin"
+
+// authenticatedKey holds the handler OnAuthenticated mounted for a request.
+const authenticatedKey = "onAuthenticated"
+
+// OnAuthenticated returns a middleware for the whole engine that runs handler
+// as soon as JWTAuthMiddleware accepts the token of a request, whatever the
+// route. Requests to public routes never run it.
+func OnAuthenticated(handler func(c *gin.Context, claims *CustomClaims)) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(authenticatedKey, handler)
+		c.Next()
+	}
+}
+
+// authenticated runs the handler mounted with OnAuthenticated, if any.
+func authenticated(c *gin.Context, claims *CustomClaims) {
+	if handler, ok := c.Get(authenticatedKey); ok {
+		handler.(func(c *gin.Context, claims *CustomClaims))(c, claims)
+	}
+}
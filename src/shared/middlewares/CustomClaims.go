@@ -5,5 +5,7 @@ import "github.com/golang-jwt/jwt/v4"
 type CustomClaims struct {
 	ClientID int64  `json:"client_id"`
 	Email  string `json:"email"`
+	// Locale is the stored language preference of the user, empty when unset
+	Locale string `json:"locale,omitempty"`
 	jwt.RegisteredClaims
 }
\ No newline at end of file
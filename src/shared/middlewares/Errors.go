@@ -0,0 +1,87 @@
+package middlewares
+
+import (
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/logging"
+	"api-order/src/shared/responses"
+	"api-order/src/shared/validation"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Codes of the errors that are not domain errors.
+const (
+	CodeTimeout  = "timeout"
+	CodeInternal = "internal_error"
+)
+
+// errorStatuses maps each kind of domain error to its HTTP status.
+var errorStatuses = map[domainerrors.Kind]int{
+	domainerrors.KindNotFound:     http.StatusNotFound,
+	domainerrors.KindConflict:     http.StatusConflict,
+	domainerrors.KindValidation:   http.StatusBadRequest,
+	domainerrors.KindForbidden:    http.StatusForbidden,
+	domainerrors.KindUnauthorized: http.StatusUnauthorized,
+}
+
+// ErrorHandler answers the last error that a handler attached with ctx.Error,
+// so the same error gets the same status, code and message on every endpoint.
+// Handlers attach the error and return without writing a body.
+func ErrorHandler() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Next()
+
+		if len(ctx.Errors) == 0 || ctx.Writer.Written() {
+			return
+		}
+		err := ctx.Errors.Last().Err
+		status, response := ErrorResponse(i18n.FromContext(ctx.Request.Context()), err)
+		if status >= http.StatusInternalServerError {
+			logging.FromContext(ctx.Request.Context()).Error("Request failed", "status", status, "error", err)
+		}
+		ctx.JSON(status, response)
+	}
+}
+
+// ErrorResponse returns the status and body that answer err, with the message
+// in locale: the one of the error code, or else the one of its kind. Only
+// validation errors expose their full text, which explains what to fix, and
+// the rejected fields of the request body; other domain errors show their own
+// message and anything else is an internal error whose details stay in the
+// logs.
+func ErrorResponse(locale string, err error) (int, responses.Response) {
+	if domainErr, ok := domainerrors.As(err); ok {
+		detail := domainErr.Message
+		if domainErr.Kind == domainerrors.KindValidation {
+			detail = err.Error()
+		}
+		message, ok := i18n.Lookup(locale, "error."+domainErr.Code)
+		if !ok {
+			message = i18n.Translate(locale, "error."+string(domainErr.Kind))
+		}
+		fields, _ := validation.FieldErrors(err)
+		return errorStatuses[domainErr.Kind], responses.Response{
+			Success: false,
+			Message: message,
+			Code:    domainErr.Code,
+			Error:   detail,
+			Fields:  fields,
+		}
+	}
+	if responses.IsTimeout(err) {
+		return http.StatusGatewayTimeout, responses.Response{
+			Success: false,
+			Message: i18n.Translate(locale, "error."+CodeTimeout),
+			Code:    CodeTimeout,
+			Error:   "operation timed out",
+		}
+	}
+	return http.StatusInternalServerError, responses.Response{
+		Success: false,
+		Message: i18n.Translate(locale, "error.internal"),
+		Code:    CodeInternal,
+		Error:   "Internal server error",
+	}
+}
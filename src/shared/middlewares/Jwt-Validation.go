@@ -1,35 +1,47 @@
 package middlewares
 
 import (
-	"log"
-	"net/http"
-	"os"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v4"
-	"github.com/joho/godotenv"
-	"api-order/src/shared/responses"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/logging"
 )
 
-var jwtKey []byte
+var (
+	jwtKey    []byte
+	jwtTTL    = 72 * time.Hour
+	jwtIssuer = "myapp"
+)
 
-func init() {
-	err := godotenv.Load()
-	if err != nil {
-		log.Printf("Error loading .env file: %v", err)
+// SetJWT replaces the key, lifetime and issuer of the tokens. It must be
+// called at startup, before any token is generated or validated; zero values
+// keep the current setting.
+func SetJWT(key []byte, ttl time.Duration, issuer string) {
+	if len(key) > 0 {
+		jwtKey = key
+	}
+	if ttl > 0 {
+		jwtTTL = ttl
+	}
+	if issuer != "" {
+		jwtIssuer = issuer
 	}
-
-	jwtKey = []byte(os.Getenv("JWT_SECRET_KEY"))
 }
 
+var (
+	ErrMissingToken = domainerrors.Unauthorized("missing_token", "token no proporcionado")
+	ErrInvalidToken = domainerrors.Unauthorized("invalid_token", "invalido el token proporcionado")
+)
+
 func JWTAuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
-			c.JSON(http.StatusUnauthorized, responses.Response{Success: false,
-				Message: "acceso denegado para el recurso solicitado",
-				Error:   "token no proporcionado o invalido el token proporcionado"})
+			c.Error(ErrMissingToken)
 			c.Abort()
 			return
 		}
@@ -42,14 +54,18 @@ func JWTAuthMiddleware() gin.HandlerFunc {
 		})
 
 		if err != nil || !token.Valid {
-			c.JSON(http.StatusUnauthorized, responses.Response{
-				Success: false,
-				Message: "acceso denegado para el recurso solicitado",
-				Error:   "invalido el token proporcionado"})
+			c.Error(ErrInvalidToken.Wrap(err))
 			c.Abort()
 			return
 		}
 		c.Set("datUser", claims)
+		c.Request = c.Request.WithContext(logging.With(c.Request.Context(), "user_id", claims.ClientID))
+		// The preference of the user wins over Accept-Language
+		if claims.Locale != "" {
+			i18n.SetLocale(c, claims.Locale)
+		}
+		// e.g. the stored preferences, which are fresher than the token
+		authenticated(c, claims)
 		c.Next()
 	}
 }
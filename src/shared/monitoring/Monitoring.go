@@ -0,0 +1,128 @@
+package monitoring
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// registry holds every metric of the API, besides the Go runtime and process
+// collectors. It is served by Handler.
+var registry = prometheus.NewRegistry()
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP requests by method, route and status code.",
+	}, []string{"method", "route", "status"})
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency by method and route.",
+		Buckets: []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10, 30},
+	}, []string{"method", "route"})
+
+	// ReadingsIngested counts stored garden data records by kit. Every kit
+	// adds a time series, so the kit_id label is AllKits unless the per kit
+	// breakdown is enabled in the configuration.
+	ReadingsIngested = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "garden_readings_ingested_total",
+		Help: "Garden data records stored, by kit.",
+	}, []string{"kit_id"})
+	// IngestionRejections counts garden data submissions that were not stored, by reason.
+	IngestionRejections = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "garden_ingestion_rejections_total",
+		Help: "Garden data submissions rejected, by reason.",
+	}, []string{"reason"})
+	// AlertsRaised counts stored alerts by type.
+	AlertsRaised = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "alerts_raised_total",
+		Help: "Alerts raised, by type.",
+	}, []string{"type"})
+	// LoginFailures counts failed logins by reason.
+	LoginFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "login_failures_total",
+		Help: "Failed login attempts, by reason.",
+	}, []string{"reason"})
+)
+
+// AllKits is the kit_id of ReadingsIngested when it is not broken down by kit.
+const AllKits = "all"
+
+// Reasons of IngestionRejections and LoginFailures.
+const (
+	ReasonInvalidRequest     = "invalid_request"
+	ReasonInvalidReadings    = "invalid_readings"
+	ReasonUnknownEmail       = "unknown_email"
+	ReasonInvalidCredentials = "invalid_credentials"
+	ReasonTimeout            = "timeout"
+	ReasonInternalError      = "internal_error"
+)
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests, httpDuration,
+		ReadingsIngested, IngestionRejections, AlertsRaised, LoginFailures,
+		pool,
+	)
+}
+
+// Handler serves the metrics in the Prometheus exposition format.
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry})
+}
+
+// Middleware records the count and latency of every request by route
+// template, e.g. /v1/kits/:id, so the label set stays bounded.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		started := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		httpRequests.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Inc()
+		httpDuration.WithLabelValues(c.Request.Method, route).Observe(time.Since(started).Seconds())
+	}
+}
+
+// pool exposes the sql.DBStats of the connection pool set with SetDB.
+var pool = &poolCollector{}
+
+type poolCollector struct {
+	mu    sync.RWMutex
+	stats prometheus.Collector
+}
+
+// SetDB exposes the statistics of db, labelled with its driver, replacing the
+// previous pool if any. A nil db stops exposing them.
+func SetDB(db *sql.DB, driver string) {
+	pool.mu.Lock()
+	defer pool.mu.Unlock()
+	pool.stats = nil
+	if db != nil {
+		pool.stats = collectors.NewDBStatsCollector(db, driver)
+	}
+}
+
+// Describe sends nothing: the collector is unchecked because the pool is
+// only known once the database is opened.
+func (p *poolCollector) Describe(chan<- *prometheus.Desc) {}
+
+func (p *poolCollector) Collect(ch chan<- prometheus.Metric) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.stats != nil {
+		p.stats.Collect(ch)
+	}
+}
@@ -0,0 +1,51 @@
+package pagination
+
+import "time"
+
+// KeysetClause builds the SQL used by cursor pages on (timeColumn, idColumn).
+// condition is empty on the first page and otherwise starts with " AND ";
+// orderBy sorts in the requested direction with the id as tie breaker.
+// Queries should fetch Limit+1 rows and pass them through TrimPage.
+func KeysetClause(timeColumn, idColumn string, params CursorParams) (condition string, args []interface{}, orderBy string) {
+	op, direction := ">", "ASC"
+	if params.Sort.Desc {
+		op, direction = "<", "DESC"
+	}
+	if params.Cursor != nil {
+		condition = " AND (" + timeColumn + " " + op + " ? OR (" + timeColumn + " = ? AND " + idColumn + " " + op + " ?))"
+		args = []interface{}{params.Cursor.Time, params.Cursor.Time, params.Cursor.ID}
+	}
+	orderBy = " ORDER BY " + timeColumn + " " + direction + ", " + idColumn + " " + direction
+	return condition, args, orderBy
+}
+
+// TrimPage cuts a result fetched with Limit+1 rows down to limit and reports
+// whether more rows follow.
+func TrimPage[T any](items []T, limit int) ([]T, bool) {
+	if len(items) > limit {
+		return items[:limit], true
+	}
+	return items, false
+}
+
+// KeysetAfter reports whether a row at (t, id) lies past the cursor in the
+// sort direction, the condition KeysetClause adds for repositories that page
+// in memory. It is always true on the first page.
+func KeysetAfter(params CursorParams, t time.Time, id int64) bool {
+	if params.Cursor == nil {
+		return true
+	}
+	return KeysetLess(params, params.Cursor.Time, params.Cursor.ID, t, id)
+}
+
+// KeysetLess reports whether the row at (t1, id1) sorts before the row at
+// (t2, id2) in the order KeysetClause uses.
+func KeysetLess(params CursorParams, t1 time.Time, id1 int64, t2 time.Time, id2 int64) bool {
+	if params.Sort.Desc {
+		t1, id1, t2, id2 = t2, id2, t1, id1
+	}
+	if !t1.Equal(t2) {
+		return t1.Before(t2)
+	}
+	return id1 < id2
+}
@@ -0,0 +1,210 @@
+// Package pagination holds the conventions shared by every list endpoint:
+//
+//	limit=N             page size (defaults and maximum depend on the endpoint)
+//	offset=N            rows to skip, for small collections
+//	cursor=TOKEN        opaque position returned as next_cursor, for time series
+//	sort=field|-field   sort field, "-" for descending
+//	filter[name]=value  equality or substring filters supported by the endpoint
+//
+// Repositories receive the parsed parameters; handlers return the Page
+// metadata in responses.Response.Pagination.
+package pagination
+
+import (
+	"api-order/src/shared/domainerrors"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidParams wraps every parsing error so handlers can answer 400.
+var ErrInvalidParams = domainerrors.Validation("invalid_pagination", "invalid pagination parameters")
+
+// Sort is a field to order by and its direction.
+type Sort struct {
+	Field string
+	Desc  bool
+}
+
+// String renders the sort in query form, e.g. "-timestamp".
+func (s Sort) String() string {
+	if s.Desc {
+		return "-" + s.Field
+	}
+	return s.Field
+}
+
+// Spec describes what a list endpoint accepts.
+type Spec struct {
+	DefaultLimit int
+	MaxLimit     int
+	// Sorts lists the accepted sort values in query form ("name", "-created_at").
+	// The first one is the default.
+	Sorts []string
+	// Filters lists the accepted filter names.
+	Filters []string
+}
+
+// OffsetParams paginates small collections by position.
+type OffsetParams struct {
+	Limit   int
+	Offset  int
+	Sort    Sort
+	Filters map[string]string
+}
+
+// CursorParams paginates time series by keyset. Cursor is nil on the first page.
+type CursorParams struct {
+	Limit   int
+	Cursor  *Cursor
+	Sort    Sort
+	Filters map[string]string
+}
+
+// Cursor is the position of the last row of a page: its sort time and its id,
+// which breaks ties between rows with the same time.
+type Cursor struct {
+	Time time.Time
+	ID   int64
+}
+
+// Encode returns the opaque token sent to clients.
+func (c Cursor) Encode() string {
+	raw := strconv.FormatInt(c.Time.UnixNano(), 10) + ":" + strconv.FormatInt(c.ID, 10)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a token produced by Cursor.Encode.
+func DecodeCursor(token string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, fmt.Errorf("%w: malformed cursor", ErrInvalidParams)
+	}
+	parts := strings.SplitN(string(raw), ":", 2)
+	if len(parts) != 2 {
+		return Cursor{}, fmt.Errorf("%w: malformed cursor", ErrInvalidParams)
+	}
+	nanos, err1 := strconv.ParseInt(parts[0], 10, 64)
+	id, err2 := strconv.ParseInt(parts[1], 10, 64)
+	if err1 != nil || err2 != nil {
+		return Cursor{}, fmt.Errorf("%w: malformed cursor", ErrInvalidParams)
+	}
+	return Cursor{Time: time.Unix(0, nanos), ID: id}, nil
+}
+
+// Page is the pagination metadata returned with a list.
+type Page struct {
+	Limit      int    `json:"limit"`
+	Offset     *int   `json:"offset,omitempty"`
+	Total      *int64 `json:"total,omitempty"`
+	Sort       string `json:"sort"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+}
+
+// OffsetPage builds the metadata of an offset page given the total row count.
+func OffsetPage(params OffsetParams, total int64) *Page {
+	offset := params.Offset
+	return &Page{
+		Limit:   params.Limit,
+		Offset:  &offset,
+		Total:   &total,
+		Sort:    params.Sort.String(),
+		HasMore: int64(params.Offset+params.Limit) < total,
+	}
+}
+
+// CursorPage builds the metadata of a cursor page. last is the position of the
+// last row returned and is only used when hasMore is true.
+func CursorPage(params CursorParams, hasMore bool, last Cursor) *Page {
+	page := &Page{Limit: params.Limit, Sort: params.Sort.String(), HasMore: hasMore}
+	if hasMore {
+		page.NextCursor = last.Encode()
+	}
+	return page
+}
+
+// ParseOffset reads limit, offset, sort and filters from a query string.
+func ParseOffset(query url.Values, spec Spec) (OffsetParams, error) {
+	limit, sort, filters, err := parseCommon(query, spec)
+	if err != nil {
+		return OffsetParams{}, err
+	}
+	offset := 0
+	if value := query.Get("offset"); value != "" {
+		offset, err = strconv.Atoi(value)
+		if err != nil || offset < 0 {
+			return OffsetParams{}, fmt.Errorf("%w: offset must be a non negative integer", ErrInvalidParams)
+		}
+	}
+	return OffsetParams{Limit: limit, Offset: offset, Sort: sort, Filters: filters}, nil
+}
+
+// ParseCursor reads limit, cursor, sort and filters from a query string.
+func ParseCursor(query url.Values, spec Spec) (CursorParams, error) {
+	limit, sort, filters, err := parseCommon(query, spec)
+	if err != nil {
+		return CursorParams{}, err
+	}
+	params := CursorParams{Limit: limit, Sort: sort, Filters: filters}
+	if token := query.Get("cursor"); token != "" {
+		cursor, err := DecodeCursor(token)
+		if err != nil {
+			return CursorParams{}, err
+		}
+		params.Cursor = &cursor
+	}
+	return params, nil
+}
+
+func parseCommon(query url.Values, spec Spec) (int, Sort, map[string]string, error) {
+	limit := spec.DefaultLimit
+	if value := query.Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed <= 0 || parsed > spec.MaxLimit {
+			return 0, Sort{}, nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidParams, spec.MaxLimit)
+		}
+		limit = parsed
+	}
+
+	sortValue := query.Get("sort")
+	if sortValue == "" && len(spec.Sorts) > 0 {
+		sortValue = spec.Sorts[0]
+	}
+	allowed := false
+	for _, candidate := range spec.Sorts {
+		if candidate == sortValue {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return 0, Sort{}, nil, fmt.Errorf("%w: sort must be one of %s", ErrInvalidParams, strings.Join(spec.Sorts, ", "))
+	}
+	sort := Sort{Field: strings.TrimPrefix(sortValue, "-"), Desc: strings.HasPrefix(sortValue, "-")}
+
+	filters := map[string]string{}
+	for key, values := range query {
+		if !strings.HasPrefix(key, "filter[") || !strings.HasSuffix(key, "]") {
+			continue
+		}
+		name := key[len("filter[") : len(key)-1]
+		known := false
+		for _, candidate := range spec.Filters {
+			if candidate == name {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return 0, Sort{}, nil, fmt.Errorf("%w: unknown filter %q", ErrInvalidParams, name)
+		}
+		if len(values) > 0 && values[0] != "" {
+			filters[name] = values[0]
+		}
+	}
+	return limit, sort, filters, nil
+}
@@ -0,0 +1,92 @@
+package pagination
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestKeysetClause(t *testing.T) {
+	at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name      string
+		params    CursorParams
+		condition string
+		args      []interface{}
+		orderBy   string
+	}{
+		{
+			name:    "first page ascending",
+			params:  CursorParams{Sort: Sort{Field: "timestamp"}},
+			orderBy: " ORDER BY timestamp ASC, data_id ASC",
+		},
+		{
+			name:    "first page descending",
+			params:  CursorParams{Sort: Sort{Field: "timestamp", Desc: true}},
+			orderBy: " ORDER BY timestamp DESC, data_id DESC",
+		},
+		{
+			name:      "next page ascending",
+			params:    CursorParams{Sort: Sort{Field: "timestamp"}, Cursor: &Cursor{Time: at, ID: 5}},
+			condition: " AND (timestamp > ? OR (timestamp = ? AND data_id > ?))",
+			args:      []interface{}{at, at, int64(5)},
+			orderBy:   " ORDER BY timestamp ASC, data_id ASC",
+		},
+		{
+			name:      "next page descending",
+			params:    CursorParams{Sort: Sort{Field: "timestamp", Desc: true}, Cursor: &Cursor{Time: at, ID: 5}},
+			condition: " AND (timestamp < ? OR (timestamp = ? AND data_id < ?))",
+			args:      []interface{}{at, at, int64(5)},
+			orderBy:   " ORDER BY timestamp DESC, data_id DESC",
+		},
+	}
+	for _, c := range cases {
+		condition, args, orderBy := KeysetClause("timestamp", "data_id", c.params)
+		if condition != c.condition || !reflect.DeepEqual(args, c.args) || orderBy != c.orderBy {
+			t.Errorf("%s: got %q %v %q, want %q %v %q", c.name, condition, args, orderBy, c.condition, c.args, c.orderBy)
+		}
+	}
+}
+
+// TestKeysetAfter checks that the in-memory keyset matches the SQL condition.
+func TestKeysetAfter(t *testing.T) {
+	at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	cursor := &Cursor{Time: at, ID: 5}
+
+	cases := []struct {
+		desc bool
+		t    time.Time
+		id   int64
+		want bool
+	}{
+		{false, at.Add(time.Second), 1, true},
+		{false, at, 6, true},
+		{false, at, 5, false},
+		{false, at.Add(-time.Second), 9, false},
+		{true, at.Add(-time.Second), 9, true},
+		{true, at, 4, true},
+		{true, at, 5, false},
+		{true, at.Add(time.Second), 1, false},
+	}
+	for _, c := range cases {
+		params := CursorParams{Sort: Sort{Field: "timestamp", Desc: c.desc}, Cursor: cursor}
+		if got := KeysetAfter(params, c.t, c.id); got != c.want {
+			t.Errorf("desc=%v (%v, %d): got %v, want %v", c.desc, c.t, c.id, got, c.want)
+		}
+	}
+	if !KeysetAfter(CursorParams{}, at, 0) {
+		t.Error("every row follows the first page cursor")
+	}
+}
+
+func TestTrimPage(t *testing.T) {
+	items, more := TrimPage([]int{1, 2, 3}, 2)
+	if !reflect.DeepEqual(items, []int{1, 2}) || !more {
+		t.Fatalf("got %v, %v, want [1 2] with more", items, more)
+	}
+	items, more = TrimPage([]int{1, 2}, 2)
+	if !reflect.DeepEqual(items, []int{1, 2}) || more {
+		t.Fatalf("got %v, %v, want [1 2] without more", items, more)
+	}
+}
@@ -0,0 +1,111 @@
+package pagination
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	for _, cursor := range []Cursor{
+		{Time: time.Unix(0, 0), ID: 0},
+		{Time: time.Date(2024, 3, 1, 12, 30, 0, 123456789, time.UTC), ID: 42},
+		{Time: time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC), ID: 1 << 62},
+	} {
+		decoded, err := DecodeCursor(cursor.Encode())
+		if err != nil {
+			t.Fatalf("DecodeCursor(%+v.Encode()) failed: %v", cursor, err)
+		}
+		if !decoded.Time.Equal(cursor.Time) || decoded.ID != cursor.ID {
+			t.Errorf("got %+v, want %+v", decoded, cursor)
+		}
+	}
+}
+
+func TestDecodeCursorRejectsMalformedTokens(t *testing.T) {
+	for _, token := range []string{
+		"not base64!",
+		"MTIz",       // "123", no id
+		"YWJjOjE",    // "abc:1"
+		"MTIzOnh5eg", // "123:xyz"
+	} {
+		if _, err := DecodeCursor(token); !errors.Is(err, ErrInvalidParams) {
+			t.Errorf("DecodeCursor(%q): got error %v, want ErrInvalidParams", token, err)
+		}
+	}
+}
+
+func TestParseCommon(t *testing.T) {
+	spec := Spec{
+		DefaultLimit: 20,
+		MaxLimit:     100,
+		Sorts:        []string{"name", "-created_at"},
+		Filters:      []string{"name"},
+	}
+
+	cases := []struct {
+		query   string
+		limit   int
+		sort    Sort
+		filters map[string]string
+		invalid bool
+	}{
+		{query: "", limit: 20, sort: Sort{Field: "name"}, filters: map[string]string{}},
+		{query: "limit=1", limit: 1, sort: Sort{Field: "name"}, filters: map[string]string{}},
+		{query: "limit=100&sort=-created_at", limit: 100, sort: Sort{Field: "created_at", Desc: true}, filters: map[string]string{}},
+		{query: "filter[name]=basil", limit: 20, sort: Sort{Field: "name"}, filters: map[string]string{"name": "basil"}},
+		{query: "filter[name]=", limit: 20, sort: Sort{Field: "name"}, filters: map[string]string{}},
+		{query: "limit=0", invalid: true},
+		{query: "limit=101", invalid: true},
+		{query: "limit=-1", invalid: true},
+		{query: "limit=ten", invalid: true},
+		{query: "sort=created_at", invalid: true},
+		{query: "filter[owner]=ada", invalid: true},
+	}
+	for _, c := range cases {
+		query, err := url.ParseQuery(c.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		limit, sort, filters, err := parseCommon(query, spec)
+		if c.invalid {
+			if !errors.Is(err, ErrInvalidParams) {
+				t.Errorf("%q: got error %v, want ErrInvalidParams", c.query, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error %v", c.query, err)
+			continue
+		}
+		if limit != c.limit || sort != c.sort || !reflect.DeepEqual(filters, c.filters) {
+			t.Errorf("%q: got limit %d, sort %+v, filters %v; want %d, %+v, %v", c.query, limit, sort, filters, c.limit, c.sort, c.filters)
+		}
+	}
+}
+
+func TestParseCursorAndOffset(t *testing.T) {
+	spec := Spec{DefaultLimit: 10, MaxLimit: 50, Sorts: []string{"-timestamp"}}
+	cursor := Cursor{Time: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), ID: 7}
+
+	params, err := ParseCursor(url.Values{"cursor": {cursor.Encode()}}, spec)
+	if err != nil {
+		t.Fatalf("ParseCursor failed: %v", err)
+	}
+	if params.Cursor == nil || !params.Cursor.Time.Equal(cursor.Time) || params.Cursor.ID != 7 || params.Limit != 10 {
+		t.Fatalf("got %+v, want the cursor and the default limit", params)
+	}
+	if _, err := ParseCursor(url.Values{"cursor": {"%%%"}}, spec); !errors.Is(err, ErrInvalidParams) {
+		t.Fatalf("malformed cursor: got error %v, want ErrInvalidParams", err)
+	}
+
+	offset, err := ParseOffset(url.Values{"offset": {"30"}}, spec)
+	if err != nil || offset.Offset != 30 {
+		t.Fatalf("got %+v, %v, want offset 30", offset, err)
+	}
+	if _, err := ParseOffset(url.Values{"offset": {"-1"}}, spec); !errors.Is(err, ErrInvalidParams) {
+		t.Fatalf("negative offset: got error %v, want ErrInvalidParams", err)
+	}
+}
@@ -0,0 +1,121 @@
+// Package preferences carries the display preferences of the authenticated
+// user through a request, so every module renders values in the user's
+// temperature unit and local times in the user's timezone.
+//
+// Temperatures are stored in Celsius, the unit of the temperature metric, and
+// converted only when they are written to a response.
+package preferences
+
+import (
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/logging"
+	"api-order/src/shared/middlewares"
+	"context"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Temperature units.
+const (
+	Celsius    = "C"
+	Fahrenheit = "F"
+)
+
+// DefaultDashboardRangeMinutes is the range of recent data shown before the
+// user picks one.
+const DefaultDashboardRangeMinutes = 60
+
+// Preferences are the display settings of a user.
+type Preferences struct {
+	// TemperatureUnit is "C" or "F"
+	TemperatureUnit string `json:"temperature_unit"`
+	// Timezone is an IANA name, e.g. "Europe/Madrid", used for local times
+	Timezone string `json:"timezone"`
+	// Locale is the language of the messages; empty follows Accept-Language
+	Locale string `json:"locale"`
+	// DashboardRangeMinutes is the window of recent data when none is requested
+	DashboardRangeMinutes int `json:"dashboard_range_minutes"`
+}
+
+// Default returns the preferences of a user who has not chosen any.
+func Default() Preferences {
+	return Preferences{
+		TemperatureUnit:       Celsius,
+		Timezone:              "UTC",
+		DashboardRangeMinutes: DefaultDashboardRangeMinutes,
+	}
+}
+
+// Location returns the timezone of the preferences, or UTC when it is not a
+// known one.
+func (p Preferences) Location() *time.Location {
+	if p.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(p.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Fahrenheit reports whether temperatures are shown in Fahrenheit.
+func (p Preferences) Fahrenheit() bool {
+	return p.TemperatureUnit == Fahrenheit
+}
+
+// Temperature converts a temperature in Celsius to the preferred unit.
+func (p Preferences) Temperature(celsius float64) float64 {
+	if p.Fahrenheit() {
+		return celsius*9/5 + 32
+	}
+	return celsius
+}
+
+// TemperatureDelta converts a difference of temperatures in Celsius, such as
+// a standard deviation, to the preferred unit.
+func (p Preferences) TemperatureDelta(celsius float64) float64 {
+	if p.Fahrenheit() {
+		return celsius * 9 / 5
+	}
+	return celsius
+}
+
+type preferencesKey struct{}
+
+// WithPreferences returns a copy of ctx that renders values with p.
+func WithPreferences(ctx context.Context, p Preferences) context.Context {
+	return context.WithValue(ctx, preferencesKey{}, p)
+}
+
+// FromContext returns the preferences stored in ctx, or Default.
+func FromContext(ctx context.Context) Preferences {
+	if p, ok := ctx.Value(preferencesKey{}).(Preferences); ok {
+		return p
+	}
+	return Default()
+}
+
+// Loader returns the stored preferences of a user.
+type Loader func(ctx context.Context, userID int64) (Preferences, error)
+
+// Middleware loads the preferences of every user authenticated by
+// middlewares.JWTAuthMiddleware, whatever the route, and must be mounted on
+// the whole engine. They are read on each authenticated request, so a change
+// applies without logging in again, the locale included; load is expected to
+// cache them briefly. A failed lookup keeps the defaults rather than failing
+// the request.
+func Middleware(load Loader) gin.HandlerFunc {
+	return middlewares.OnAuthenticated(func(c *gin.Context, claims *middlewares.CustomClaims) {
+		p, err := load(c.Request.Context(), claims.ClientID)
+		if err != nil {
+			logging.FromContext(c.Request.Context()).Warn("Cannot load user preferences, using defaults", "error", err)
+			p = Default()
+		}
+		c.Request = c.Request.WithContext(WithPreferences(c.Request.Context(), p))
+		if p.Locale != "" {
+			i18n.SetLocale(c, p.Locale)
+		}
+	})
+}
@@ -0,0 +1,13 @@
+package responses
+
+// FieldError describes why one field of a request body was rejected.
+type FieldError struct {
+	// Field is the JSON path of the field, e.g. "email" or "metrics[0]"
+	Field string `json:"field"`
+	// Rule is the validation rule that failed, e.g. "required" or "min"
+	Rule string `json:"rule"`
+	// Param is the argument of the rule, e.g. "6" for min=6
+	Param string `json:"param,omitempty"`
+	// Message explains the failure to the user
+	Message string `json:"message"`
+}
@@ -1,8 +1,16 @@
 package responses
 
+import "api-order/src/shared/pagination"
+
 type Response struct {
 	Success bool 			`json:"success"`
 	Message string 			`json:"message"`
+	// Code identifies the error for clients, e.g. "kit_not_found"; empty on success
+	Code string 			`json:"code,omitempty"`
 	Error interface{} 		`json:"error"`
+	// Fields lists the rejected fields of an invalid request body
+	Fields []FieldError 	`json:"fields,omitempty"`
 	Data interface{} 		`json:"data"`
+	// Pagination is set by list endpoints
+	Pagination *pagination.Page `json:"pagination,omitempty"`
 }
\ No newline at end of file
@@ -0,0 +1,12 @@
+package responses
+
+import (
+	"context"
+	"errors"
+)
+
+// IsTimeout reports whether err comes from an operation that exceeded its deadline,
+// which controllers answer with 504 Gateway Timeout.
+func IsTimeout(err error) bool {
+	return errors.Is(err, context.DeadlineExceeded)
+}
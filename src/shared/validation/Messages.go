@@ -0,0 +1,63 @@
+package validation
+
+import (
+	"api-order/src/shared/i18n"
+	"reflect"
+)
+
+// sizeRules depend on the type of the field: they count characters in
+// strings, items in slices and maps, and compare the value of numbers.
+var sizeRules = map[string]bool{
+	"min": true, "max": true, "len": true,
+	"gt": true, "gte": true, "lt": true, "lte": true,
+}
+
+// messageKey returns the catalog key that explains a failed rule, e.g.
+// "validation.min.string". The catalogs format it with the parameter of the
+// rule.
+func messageKey(rule string, kind reflect.Kind) string {
+	if !sizeRules[rule] {
+		return "validation." + rule
+	}
+	switch kind {
+	case reflect.String:
+		return "validation." + rule + ".string"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "validation." + rule + ".length"
+	}
+	return "validation." + rule + ".value"
+}
+
+// message explains a failed rule to the user in locale.
+func message(locale, rule, param string, kind reflect.Kind) string {
+	var args []interface{}
+	if param != "" {
+		args = append(args, param)
+	}
+	if text, ok := i18n.Lookup(locale, messageKey(rule, kind), args...); ok {
+		return text
+	}
+	return i18n.Translate(locale, "validation.invalid")
+}
+
+// typeMessage explains in locale a JSON value that cannot be decoded into a
+// field of type t.
+func typeMessage(locale string, t reflect.Type) string {
+	key := "validation.type.invalid"
+	switch t.Kind() {
+	case reflect.String:
+		key = "validation.type.string"
+	case reflect.Bool:
+		key = "validation.type.bool"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		key = "validation.type.int"
+	case reflect.Float32, reflect.Float64:
+		key = "validation.type.float"
+	case reflect.Slice, reflect.Array:
+		key = "validation.type.list"
+	case reflect.Map, reflect.Struct:
+		key = "validation.type.object"
+	}
+	return i18n.Translate(locale, key)
+}
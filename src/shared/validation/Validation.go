@@ -0,0 +1,131 @@
+// Package validation checks request bodies for every controller with a single
+// validator, and reports each rejected field by its JSON name so clients can
+// show the message next to the matching form field.
+package validation
+
+import (
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/responses"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+// validate caches the rules of each request type, so it is shared by all
+// controllers instead of each one building its own.
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New()
+	// Report fields by the name clients send, not the Go one
+	v.RegisterTagNameFunc(jsonName)
+	return v
+}
+
+// jsonName is the key of field in a JSON body.
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// Errors lists the rejected fields of a request. It is the cause of
+// domainerrors.ErrValidationFailed.
+type Errors []responses.FieldError
+
+func (e Errors) Error() string {
+	failures := make([]string, len(e))
+	for i, field := range e {
+		rule := field.Rule
+		if field.Param != "" {
+			rule += "=" + field.Param
+		}
+		failures[i] = fmt.Sprintf("%s failed on '%s'", field.Field, rule)
+	}
+	return strings.Join(failures, ", ")
+}
+
+// FieldErrors returns the rejected fields reported by err, if any.
+func FieldErrors(err error) (Errors, bool) {
+	var fields Errors
+	if errors.As(err, &fields) {
+		return fields, true
+	}
+	return nil, false
+}
+
+// Struct validates the tags of a request body. The error is
+// domainerrors.ErrValidationFailed wrapping the Errors of every rule that
+// failed, explained in the locale of ctx.
+func Struct(ctx context.Context, request interface{}) error {
+	err := validate.Struct(request)
+	var failures validator.ValidationErrors
+	if !errors.As(err, &failures) {
+		// nil, or a request that is not a struct, which is a programming error
+		return err
+	}
+
+	requestType := reflect.TypeOf(request)
+	for requestType.Kind() == reflect.Pointer {
+		requestType = requestType.Elem()
+	}
+	locale := i18n.FromContext(ctx)
+	fields := make(Errors, len(failures))
+	for i, failure := range failures {
+		fields[i] = fieldError(locale, requestType, failure)
+	}
+	return domainerrors.ErrValidationFailed.Wrap(fields)
+}
+
+// BindJSON decodes the JSON body of the request into target and validates it.
+// A value of the wrong type is reported as a failed "type" rule of its field;
+// a body that is not JSON at all is domainerrors.ErrInvalidRequest.
+func BindJSON(ctx *gin.Context, target interface{}) error {
+	if err := ctx.ShouldBindJSON(target); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			return domainerrors.ErrValidationFailed.Wrap(Errors{{
+				Field:   typeErr.Field,
+				Rule:    "type",
+				Param:   typeErr.Type.String(),
+				Message: typeMessage(i18n.FromContext(ctx.Request.Context()), typeErr.Type),
+			}})
+		}
+		return domainerrors.ErrInvalidRequest.Wrap(err)
+	}
+	return Struct(ctx.Request.Context(), target)
+}
+
+// fieldError describes a failed rule with the JSON path of its field, e.g.
+// "metrics[0]" instead of "SetKitSensorsRequest.metrics[0]".
+func fieldError(locale string, requestType reflect.Type, failure validator.FieldError) responses.FieldError {
+	field := failure.Namespace()
+	if _, path, ok := strings.Cut(field, "."); ok {
+		field = path
+	}
+	param := failure.Param()
+	// Cross-field rules such as gtfield=MinValue name the other Go field
+	if strings.HasSuffix(failure.Tag(), "field") && requestType.Kind() == reflect.Struct {
+		if other, ok := requestType.FieldByName(param); ok {
+			param = jsonName(other)
+		}
+	}
+	return responses.FieldError{
+		Field:   field,
+		Rule:    failure.Tag(),
+		Param:   param,
+		Message: message(locale, failure.Tag(), param, failure.Kind()),
+	}
+}
@@ -1,9 +1,11 @@
 package application
 
 import (
+	"api-order/src/shared/logging"
+	"api-order/src/shared/tracing"
 	"api-order/src/user/domain/entities"
 	"api-order/src/user/domain/ports"
-	"fmt"
+	"context"
 )
 
 type GetUserByIdUseCase struct {
@@ -14,11 +16,14 @@ func NewGetUserByIdUseCase(userRepository ports.IUser) *GetUserByIdUseCase {
 	return &GetUserByIdUseCase{UserRepository: userRepository}
 }
 
-func (uc *GetUserByIdUseCase) Run(id int64) (entities.User, error) {
-	user, err := uc.UserRepository.GetById(id)
+func (uc *GetUserByIdUseCase) Run(ctx context.Context, id int64) (entities.User, error) {
+	ctx, span := tracing.Start(ctx, "GetUserByIdUseCase.Run")
+	defer span.End()
+
+	user, err := uc.UserRepository.GetById(ctx, id)
 	if err != nil {
-		fmt.Printf("Error fetching user by ID %d: %v\n", id, err)
-		return entities.User{}, err // Let controller interpret sql.ErrNoRows
+		logging.FromContext(ctx).Error("Error fetching user by ID", "user_id", id, "error", err)
+		return entities.User{}, err // ports.ErrUserNotFound when the user does not exist
 	}
 	return user, nil
 }
@@ -0,0 +1,28 @@
+package application
+
+import (
+	"api-order/src/shared/preferences"
+	"api-order/src/shared/tracing"
+	"api-order/src/user/domain/ports"
+	"context"
+)
+
+type GetUserPreferencesUseCase struct {
+	UserRepository ports.IUser
+}
+
+func NewGetUserPreferencesUseCase(userRepository ports.IUser) *GetUserPreferencesUseCase {
+	return &GetUserPreferencesUseCase{UserRepository: userRepository}
+}
+
+// Run returns the display preferences of a user, or ports.ErrUserNotFound.
+func (uc *GetUserPreferencesUseCase) Run(ctx context.Context, id int64) (preferences.Preferences, error) {
+	ctx, span := tracing.Start(ctx, "GetUserPreferencesUseCase.Run")
+	defer span.End()
+
+	user, err := uc.UserRepository.GetById(ctx, id)
+	if err != nil {
+		return preferences.Preferences{}, err
+	}
+	return user.Preferences(), nil
+}
@@ -1,10 +1,13 @@
 package application
 
 import (
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/logging"
+	"api-order/src/shared/tracing"
 	"api-order/src/user/application/services"
 	"api-order/src/user/domain/entities"
 	"api-order/src/user/domain/ports"
-	"fmt"
+	"context"
 )
 
 type LoginUseCase struct {
@@ -19,19 +22,25 @@ func NewLoginUseCase(userRepository ports.IUser, encryptService services.IEncryp
 	}
 }
 
-func (uc *LoginUseCase) Run(email string, password string) (entities.User, error) {
-	user, err := uc.UserRepository.GetByEmail(email)
+// ErrInvalidCredentials is returned when the password does not match the user's.
+var ErrInvalidCredentials = domainerrors.Unauthorized("invalid_credentials", "invalid credentials")
+
+func (uc *LoginUseCase) Run(ctx context.Context, email string, password string) (entities.User, error) {
+	ctx, span := tracing.Start(ctx, "LoginUseCase.Run")
+	defer span.End()
+
+	user, err := uc.UserRepository.GetByEmail(ctx, email)
 	if err != nil {
 		// Error could be "not found" or DB error
-		fmt.Printf("Error fetching user by email '%s': %v\n", email, err)
-		return entities.User{}, err // Let controller interpret sql.ErrNoRows
+		logging.FromContext(ctx).Error("Error fetching user by email", "email", email, "error", err)
+		return entities.User{}, err // ports.ErrUserNotFound when the user does not exist
 	}
 
 	// Compare password
 	err = uc.EncryptService.ComparePassword(user.Password, []byte(password))
 	if err != nil {
 		// Password mismatch
-		return entities.User{}, fmt.Errorf("invalid credentials") // Specific error for mismatch
+		return entities.User{}, ErrInvalidCredentials
 	}
 
 	// Login successful, return user data (controller will strip password)
@@ -2,10 +2,14 @@ package application
 
 import (
 	kit "api-order/src/kit/domain/ports"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/logging"
+	"api-order/src/shared/preferences"
+	"api-order/src/shared/tracing"
 	"api-order/src/user/application/services"
 	"api-order/src/user/domain/entities"
 	"api-order/src/user/domain/ports"
-	"errors"
+	"context"
 	"fmt"
 )
 
@@ -23,15 +27,18 @@ func NewRegisterUserUseCase(userRepository ports.IUser, kitRepository kit.IKit,
 	}
 }
 
-var ErrKitCodeExists = errors.New("kit code already exists")
-var ErrUserEmailExists = errors.New("user email already exists")
+var ErrKitCodeExists = domainerrors.Conflict("kit_code_exists", "kit code already exists")
+var ErrUserEmailExists = ports.ErrUserEmailExists
+
+func (uc *RegisterUserUseCase) Run(ctx context.Context, firstName, lastName, email, password, kitCode, locale string) (entities.User, error) {
+	ctx, span := tracing.Start(ctx, "RegisterUserUseCase.Run")
+	defer span.End()
 
-func (uc *RegisterUserUseCase) Run(firstName, lastName, email, password, kitCode string) (entities.User, error) {
 	// 1. Check if Kit Code already exists
-	kitExists, err := uc.KitRepository.CheckKitNameExists(kitCode)
+	kitExists, err := uc.KitRepository.CheckKitNameExists(ctx, kitCode)
 	if err != nil {
 		// Log the error internally if needed
-		fmt.Printf("Error checking kit name existence: %v\n", err)
+		logging.FromContext(ctx).Error("Error checking kit name existence", "error", err)
 		return entities.User{}, fmt.Errorf("failed to validate kit code: %w", err)
 	}
 	if kitExists {
@@ -39,9 +46,9 @@ func (uc *RegisterUserUseCase) Run(firstName, lastName, email, password, kitCode
 	}
 
 	// 2. Check if Email already exists (optional but good practice)
-	emailExists, err := uc.UserRepository.CheckEmailExists(email)
+	emailExists, err := uc.UserRepository.CheckEmailExists(ctx, email)
 	if err != nil {
-		fmt.Printf("Error checking email existence: %v\n", err)
+		logging.FromContext(ctx).Error("Error checking email existence", "error", err)
 		return entities.User{}, fmt.Errorf("failed to validate email: %w", err)
 	}
 	if emailExists {
@@ -52,7 +59,7 @@ func (uc *RegisterUserUseCase) Run(firstName, lastName, email, password, kitCode
 	hashPass, err := uc.EncryptService.EncryptPassword([]byte(password))
 	if err != nil {
 		// Log the error internally if needed
-		fmt.Printf("Error encrypting password: %v\n", err)
+		logging.FromContext(ctx).Error("Error encrypting password", "error", err)
 		return entities.User{}, fmt.Errorf("failed to secure password: %w", err)
 	}
 
@@ -63,12 +70,15 @@ func (uc *RegisterUserUseCase) Run(firstName, lastName, email, password, kitCode
 		Email:     email,
 		Password:  hashPass, // Store the hashed password
 	}
+	defaults := preferences.Default()
+	defaults.Locale = locale
+	user.SetPreferences(defaults)
 
 	// 5. Create User in Repository
-	createdUser, err := uc.UserRepository.Create(user)
+	createdUser, err := uc.UserRepository.Create(ctx, user)
 	if err != nil {
 		// Log the error internally if needed
-		fmt.Printf("Error creating user in repository: %v\n", err)
+		logging.FromContext(ctx).Error("Error creating user in repository", "error", err)
 		// The repository might return a specific error for duplicates if CheckEmailExists wasn't used
 		return entities.User{}, fmt.Errorf("failed to register user: %w", err)
 	}
@@ -84,7 +94,7 @@ func (uc *RegisterUserUseCase) Run(firstName, lastName, email, password, kitCode
 	   _, err = uc.KitRepository.CreateKit(kit)
 	   if err != nil {
 	       // Handle kit creation failure - maybe rollback user creation? (complex transaction needed)
-	       fmt.Printf("Warning: User created (ID: %d) but failed to create kit entry: %v\n", createdUser.ID, err)
+	       logging.FromContext(ctx).Warn("User created but failed to create kit entry", "user_id", createdUser.ID, "error", err)
 	       // Depending on requirements, you might return an error here or just log it.
 	   }
 	*/
@@ -0,0 +1,43 @@
+package application
+
+import (
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/logging"
+	"api-order/src/shared/preferences"
+	"api-order/src/shared/tracing"
+	"api-order/src/user/domain/ports"
+	"context"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidTimezone = domainerrors.Validation("invalid_timezone", "timezone must be an IANA name such as Europe/Madrid")
+
+type UpdateUserPreferencesUseCase struct {
+	UserRepository ports.IUser
+}
+
+func NewUpdateUserPreferencesUseCase(userRepository ports.IUser) *UpdateUserPreferencesUseCase {
+	return &UpdateUserPreferencesUseCase{UserRepository: userRepository}
+}
+
+// Run replaces the display preferences of a user and returns the stored ones.
+func (uc *UpdateUserPreferencesUseCase) Run(ctx context.Context, id int64, prefs preferences.Preferences) (preferences.Preferences, error) {
+	ctx, span := tracing.Start(ctx, "UpdateUserPreferencesUseCase.Run")
+	defer span.End()
+
+	// "Local" would follow the clock of whichever server answers
+	if prefs.Timezone == "" || prefs.Timezone == "Local" {
+		return preferences.Preferences{}, ErrInvalidTimezone
+	}
+	if _, err := time.LoadLocation(prefs.Timezone); err != nil {
+		return preferences.Preferences{}, ErrInvalidTimezone.Wrap(err)
+	}
+
+	user, err := uc.UserRepository.UpdatePreferences(ctx, id, prefs)
+	if err != nil {
+		logging.FromContext(ctx).Error("Error updating user preferences", "user_id", id, "error", err)
+		return preferences.Preferences{}, fmt.Errorf("failed to update user preferences: %w", err)
+	}
+	return user.Preferences(), nil
+}
@@ -1,8 +1,11 @@
 package application
 
 import (
+	"api-order/src/shared/logging"
+	"api-order/src/shared/tracing"
 	"api-order/src/user/domain/entities"
 	"api-order/src/user/domain/ports"
+	"context"
 	"fmt"
 )
 
@@ -14,25 +17,31 @@ func NewUpdateUserUseCase(userRepository ports.IUser) *UpdateUserUseCase {
 	return &UpdateUserUseCase{UserRepository: userRepository}
 }
 
-// Consider adding password update logic separately if needed
-func (uc *UpdateUserUseCase) Run(id int64, firstName, lastName string) (entities.User, error) {
-	// You might want to fetch the user first to ensure they exist,
-	// though the Update operation in the repo might handle "not found".
-	// _, err := uc.UserRepository.GetById(id)
-	// if err != nil {
-	//     return entities.User{}, err // Propagate "not found" or other errors
-	// }
+// Consider adding password update logic separately if needed. An empty locale
+// keeps the language the user chose before.
+func (uc *UpdateUserUseCase) Run(ctx context.Context, id int64, firstName, lastName, locale string) (entities.User, error) {
+	ctx, span := tracing.Start(ctx, "UpdateUserUseCase.Run")
+	defer span.End()
+
+	if locale == "" {
+		current, err := uc.UserRepository.GetById(ctx, id)
+		if err != nil {
+			return entities.User{}, fmt.Errorf("failed to update user: %w", err)
+		}
+		locale = current.Locale
+	}
 
 	userToUpdate := entities.User{
 		FirstName: firstName,
 		LastName:  lastName,
+		Locale:    locale,
 		// Email is usually not updated or handled specially due to uniqueness
 		// Password update would require current password verification and hashing
 	}
 
-	updatedUser, err := uc.UserRepository.Update(id, userToUpdate)
+	updatedUser, err := uc.UserRepository.Update(ctx, id, userToUpdate)
 	if err != nil {
-		fmt.Printf("Error updating user ID %d: %v\n", id, err)
+		logging.FromContext(ctx).Error("Error updating user ID", "user_id", id, "error", err)
 		return entities.User{}, fmt.Errorf("failed to update user: %w", err)
 	}
 
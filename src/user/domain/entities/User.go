@@ -1,6 +1,9 @@
 package entities
 
-import "time"
+import (
+	"api-order/src/shared/preferences"
+	"time"
+)
 
 type User struct {
 	ID        int64     `json:"id"`
@@ -8,7 +11,12 @@ type User struct {
 	Password  string    `json:"-"` // Exclude password from default JSON responses
 	FirstName string    `json:"first_name"`
 	LastName  string    `json:"last_name"`
+	Locale    string    `json:"locale"` // Preferred language, e.g. "en"; empty follows Accept-Language
 	CreatedAt time.Time `json:"created_at"`
+	// Display preferences, see preferences.Preferences
+	TemperatureUnit       string `json:"temperature_unit"`
+	Timezone              string `json:"timezone"`
+	DashboardRangeMinutes int    `json:"dashboard_range_minutes"`
 }
 
 // UserResponse is used specifically for responses where password shouldn't be included
@@ -17,6 +25,7 @@ type UserResponse struct {
 	Email     string    `json:"email"`
 	FirstName string    `json:"first_name"`
 	LastName  string    `json:"last_name"`
+	Locale    string    `json:"locale"`
 	CreatedAt time.Time `json:"created_at"`
 }
 
@@ -26,6 +35,25 @@ func (u *User) ToResponse() UserResponse {
 		Email:     u.Email,
 		FirstName: u.FirstName,
 		LastName:  u.LastName,
+		Locale:    u.Locale,
 		CreatedAt: u.CreatedAt,
 	}
 }
+
+// Preferences returns the display preferences of the user.
+func (u *User) Preferences() preferences.Preferences {
+	return preferences.Preferences{
+		TemperatureUnit:       u.TemperatureUnit,
+		Timezone:              u.Timezone,
+		Locale:                u.Locale,
+		DashboardRangeMinutes: u.DashboardRangeMinutes,
+	}
+}
+
+// SetPreferences replaces the display preferences of the user.
+func (u *User) SetPreferences(p preferences.Preferences) {
+	u.TemperatureUnit = p.TemperatureUnit
+	u.Timezone = p.Timezone
+	u.Locale = p.Locale
+	u.DashboardRangeMinutes = p.DashboardRangeMinutes
+}
@@ -0,0 +1,10 @@
+package ports
+
+import "api-order/src/shared/domainerrors"
+
+var (
+	// ErrUserNotFound is returned by repositories when no user has the id or email.
+	ErrUserNotFound = domainerrors.NotFound("user_not_found", "user not found")
+	// ErrUserEmailExists is returned by Create when the email is already registered.
+	ErrUserEmailExists = domainerrors.Conflict("user_email_exists", "user email already exists")
+)
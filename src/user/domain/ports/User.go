@@ -1,11 +1,17 @@
 package ports
 
-import "api-order/src/user/domain/entities"
+import (
+	"api-order/src/shared/preferences"
+	"api-order/src/user/domain/entities"
+	"context"
+)
 
 type IUser interface {
-	Create(user entities.User) (entities.User, error)
-	GetById(id int64) (entities.User, error)
-	GetByEmail(email string) (entities.User, error)
-	Update(id int64, user entities.User) (entities.User, error)
-	CheckEmailExists(email string) (bool, error) // Helper for registration check
+	Create(ctx context.Context, user entities.User) (entities.User, error)
+	GetById(ctx context.Context, id int64) (entities.User, error)
+	GetByEmail(ctx context.Context, email string) (entities.User, error)
+	Update(ctx context.Context, id int64, user entities.User) (entities.User, error)
+	// UpdatePreferences stores the display preferences and locale of the user
+	UpdatePreferences(ctx context.Context, id int64, prefs preferences.Preferences) (entities.User, error)
+	CheckEmailExists(ctx context.Context, email string) (bool, error) // Helper for registration check
 }
@@ -0,0 +1,70 @@
+package adapters
+
+import (
+	"api-order/src/shared/preferences"
+	"api-order/src/user/domain/entities"
+	"api-order/src/user/domain/ports"
+	"context"
+	"sync"
+	"time"
+)
+
+type cachedUser struct {
+	user      entities.User
+	expiresAt time.Time
+}
+
+// UserRepositoryCached keeps the users looked up by ID in process memory, so
+// loading the preferences of every authenticated request does not query the
+// users table each time. Entries expire after ttl, so changes made through
+// other instances are picked up; the writes of this instance drop the entry.
+type UserRepositoryCached struct {
+	ports.IUser
+
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[int64]cachedUser
+}
+
+func NewUserRepositoryCached(inner ports.IUser, ttl time.Duration) *UserRepositoryCached {
+	return &UserRepositoryCached{IUser: inner, ttl: ttl, entries: make(map[int64]cachedUser)}
+}
+
+// GetById implements ports.IUser
+func (r *UserRepositoryCached) GetById(ctx context.Context, id int64) (entities.User, error) {
+	r.mu.Lock()
+	entry, ok := r.entries[id]
+	r.mu.Unlock()
+	if ok && time.Now().Before(entry.expiresAt) {
+		return entry.user, nil
+	}
+
+	user, err := r.IUser.GetById(ctx, id)
+	if err != nil {
+		return entities.User{}, err
+	}
+
+	r.mu.Lock()
+	r.entries[id] = cachedUser{user: user, expiresAt: time.Now().Add(r.ttl)}
+	r.mu.Unlock()
+	return user, nil
+}
+
+// Update implements ports.IUser
+func (r *UserRepositoryCached) Update(ctx context.Context, id int64, user entities.User) (entities.User, error) {
+	defer r.forget(id)
+	return r.IUser.Update(ctx, id, user)
+}
+
+// UpdatePreferences implements ports.IUser
+func (r *UserRepositoryCached) UpdatePreferences(ctx context.Context, id int64, prefs preferences.Preferences) (entities.User, error) {
+	defer r.forget(id)
+	return r.IUser.UpdatePreferences(ctx, id, prefs)
+}
+
+func (r *UserRepositoryCached) forget(id int64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.entries, id)
+}
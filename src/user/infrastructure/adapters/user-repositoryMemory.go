@@ -0,0 +1,109 @@
+package adapters
+
+import (
+	"api-order/src/shared/preferences"
+	"api-order/src/user/domain/entities"
+	"api-order/src/user/domain/ports"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// UserRepositoryMemory keeps users in process memory. It backs the HTTP test
+// suite and reports missing users with the same errors as the MySQL repository.
+type UserRepositoryMemory struct {
+	mu     sync.RWMutex
+	nextID int64
+	users  map[int64]entities.User
+}
+
+func NewUserRepositoryMemory() *UserRepositoryMemory {
+	return &UserRepositoryMemory{users: make(map[int64]entities.User)}
+}
+
+// Create implements ports.IUser
+func (r *UserRepositoryMemory) Create(ctx context.Context, user entities.User) (entities.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, existing := range r.users {
+		if existing.Email == user.Email {
+			return entities.User{}, ports.ErrUserEmailExists
+		}
+	}
+
+	r.nextID++
+	user.ID = r.nextID
+	user.CreatedAt = time.Now()
+	r.users[user.ID] = user
+	return user, nil
+}
+
+// GetByEmail implements ports.IUser
+func (r *UserRepositoryMemory) GetByEmail(ctx context.Context, email string) (entities.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, user := range r.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return entities.User{}, fmt.Errorf("user with email %s not found: %w", email, ports.ErrUserNotFound)
+}
+
+// GetById implements ports.IUser
+func (r *UserRepositoryMemory) GetById(ctx context.Context, id int64) (entities.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, ok := r.users[id]
+	if !ok {
+		return entities.User{}, fmt.Errorf("user with id %d not found: %w", id, ports.ErrUserNotFound)
+	}
+	return user, nil
+}
+
+// Update implements ports.IUser
+func (r *UserRepositoryMemory) Update(ctx context.Context, id int64, user entities.User) (entities.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.users[id]
+	if !ok {
+		return entities.User{}, fmt.Errorf("user with id %d not found for update: %w", id, ports.ErrUserNotFound)
+	}
+	existing.FirstName = user.FirstName
+	existing.LastName = user.LastName
+	existing.Locale = user.Locale
+	r.users[id] = existing
+	return existing, nil
+}
+
+// UpdatePreferences implements ports.IUser
+func (r *UserRepositoryMemory) UpdatePreferences(ctx context.Context, id int64, prefs preferences.Preferences) (entities.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.users[id]
+	if !ok {
+		return entities.User{}, fmt.Errorf("user with id %d not found for preferences update: %w", id, ports.ErrUserNotFound)
+	}
+	existing.SetPreferences(prefs)
+	r.users[id] = existing
+	return existing, nil
+}
+
+// CheckEmailExists implements ports.IUser
+func (r *UserRepositoryMemory) CheckEmailExists(ctx context.Context, email string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, user := range r.users {
+		if user.Email == email {
+			return true, nil
+		}
+	}
+	return false, nil
+}
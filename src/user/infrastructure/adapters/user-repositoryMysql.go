@@ -2,44 +2,44 @@ package adapters
 
 import (
 	database "api-order/src/Database" // Assuming Database package is at this path
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/preferences"
 	"api-order/src/user/domain/entities"
+	"api-order/src/user/domain/ports"
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
-	"strings"
 	"time"
 )
 
 type UserRepositoryMysql struct {
-	DB *sql.DB
+	DB       *sql.DB
+	Timeouts database.Timeouts
 }
 
-// Assuming a shared DB connection setup like in client
-func NewUserRepositoryMysql() (*UserRepositoryMysql, error) {
-	db, err := database.Connect()
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to database: %w", err)
-	}
-	// It's generally better to pass the *sql.DB instance in rather than connecting here
-	// e.g., func NewUserRepositoryMysql(db *sql.DB) *UserRepositoryMysql { return &UserRepositoryMysql{DB: db} }
-	// But following the client example pattern:
-	return &UserRepositoryMysql{DB: db}, nil
+// NewUserRepositoryMysql wraps the shared connection pool.
+func NewUserRepositoryMysql(db *sql.DB, timeouts database.Timeouts) *UserRepositoryMysql {
+	return &UserRepositoryMysql{DB: db, Timeouts: timeouts}
 }
 
-func (r *UserRepositoryMysql) Create(user entities.User) (entities.User, error) {
-	query := "INSERT INTO users (first_name, last_name, email, password, created_at) VALUES (?, ?, ?, ?, ?)"
-	stmt, err := r.DB.Prepare(query)
+func (r *UserRepositoryMysql) Create(ctx context.Context, user entities.User) (entities.User, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpWrite)
+	defer cancel()
+
+	query := "INSERT INTO users (first_name, last_name, email, password, locale, temperature_unit, timezone, dashboard_range_minutes, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)"
+	stmt, err := r.DB.PrepareContext(ctx, query)
 	if err != nil {
 		return entities.User{}, fmt.Errorf("failed to prepare user insert statement: %w", err)
 	}
 	defer stmt.Close()
 
 	now := time.Now()
-	result, err := stmt.Exec(user.FirstName, user.LastName, user.Email, user.Password, now)
+	result, err := stmt.ExecContext(ctx, user.FirstName, user.LastName, user.Email, user.Password, user.Locale, user.TemperatureUnit, user.Timezone, user.DashboardRangeMinutes, now)
 	if err != nil {
-		// Check for duplicate email error (adjust 'Error 1062' and 'users.email' if your DB differs)
-		if strings.Contains(err.Error(), "Error 1062") && strings.Contains(err.Error(), "users.email") {
-			return entities.User{}, err // Use the specific error
+		// email is the only unique column of users
+		if err = database.TranslateError(err); errors.Is(err, domainerrors.ErrDuplicate) {
+			return entities.User{}, ports.ErrUserEmailExists.Wrap(err)
 		}
 		return entities.User{}, fmt.Errorf("failed to execute user insert: %w", err)
 	}
@@ -56,15 +56,18 @@ func (r *UserRepositoryMysql) Create(user entities.User) (entities.User, error)
 	return user, nil
 }
 
-func (r *UserRepositoryMysql) GetByEmail(email string) (entities.User, error) {
-	query := "SELECT id, first_name, last_name, email, password, created_at FROM users WHERE email = ?"
-	row := r.DB.QueryRow(query, email)
+func (r *UserRepositoryMysql) GetByEmail(ctx context.Context, email string) (entities.User, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	query := "SELECT id, first_name, last_name, email, password, locale, temperature_unit, timezone, dashboard_range_minutes, created_at FROM users WHERE email = ?"
+	row := r.DB.QueryRowContext(ctx, query, email)
 
 	var user entities.User
-	err := row.Scan(&user.ID, &user.FirstName, &user.LastName, &user.Email, &user.Password, &user.CreatedAt)
+	err := row.Scan(&user.ID, &user.FirstName, &user.LastName, &user.Email, &user.Password, &user.Locale, &user.TemperatureUnit, &user.Timezone, &user.DashboardRangeMinutes, &user.CreatedAt)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return entities.User{}, fmt.Errorf("user with email %s not found: %w", email, err)
+			return entities.User{}, fmt.Errorf("user with email %s not found: %w", email, ports.ErrUserNotFound)
 		}
 		return entities.User{}, fmt.Errorf("failed to scan user row by email: %w", err)
 	}
@@ -72,30 +75,36 @@ func (r *UserRepositoryMysql) GetByEmail(email string) (entities.User, error) {
 	return user, nil
 }
 
-func (r *UserRepositoryMysql) GetById(id int64) (entities.User, error) {
-	query := "SELECT id, first_name, last_name, email, password, created_at FROM users WHERE id = ?"
-	row := r.DB.QueryRow(query, id)
+func (r *UserRepositoryMysql) GetById(ctx context.Context, id int64) (entities.User, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	query := "SELECT id, first_name, last_name, email, password, locale, temperature_unit, timezone, dashboard_range_minutes, created_at FROM users WHERE id = ?"
+	row := r.DB.QueryRowContext(ctx, query, id)
 
 	var user entities.User
-	err := row.Scan(&user.ID, &user.FirstName, &user.LastName, &user.Email, &user.Password, &user.CreatedAt)
+	err := row.Scan(&user.ID, &user.FirstName, &user.LastName, &user.Email, &user.Password, &user.Locale, &user.TemperatureUnit, &user.Timezone, &user.DashboardRangeMinutes, &user.CreatedAt)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return entities.User{}, fmt.Errorf("user with id %d not found: %w", id, err)
+			return entities.User{}, fmt.Errorf("user with id %d not found: %w", id, ports.ErrUserNotFound)
 		}
 		return entities.User{}, fmt.Errorf("failed to scan user row by id: %w", err)
 	}
 	return user, nil
 }
 
-func (r *UserRepositoryMysql) Update(id int64, user entities.User) (entities.User, error) {
-	query := "UPDATE users SET first_name = ?, last_name = ? WHERE id = ?"
-	stmt, err := r.DB.Prepare(query)
+func (r *UserRepositoryMysql) Update(ctx context.Context, id int64, user entities.User) (entities.User, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpWrite)
+	defer cancel()
+
+	query := "UPDATE users SET first_name = ?, last_name = ?, locale = ? WHERE id = ?"
+	stmt, err := r.DB.PrepareContext(ctx, query)
 	if err != nil {
 		return entities.User{}, fmt.Errorf("failed to prepare user update statement: %w", err)
 	}
 	defer stmt.Close()
 
-	result, err := stmt.Exec(user.FirstName, user.LastName, id)
+	result, err := stmt.ExecContext(ctx, user.FirstName, user.LastName, user.Locale, id)
 	if err != nil {
 		return entities.User{}, fmt.Errorf("failed to execute user update for ID %d: %w", id, err)
 	}
@@ -107,11 +116,11 @@ func (r *UserRepositoryMysql) Update(id int64, user entities.User) (entities.Use
 
 	if rowsAffected == 0 {
 		// This means the user ID didn't exist
-		return entities.User{}, fmt.Errorf("user with id %d not found for update", id)
+		return entities.User{}, fmt.Errorf("user with id %d not found for update: %w", id, ports.ErrUserNotFound)
 	}
 
 	// Fetch the updated user data to return
-	updatedUser, err := r.GetById(id)
+	updatedUser, err := r.GetById(ctx, id)
 	if err != nil {
 		// This shouldn't ideally happen if update succeeded, but handle it
 		return entities.User{}, fmt.Errorf("failed to fetch user data after update for ID %d: %w", id, err)
@@ -120,10 +129,31 @@ func (r *UserRepositoryMysql) Update(id int64, user entities.User) (entities.Use
 	return updatedUser, nil
 }
 
-func (r *UserRepositoryMysql) CheckEmailExists(email string) (bool, error) {
+// UpdatePreferences implements ports.IUser
+func (r *UserRepositoryMysql) UpdatePreferences(ctx context.Context, id int64, prefs preferences.Preferences) (entities.User, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpWrite)
+	defer cancel()
+
+	query := "UPDATE users SET temperature_unit = ?, timezone = ?, locale = ?, dashboard_range_minutes = ? WHERE id = ?"
+	if _, err := r.DB.ExecContext(ctx, query, prefs.TemperatureUnit, prefs.Timezone, prefs.Locale, prefs.DashboardRangeMinutes, id); err != nil {
+		return entities.User{}, fmt.Errorf("failed to execute preferences update for user ID %d: %w", id, err)
+	}
+
+	// Unchanged rows count as not affected in MySQL, so existence is checked by reading the user back
+	updatedUser, err := r.GetById(ctx, id)
+	if err != nil {
+		return entities.User{}, fmt.Errorf("failed to fetch user data after preferences update for ID %d: %w", id, err)
+	}
+	return updatedUser, nil
+}
+
+func (r *UserRepositoryMysql) CheckEmailExists(ctx context.Context, email string) (bool, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
 	query := "SELECT EXISTS(SELECT 1 FROM users WHERE email = ?)"
 	var exists bool
-	err := r.DB.QueryRow(query, email).Scan(&exists)
+	err := r.DB.QueryRowContext(ctx, query, email).Scan(&exists)
 	if err != nil {
 		return false, fmt.Errorf("failed to check email existence for %s: %w", email, err)
 	}
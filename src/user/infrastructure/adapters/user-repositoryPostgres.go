@@ -0,0 +1,132 @@
+package adapters
+
+import (
+	database "api-order/src/Database"
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/preferences"
+	"api-order/src/user/domain/entities"
+	"api-order/src/user/domain/ports"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+type UserRepositoryPostgres struct {
+	DB       *sql.DB
+	Timeouts database.Timeouts
+}
+
+// NewUserRepositoryPostgres wraps the shared connection pool.
+func NewUserRepositoryPostgres(db *sql.DB, timeouts database.Timeouts) *UserRepositoryPostgres {
+	return &UserRepositoryPostgres{DB: db, Timeouts: timeouts}
+}
+
+// Create implements ports.IUser
+func (r *UserRepositoryPostgres) Create(ctx context.Context, user entities.User) (entities.User, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpWrite)
+	defer cancel()
+
+	now := time.Now()
+	query := database.Rebind("INSERT INTO users (first_name, last_name, email, password, locale, temperature_unit, timezone, dashboard_range_minutes, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?) RETURNING id")
+	if err := r.DB.QueryRowContext(ctx, query, user.FirstName, user.LastName, user.Email, user.Password, user.Locale, user.TemperatureUnit, user.Timezone, user.DashboardRangeMinutes, now).Scan(&user.ID); err != nil {
+		// email is the only unique column of users
+		if err = database.TranslateError(err); errors.Is(err, domainerrors.ErrDuplicate) {
+			return entities.User{}, ports.ErrUserEmailExists.Wrap(err)
+		}
+		return entities.User{}, fmt.Errorf("failed to execute user insert: %w", err)
+	}
+
+	user.CreatedAt = now
+	return user, nil
+}
+
+// GetByEmail implements ports.IUser
+func (r *UserRepositoryPostgres) GetByEmail(ctx context.Context, email string) (entities.User, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	query := database.Rebind("SELECT id, first_name, last_name, email, password, locale, temperature_unit, timezone, dashboard_range_minutes, created_at FROM users WHERE email = ?")
+	var user entities.User
+	err := r.DB.QueryRowContext(ctx, query, email).Scan(&user.ID, &user.FirstName, &user.LastName, &user.Email, &user.Password, &user.Locale, &user.TemperatureUnit, &user.Timezone, &user.DashboardRangeMinutes, &user.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entities.User{}, fmt.Errorf("user with email %s not found: %w", email, ports.ErrUserNotFound)
+		}
+		return entities.User{}, fmt.Errorf("failed to scan user row by email: %w", err)
+	}
+	return user, nil
+}
+
+// GetById implements ports.IUser
+func (r *UserRepositoryPostgres) GetById(ctx context.Context, id int64) (entities.User, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	query := database.Rebind("SELECT id, first_name, last_name, email, password, locale, temperature_unit, timezone, dashboard_range_minutes, created_at FROM users WHERE id = ?")
+	var user entities.User
+	err := r.DB.QueryRowContext(ctx, query, id).Scan(&user.ID, &user.FirstName, &user.LastName, &user.Email, &user.Password, &user.Locale, &user.TemperatureUnit, &user.Timezone, &user.DashboardRangeMinutes, &user.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return entities.User{}, fmt.Errorf("user with id %d not found: %w", id, ports.ErrUserNotFound)
+		}
+		return entities.User{}, fmt.Errorf("failed to scan user row by id: %w", err)
+	}
+	return user, nil
+}
+
+// Update implements ports.IUser
+func (r *UserRepositoryPostgres) Update(ctx context.Context, id int64, user entities.User) (entities.User, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpWrite)
+	defer cancel()
+
+	result, err := r.DB.ExecContext(ctx, database.Rebind("UPDATE users SET first_name = ?, last_name = ?, locale = ? WHERE id = ?"), user.FirstName, user.LastName, user.Locale, id)
+	if err != nil {
+		return entities.User{}, fmt.Errorf("failed to execute user update for ID %d: %w", id, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return entities.User{}, fmt.Errorf("failed to get rows affected for user update ID %d: %w", id, err)
+	}
+	if rowsAffected == 0 {
+		return entities.User{}, fmt.Errorf("user with id %d not found for update: %w", id, ports.ErrUserNotFound)
+	}
+
+	updatedUser, err := r.GetById(ctx, id)
+	if err != nil {
+		return entities.User{}, fmt.Errorf("failed to fetch user data after update for ID %d: %w", id, err)
+	}
+	return updatedUser, nil
+}
+
+// UpdatePreferences implements ports.IUser
+func (r *UserRepositoryPostgres) UpdatePreferences(ctx context.Context, id int64, prefs preferences.Preferences) (entities.User, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpWrite)
+	defer cancel()
+
+	query := database.Rebind("UPDATE users SET temperature_unit = ?, timezone = ?, locale = ?, dashboard_range_minutes = ? WHERE id = ?")
+	if _, err := r.DB.ExecContext(ctx, query, prefs.TemperatureUnit, prefs.Timezone, prefs.Locale, prefs.DashboardRangeMinutes, id); err != nil {
+		return entities.User{}, fmt.Errorf("failed to execute preferences update for user ID %d: %w", id, err)
+	}
+
+	// Unchanged rows count as not affected in MySQL, so existence is checked by reading the user back
+	updatedUser, err := r.GetById(ctx, id)
+	if err != nil {
+		return entities.User{}, fmt.Errorf("failed to fetch user data after preferences update for ID %d: %w", id, err)
+	}
+	return updatedUser, nil
+}
+
+// CheckEmailExists implements ports.IUser
+func (r *UserRepositoryPostgres) CheckEmailExists(ctx context.Context, email string) (bool, error) {
+	ctx, cancel := r.Timeouts.WithTimeout(ctx, database.OpRead)
+	defer cancel()
+
+	var exists bool
+	if err := r.DB.QueryRowContext(ctx, database.Rebind("SELECT EXISTS(SELECT 1 FROM users WHERE email = ?)"), email).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check email existence for %s: %w", email, err)
+	}
+	return exists, nil
+}
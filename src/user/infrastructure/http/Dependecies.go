@@ -2,62 +2,59 @@ package http
 
 import (
 	kit "api-order/src/kit/domain/ports"
-	kitAdpt "api-order/src/kit/infrastructure/adapters"
 	"api-order/src/user/application"
 	"api-order/src/user/application/services"
 	"api-order/src/user/domain/ports"
-	"api-order/src/user/infrastructure/adapters"
 	"api-order/src/user/infrastructure/http/controllers"
 	"api-order/src/user/infrastructure/http/controllers/helpers" // User's helpers
-	"log"
+	"fmt"
 )
 
-var (
-	userRepository ports.IUser
-	kitRepository  kit.IKit
-	encryptService services.IEncrypt // User's encrypt service interface
-)
-
-// Initialize dependencies for the User feature
-// Note: This init might run alongside the client's init if both packages are imported.
-// Consider a central dependency management approach.
-func init() {
-	var err error
-	userRepository, err = adapters.NewUserRepositoryMysql()
-	if err != nil {
-		log.Fatalf("Error initializing user repository: %v", err)
-	}
-
-	kitRepository, err = kitAdpt.NewKitRepositoryMysql()
-	if err != nil {
-		log.Fatalf("Error initializing kit repository: %v", err)
-	}
+// Dependencies holds what the user controllers need. It is built by the
+// application container and handed to UserRoutes.
+type Dependencies struct {
+	UserRepository ports.IUser
+	KitRepository  kit.IKit
+	EncryptService services.IEncrypt // User's encrypt service interface
+}
 
+func NewDependencies(userRepository ports.IUser, kitRepository kit.IKit) (*Dependencies, error) {
 	// Use the user's helper for IEncrypt interface
-	encryptService, err = helpers.NewBcryptHelper()
+	encryptService, err := helpers.NewBcryptHelper()
 	if err != nil {
-		log.Fatalf("Error initializing user encrypt service: %v", err)
+		return nil, fmt.Errorf("error initializing user encrypt service: %w", err)
 	}
+	return &Dependencies{UserRepository: userRepository, KitRepository: kitRepository, EncryptService: encryptService}, nil
 }
 
 // Setup functions for User controllers
 
-func SetUpRegisterUserController() *controllers.RegisterUserController {
-	registerUseCase := application.NewRegisterUserUseCase(userRepository, kitRepository, encryptService)
+func (d *Dependencies) SetUpRegisterUserController() *controllers.RegisterUserController {
+	registerUseCase := application.NewRegisterUserUseCase(d.UserRepository, d.KitRepository, d.EncryptService)
 	return controllers.NewRegisterUserController(registerUseCase)
 }
 
-func SetUpLoginController() *controllers.LoginController {
-	loginUseCase := application.NewLoginUseCase(userRepository, encryptService)
+func (d *Dependencies) SetUpLoginController() *controllers.LoginController {
+	loginUseCase := application.NewLoginUseCase(d.UserRepository, d.EncryptService)
 	return controllers.NewLoginController(loginUseCase)
 }
 
-func SetUpGetUserByIdController() *controllers.GetUserByIdController {
-	getUserUseCase := application.NewGetUserByIdUseCase(userRepository)
+func (d *Dependencies) SetUpGetUserByIdController() *controllers.GetUserByIdController {
+	getUserUseCase := application.NewGetUserByIdUseCase(d.UserRepository)
 	return controllers.NewGetUserByIdController(getUserUseCase)
 }
 
-func SetUpUpdateUserController() *controllers.UpdateUserController {
-	updateUserUseCase := application.NewUpdateUserUseCase(userRepository)
+func (d *Dependencies) SetUpUpdateUserController() *controllers.UpdateUserController {
+	updateUserUseCase := application.NewUpdateUserUseCase(d.UserRepository)
 	return controllers.NewUpdateUserController(updateUserUseCase)
 }
+
+func (d *Dependencies) SetUpGetUserPreferencesController() *controllers.GetUserPreferencesController {
+	getPreferencesUseCase := application.NewGetUserPreferencesUseCase(d.UserRepository)
+	return controllers.NewGetUserPreferencesController(getPreferencesUseCase)
+}
+
+func (d *Dependencies) SetUpUpdateUserPreferencesController() *controllers.UpdateUserPreferencesController {
+	updatePreferencesUseCase := application.NewUpdateUserPreferencesUseCase(d.UserRepository)
+	return controllers.NewUpdateUserPreferencesController(updatePreferencesUseCase)
+}
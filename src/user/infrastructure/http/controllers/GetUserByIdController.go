@@ -1,13 +1,12 @@
 package controllers
 
 import (
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/i18n"
 	"api-order/src/shared/responses"
 	"api-order/src/user/application"
-	"database/sql"
-	"errors"
 	"net/http"
 	"strconv"
-	"strings"
 
 	"github.com/gin-gonic/gin"
 )
@@ -32,6 +31,7 @@ func NewGetUserByIdController(userService *application.GetUserByIdUseCase) *GetU
 // @Failure      403  {object}  responses.Response "Forbidden - User attempting to access another user's data (if implemented)"
 // @Failure      404  {object}  responses.Response "User not found"
 // @Failure      500  {object}  responses.Response "Internal server error while retrieving user"
+// @Failure      504  {object}  responses.Response "Operation timed out"
 // @Router       /v1/users/{id} [get]
 // @Security     BearerAuth
 func (ctr *GetUserByIdController) Run(ctx *gin.Context) {
@@ -39,9 +39,7 @@ func (ctr *GetUserByIdController) Run(ctx *gin.Context) {
 	id, err := strconv.ParseInt(idParam, 10, 64)
 
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, responses.Response{
-			Success: false, Message: "ID de usuario inválido.", Error: err.Error(), Data: nil,
-		})
+		ctx.Error(domainerrors.InvalidParameter("id", "must be an integer"))
 		return
 	}
 
@@ -49,28 +47,19 @@ func (ctr *GetUserByIdController) Run(ctx *gin.Context) {
 	// userIDFromToken, exists := ctx.Get("userID") // Assuming middleware sets this
 	// if !exists || userIDFromToken.(int64) != id {
 	//     // You might allow admins to bypass this check based on a role claim in the token
-	//     ctx.JSON(http.StatusForbidden, responses.Response{
-	//         Success: false, Message: "Acceso denegado.", Error: "Forbidden", Data: nil,
-	//     })
+	//     ctx.Error(domainerrors.Forbidden("user_not_owned", "cannot read another user's data"))
 	//     return
 	// }
 
-	user, err := ctr.UserService.Run(id)
+	user, err := ctr.UserService.Run(ctx.Request.Context(), id)
 
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) || strings.Contains(err.Error(), "not found") {
-			ctx.JSON(http.StatusNotFound, responses.Response{
-				Success: false, Message: "Usuario no encontrado.", Error: "User not found", Data: nil,
-			})
-		} else {
-			ctx.JSON(http.StatusInternalServerError, responses.Response{
-				Success: false, Message: "Error al obtener el usuario.", Error: "Internal server error", Data: nil,
-			})
-		}
+		// ports.ErrUserNotFound answers 404, see middlewares.ErrorHandler
+		ctx.Error(err)
 		return
 	}
 
 	ctx.JSON(http.StatusOK, responses.Response{
-		Success: true, Message: "Usuario obtenido con éxito.", Error: nil, Data: user.ToResponse(), // Return response without password
+		Success: true, Message: i18n.T(ctx.Request.Context(), "user.retrieved"), Error: nil, Data: user.ToResponse(), // Return response without password
 	})
 }
@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/responses"
+	"api-order/src/user/application"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type GetUserPreferencesController struct {
+	UseCase *application.GetUserPreferencesUseCase
+}
+
+func NewGetUserPreferencesController(useCase *application.GetUserPreferencesUseCase) *GetUserPreferencesController {
+	return &GetUserPreferencesController{UseCase: useCase}
+}
+
+// @Summary      Get user preferences
+// @Description  Returns the temperature unit, timezone, language and default dashboard range of the authenticated user. Garden data and statistics responses are rendered with them.
+// @Tags         Users
+// @Produce      json
+// @Param        Authorization header string true "Bearer Token"
+// @Param        id path int true "User ID" Format(int64)
+// @Success      200  {object}  responses.Response{data=preferences.Preferences} "Preferences retrieved successfully"
+// @Failure      400  {object}  responses.Response "Invalid user ID"
+// @Failure      401  {object}  responses.Response "Unauthorized - Invalid or missing token"
+// @Failure      403  {object}  responses.Response "Forbidden - User attempting to read another user's preferences"
+// @Failure      404  {object}  responses.Response "User not found"
+// @Failure      500  {object}  responses.Response "Internal server error"
+// @Failure      504  {object}  responses.Response "Operation timed out"
+// @Router       /v1/users/{id}/preferences [get]
+// @Security     BearerAuth
+func (ctr *GetUserPreferencesController) Run(ctx *gin.Context) {
+	id, ok := ownUserID(ctx)
+	if !ok {
+		return
+	}
+
+	prefs, err := ctr.UseCase.Run(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, responses.Response{
+		Success: true,
+		Message: i18n.T(ctx.Request.Context(), "user.preferences_retrieved"),
+		Data:    prefs,
+	})
+}
@@ -1,21 +1,22 @@
 package controllers
 
 import (
+	"api-order/src/shared/i18n"
 	"api-order/src/shared/middlewares" // Assuming JWT generation is here
+	"api-order/src/shared/monitoring"
 	"api-order/src/shared/responses"
+	"api-order/src/shared/validation"
 	"api-order/src/user/application"
+	"api-order/src/user/domain/ports"
 	"api-order/src/user/infrastructure/http/request"
-	"database/sql"
 	"errors"
+	"fmt"
 	"net/http"
-	"strings"
 
 	// Use alias if needed to avoid conflict if client also has entities
 	userEntities "api-order/src/user/domain/entities"
 
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
-	"golang.org/x/crypto/bcrypt" // Needed for bcrypt.ErrMismatchedHashAndPassword
 )
 
 // LoginResponseData defines the structure for the successful login response data
@@ -26,14 +27,12 @@ type LoginResponseData struct {
 
 type LoginController struct {
 	UserService *application.LoginUseCase
-	Validator   *validator.Validate
 	// BcryptHelper is used within the use case now
 }
 
 func NewLoginController(userService *application.LoginUseCase) *LoginController {
 	return &LoginController{
 		UserService: userService,
-		Validator:   validator.New(),
 	}
 }
 
@@ -48,59 +47,42 @@ func NewLoginController(userService *application.LoginUseCase) *LoginController
 // @Failure      401  {object}  responses.Response "Incorrect password or invalid credentials"
 // @Failure      404  {object}  responses.Response "Email not found"
 // @Failure      500  {object}  responses.Response "Internal server error during login or token generation"
+// @Failure      504  {object}  responses.Response "Operation timed out"
 // @Router       /v1/users/login [post]
 func (ctr *LoginController) Run(ctx *gin.Context) {
 	var req request.LoginRequest
 
-	// Bind and validate JSON input
-	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, responses.Response{
-			Success: false, Message: "Error procesando la solicitud. Verifique los campos.", Error: err.Error(), Data: nil,
-		})
-		return
-	}
-	if err := ctr.Validator.Struct(req); err != nil {
-		ctx.JSON(http.StatusBadRequest, responses.Response{
-			Success: false, Message: "Datos de login inválidos.", Error: err.Error(), Data: nil,
-		})
+	// Bind and validate the JSON body
+	if err := validation.BindJSON(ctx, &req); err != nil {
+		monitoring.LoginFailures.WithLabelValues(monitoring.ReasonInvalidRequest).Inc()
+		ctx.Error(err)
 		return
 	}
 
 	// Execute login use case
-	user, err := ctr.UserService.Run(req.Email, req.Password)
+	user, err := ctr.UserService.Run(ctx.Request.Context(), req.Email, req.Password)
 
 	// Handle errors from use case
 	if err != nil {
-		// Check for "not found" error (specific check for sql.ErrNoRows is good)
-		if errors.Is(err, sql.ErrNoRows) || strings.Contains(err.Error(), "not found") { // Check message if Is doesn't work across layers
-			ctx.JSON(http.StatusNotFound, responses.Response{
-				Success: false, Message: "El email no está registrado.", Error: "Email not found", Data: nil,
-			})
-			return
+		switch {
+		case responses.IsTimeout(err):
+			monitoring.LoginFailures.WithLabelValues(monitoring.ReasonTimeout).Inc()
+		case errors.Is(err, ports.ErrUserNotFound):
+			monitoring.LoginFailures.WithLabelValues(monitoring.ReasonUnknownEmail).Inc()
+		case errors.Is(err, application.ErrInvalidCredentials):
+			monitoring.LoginFailures.WithLabelValues(monitoring.ReasonInvalidCredentials).Inc()
+		default:
+			monitoring.LoginFailures.WithLabelValues(monitoring.ReasonInternalError).Inc()
 		}
-		// Check for invalid credentials / password mismatch
-		// Compare directly with bcrypt error or the specific error string from the use case
-		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) || (err != nil && err.Error() == "invalid credentials") {
-			ctx.JSON(http.StatusUnauthorized, responses.Response{
-				Success: false, Message: "Contraseña incorrecta.", Error: "Invalid credentials", Data: nil,
-			})
-			return
-		}
-
-		// Handle other internal errors
-		ctx.JSON(http.StatusInternalServerError, responses.Response{
-			Success: false, Message: "Error al intentar iniciar sesión.", Error: "Internal server error", Data: nil,
-		})
+		ctx.Error(err)
 		return
 	}
 
 	// Generate JWT Token
-	// Assuming GenerateJWT takes user ID (int64) and email (string)
-	token, err := middlewares.GenerateJWT(user.ID, user.Email)
+	// The token carries the preferred language to the requests that follow
+	token, err := middlewares.GenerateJWT(user.ID, user.Email, user.Locale)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, responses.Response{
-			Success: false, Message: "Error al generar el token de sesión.", Error: "Failed to generate token", Data: nil,
-		})
+		ctx.Error(fmt.Errorf("failed to generate token: %w", err))
 		return
 	}
 
@@ -111,7 +93,8 @@ func (ctr *LoginController) Run(ctx *gin.Context) {
 	}
 
 	// Send success response
+	i18n.SetLocale(ctx, user.Locale)
 	ctx.JSON(http.StatusOK, responses.Response{
-		Success: true, Message: "Sesión iniciada con éxito.", Error: nil, Data: responseData,
+		Success: true, Message: i18n.T(ctx.Request.Context(), "user.logged_in"), Error: nil, Data: responseData,
 	})
 }
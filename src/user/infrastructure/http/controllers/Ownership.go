@@ -0,0 +1,36 @@
+package controllers
+
+import (
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/middlewares"
+	"errors"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ownUserID returns the id path parameter when it is the authenticated user.
+// Otherwise it reports the failure with ctx.Error and returns false.
+func ownUserID(ctx *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.Error(domainerrors.InvalidParameter("id", "must be an integer"))
+		return 0, false
+	}
+
+	claimsData, exists := ctx.Get("datUser") // Set by JWTAuthMiddleware
+	if !exists {
+		ctx.Error(middlewares.ErrMissingToken)
+		return 0, false
+	}
+	claims, ok := claimsData.(*middlewares.CustomClaims)
+	if !ok {
+		ctx.Error(errors.New("invalid user claims in context"))
+		return 0, false
+	}
+	if claims.ClientID != id {
+		ctx.Error(ErrUserNotOwned)
+		return 0, false
+	}
+	return id, true
+}
@@ -1,33 +1,35 @@
 package controllers
 
 import (
+	"api-order/src/shared/domainerrors"
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/middlewares"
 	"api-order/src/shared/responses"
+	"api-order/src/shared/validation"
 	"api-order/src/user/application"
 	"api-order/src/user/infrastructure/http/request"
-	"database/sql" // Required for sql.ErrNoRows if checking that specifically
-	"errors"       // Required for errors.Is
+	"errors"
 	"net/http"
 	"strconv"
-	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/go-playground/validator/v10"
 )
 
+// ErrUserNotOwned is returned when a user tries to update someone else.
+var ErrUserNotOwned = domainerrors.Forbidden("user_not_owned", "cannot modify another user's data")
+
 type UpdateUserController struct {
 	UserService *application.UpdateUserUseCase
-	Validator   *validator.Validate
 }
 
 func NewUpdateUserController(userService *application.UpdateUserUseCase) *UpdateUserController {
 	return &UpdateUserController{
 		UserService: userService,
-		Validator:   validator.New(),
 	}
 }
 
 // @Summary      Update user information
-// @Description  Updates the first name and last name for the specified user ID. Requires authentication, and users can typically only update their own data.
+// @Description  Updates the first name, last name and preferred language for the specified user ID. Requires authentication, and users can typically only update their own data.
 // @Tags         Users
 // @Accept       json
 // @Produce      json
@@ -40,6 +42,7 @@ func NewUpdateUserController(userService *application.UpdateUserUseCase) *Update
 // @Failure      403  {object}  responses.Response "Forbidden - User attempting to update another user's data"
 // @Failure      404  {object}  responses.Response "User not found"
 // @Failure      500  {object}  responses.Response "Internal server error during update"
+// @Failure      504  {object}  responses.Response "Operation timed out"
 // @Router       /v1/users/{id} [put]
 // @Security     BearerAuth
 func (ctr *UpdateUserController) Run(ctx *gin.Context) {
@@ -47,75 +50,50 @@ func (ctr *UpdateUserController) Run(ctx *gin.Context) {
 	idParam := ctx.Param("id")
 	id, err := strconv.ParseInt(idParam, 10, 64)
 	if err != nil {
-		ctx.JSON(http.StatusBadRequest, responses.Response{
-			Success: false, Message: "ID de usuario inválido.", Error: err.Error(), Data: nil,
-		})
+		ctx.Error(domainerrors.InvalidParameter("id", "must be an integer"))
 		return
 	}
 
 	// 2. Authorization Check: Ensure the authenticated user matches the ID being updated
-	userIDFromToken, exists := ctx.Get("userID") // Assuming middleware sets "userID" as int64
+	claimsData, exists := ctx.Get("datUser") // Set by JWTAuthMiddleware
 	if !exists {
-		ctx.JSON(http.StatusUnauthorized, responses.Response{
-			Success: false, Message: "No autorizado: Falta información de usuario.", Error: "Missing user context", Data: nil,
-		})
+		ctx.Error(middlewares.ErrMissingToken)
 		return
 	}
-	// Type assertion to ensure it's int64
-	authenticatedUserID, ok := userIDFromToken.(int64)
+	claims, ok := claimsData.(*middlewares.CustomClaims)
 	if !ok {
-		ctx.JSON(http.StatusInternalServerError, responses.Response{
-			Success: false, Message: "Error interno del servidor.", Error: "Invalid user ID type in context", Data: nil,
-		})
+		ctx.Error(errors.New("invalid user claims in context"))
 		return
 	}
+	authenticatedUserID := claims.ClientID
 
 	if authenticatedUserID != id {
 		// Optional: Allow admins based on role check here
 		// roleFromToken, _ := ctx.Get("role")
 		// if roleFromToken != "admin" { ... }
-		ctx.JSON(http.StatusForbidden, responses.Response{
-			Success: false, Message: "Acceso denegado: No puedes modificar datos de otro usuario.", Error: "Forbidden", Data: nil,
-		})
+		ctx.Error(ErrUserNotOwned)
 		return
 	}
 
 	// 3. Bind and Validate Request Body
 	var req request.UpdateUserRequest
-	if err := ctx.ShouldBindJSON(&req); err != nil {
-		ctx.JSON(http.StatusBadRequest, responses.Response{
-			Success: false, Message: "Error procesando la solicitud. Verifique los campos.", Error: err.Error(), Data: nil,
-		})
-		return
-	}
-	if err := ctr.Validator.Struct(req); err != nil {
-		ctx.JSON(http.StatusBadRequest, responses.Response{
-			Success: false, Message: "Datos inválidos proporcionados.", Error: err.Error(), Data: nil,
-		})
+	if err := validation.BindJSON(ctx, &req); err != nil {
+		ctx.Error(err)
 		return
 	}
 
 	// 4. Execute Update Use Case
-	updatedUser, err := ctr.UserService.Run(id, req.FirstName, req.LastName)
+	updatedUser, err := ctr.UserService.Run(ctx.Request.Context(), id, req.FirstName, req.LastName, req.Locale)
 
-	// 5. Handle Errors
+	// 5. Handle Errors (ports.ErrUserNotFound answers 404)
 	if err != nil {
-		// Check if the error indicates the user wasn't found for update
-		if errors.Is(err, sql.ErrNoRows) || strings.Contains(err.Error(), "not found") {
-			ctx.JSON(http.StatusNotFound, responses.Response{
-				Success: false, Message: "Usuario no encontrado para actualizar.", Error: "User not found", Data: nil,
-			})
-		} else {
-			// Handle other potential errors (DB connection issues, etc.)
-			ctx.JSON(http.StatusInternalServerError, responses.Response{
-				Success: false, Message: "Error al actualizar el usuario.", Error: "Internal server error", Data: nil,
-			})
-		}
+		ctx.Error(err)
 		return
 	}
 
-	// 6. Return Success Response
+	// 6. Return Success Response, in the language the user just chose
+	i18n.SetLocale(ctx, updatedUser.Locale)
 	ctx.JSON(http.StatusOK, responses.Response{
-		Success: true, Message: "Usuario actualizado correctamente.", Error: nil, Data: updatedUser.ToResponse(), // Use response struct
+		Success: true, Message: i18n.T(ctx.Request.Context(), "user.updated"), Error: nil, Data: updatedUser.ToResponse(), // Use response struct
 	})
 }
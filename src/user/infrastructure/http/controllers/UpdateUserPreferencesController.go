@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"api-order/src/shared/i18n"
+	"api-order/src/shared/preferences"
+	"api-order/src/shared/responses"
+	"api-order/src/shared/validation"
+	"api-order/src/user/application"
+	"api-order/src/user/infrastructure/http/request"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UpdateUserPreferencesController struct {
+	UseCase *application.UpdateUserPreferencesUseCase
+}
+
+func NewUpdateUserPreferencesController(useCase *application.UpdateUserPreferencesUseCase) *UpdateUserPreferencesController {
+	return &UpdateUserPreferencesController{UseCase: useCase}
+}
+
+// @Summary      Update user preferences
+// @Description  Replaces the temperature unit (C or F), IANA timezone, language and default dashboard range in minutes of the authenticated user. They apply to the next request, without logging in again.
+// @Tags         Users
+// @Accept       json
+// @Produce      json
+// @Param        Authorization header string true "Bearer Token"
+// @Param        id path int true "User ID" Format(int64)
+// @Param        preferences body request.UpdatePreferencesRequest true "New preferences"
+// @Success      200  {object}  responses.Response{data=preferences.Preferences} "Preferences updated successfully"
+// @Failure      400  {object}  responses.Response "Invalid user ID, validation failed or unknown timezone"
+// @Failure      401  {object}  responses.Response "Unauthorized - Invalid or missing token"
+// @Failure      403  {object}  responses.Response "Forbidden - User attempting to update another user's preferences"
+// @Failure      404  {object}  responses.Response "User not found"
+// @Failure      500  {object}  responses.Response "Internal server error"
+// @Failure      504  {object}  responses.Response "Operation timed out"
+// @Router       /v1/users/{id}/preferences [put]
+// @Security     BearerAuth
+func (ctr *UpdateUserPreferencesController) Run(ctx *gin.Context) {
+	id, ok := ownUserID(ctx)
+	if !ok {
+		return
+	}
+
+	var req request.UpdatePreferencesRequest
+	if err := validation.BindJSON(ctx, &req); err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	// application.ErrInvalidTimezone answers 400
+	prefs, err := ctr.UseCase.Run(ctx.Request.Context(), id, preferences.Preferences{
+		TemperatureUnit:       req.TemperatureUnit,
+		Timezone:              req.Timezone,
+		Locale:                req.Locale,
+		DashboardRangeMinutes: req.DashboardRangeMinutes,
+	})
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	// Answer in the language the user just chose
+	i18n.SetLocale(ctx, prefs.Locale)
+	ctx.JSON(http.StatusOK, responses.Response{
+		Success: true,
+		Message: i18n.T(ctx.Request.Context(), "user.preferences_updated"),
+		Data:    prefs,
+	})
+}
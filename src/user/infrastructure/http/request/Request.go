@@ -7,6 +7,8 @@ type RegisterUserRequest struct {
 	Email     string `json:"email" validate:"required,email"`
 	Password  string `json:"password" validate:"required,min=6"`
 	KitCode   string `json:"kit_code" validate:"required"` // El código de kit
+	// Locale is the preferred language; empty follows Accept-Language
+	Locale string `json:"locale" validate:"omitempty,oneof=es en"`
 }
 
 // Request body for user login
@@ -15,8 +17,19 @@ type LoginRequest struct {
 	Password string `json:"password" validate:"required"`
 }
 
-// Request body for updating user info (first name, last name)
+// Request body for updating user info (first name, last name, language)
 type UpdateUserRequest struct {
 	FirstName string `json:"first_name" validate:"required"`
 	LastName  string `json:"last_name" validate:"required"`
+	// Locale keeps the stored preference when omitted
+	Locale string `json:"locale" validate:"omitempty,oneof=es en"`
+}
+
+// Request body for replacing the display preferences of a user
+type UpdatePreferencesRequest struct {
+	TemperatureUnit string `json:"temperature_unit" validate:"required,oneof=C F"`
+	Timezone        string `json:"timezone" validate:"required"` // Nombre IANA, p. ej. Europe/Madrid
+	// Locale is the preferred language; empty follows Accept-Language
+	Locale                string `json:"locale" validate:"omitempty,oneof=es en"`
+	DashboardRangeMinutes int    `json:"dashboard_range_minutes" validate:"required,min=1,max=43200"`
 }
@@ -7,12 +7,14 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func UserRoutes(router *gin.RouterGroup) {
+func UserRoutes(router *gin.RouterGroup, deps *http.Dependencies) {
 	// Instantiate controllers using the setup functions
-	registerController := http.SetUpRegisterUserController()
-	loginController := http.SetUpLoginController()
-	getUserController := http.SetUpGetUserByIdController()
-	updateUserController := http.SetUpUpdateUserController()
+	registerController := deps.SetUpRegisterUserController()
+	loginController := deps.SetUpLoginController()
+	getUserController := deps.SetUpGetUserByIdController()
+	updateUserController := deps.SetUpUpdateUserController()
+	getPreferencesController := deps.SetUpGetUserPreferencesController()
+	updatePreferencesController := deps.SetUpUpdateUserPreferencesController()
 
 	// Public routes
 	router.POST("/", registerController.Run)   // Register User
@@ -23,7 +25,9 @@ func UserRoutes(router *gin.RouterGroup) {
 	authorized := router.Group("/")
 	authorized.Use(middlewares.JWTAuthMiddleware()) // Apply your JWT auth middleware
 	{
-		authorized.GET("/:id", getUserController.Run)    // Get User By ID
-		authorized.PUT("/:id", updateUserController.Run) // Update User
+		authorized.GET("/:id", getUserController.Run)                       // Get User By ID
+		authorized.PUT("/:id", updateUserController.Run)                    // Update User
+		authorized.GET("/:id/preferences", getPreferencesController.Run)    // Units, timezone and language
+		authorized.PUT("/:id/preferences", updatePreferencesController.Run) // Replace them
 	}
 }